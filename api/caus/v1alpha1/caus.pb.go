@@ -0,0 +1,948 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.9
+// source: v1alpha1/caus.proto
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/w-h-a/caus/api/causal/v1alpha1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Run is the data of a request: the variables and the window to fetch, as
+// vars.yml and the window flags of the CLI give them.
+type Run struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// a vars.yml document, in YAML or JSON; its sources must be served by the
+	// server, which fills in their credentials
+	Vars string `protobuf:"bytes,1,opt,name=vars,proto3" json:"vars,omitempty"`
+	// a duration ago ("3h"), an RFC3339 timestamp or unix seconds; empty
+	// means 2h ago, as in the CLI
+	Start string `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// same forms as start; empty means 5m ago, as in the CLI
+	End string `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Go duration, e.g. "1m"; empty means 1m
+	Step string `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`
+	// detrend or difference the non-stationary variables
+	MakeStationary bool `protobuf:"varint,5,opt,name=make_stationary,json=makeStationary,proto3" json:"make_stationary,omitempty"`
+	// windows to leave out, "start/end" in the forms of start and end
+	Exclude       []string `protobuf:"bytes,6,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Run) Reset() {
+	*x = Run{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Run) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Run) ProtoMessage() {}
+
+func (x *Run) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Run.ProtoReflect.Descriptor instead.
+func (*Run) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Run) GetVars() string {
+	if x != nil {
+		return x.Vars
+	}
+	return ""
+}
+
+func (x *Run) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *Run) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *Run) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *Run) GetMakeStationary() bool {
+	if x != nil {
+		return x.MakeStationary
+	}
+	return false
+}
+
+func (x *Run) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+type DiscoverRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Run     *Run                   `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	MaxLag  int32                  `protobuf:"varint,2,opt,name=max_lag,json=maxLag,proto3" json:"max_lag,omitempty"`
+	PcAlpha float32                `protobuf:"fixed32,3,opt,name=pc_alpha,json=pcAlpha,proto3" json:"pc_alpha,omitempty"`
+	// drop edges whose absolute partial correlation is below this
+	MinStrength   float32 `protobuf:"fixed32,4,opt,name=min_strength,json=minStrength,proto3" json:"min_strength,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverRequest) Reset() {
+	*x = DiscoverRequest{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverRequest) ProtoMessage() {}
+
+func (x *DiscoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiscoverRequest) GetRun() *Run {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *DiscoverRequest) GetMaxLag() int32 {
+	if x != nil {
+		return x.MaxLag
+	}
+	return 0
+}
+
+func (x *DiscoverRequest) GetPcAlpha() float32 {
+	if x != nil {
+		return x.PcAlpha
+	}
+	return 0
+}
+
+func (x *DiscoverRequest) GetMinStrength() float32 {
+	if x != nil {
+		return x.MinStrength
+	}
+	return 0
+}
+
+type EstimateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Run   *Run                   `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	Graph *v1alpha1.CausalGraph  `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	// skip the edges of the graph that aren't directed instead of failing
+	AllowUnoriented bool `protobuf:"varint,3,opt,name=allow_unoriented,json=allowUnoriented,proto3" json:"allow_unoriented,omitempty"`
+	// confidence level for the coefficient intervals; 0 means 0.95
+	ConfidenceLevel float32             `protobuf:"fixed32,4,opt,name=confidence_level,json=confidenceLevel,proto3" json:"confidence_level,omitempty"`
+	Bootstrap       *v1alpha1.Bootstrap `protobuf:"bytes,5,opt,name=bootstrap,proto3" json:"bootstrap,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EstimateRequest) Reset() {
+	*x = EstimateRequest{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateRequest) ProtoMessage() {}
+
+func (x *EstimateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateRequest.ProtoReflect.Descriptor instead.
+func (*EstimateRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EstimateRequest) GetRun() *Run {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *EstimateRequest) GetGraph() *v1alpha1.CausalGraph {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+func (x *EstimateRequest) GetAllowUnoriented() bool {
+	if x != nil {
+		return x.AllowUnoriented
+	}
+	return false
+}
+
+func (x *EstimateRequest) GetConfidenceLevel() float32 {
+	if x != nil {
+		return x.ConfidenceLevel
+	}
+	return 0
+}
+
+func (x *EstimateRequest) GetBootstrap() *v1alpha1.Bootstrap {
+	if x != nil {
+		return x.Bootstrap
+	}
+	return nil
+}
+
+type Job struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "discover" or "estimate"
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// "queued", "running", "succeeded", "failed" or "cancelled"
+	State    string       `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Progress *JobProgress `protobuf:"bytes,4,opt,name=progress,proto3" json:"progress,omitempty"`
+	// why the job failed or was cancelled
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// unix milliseconds; started_at and finished_at are 0 until then
+	CreatedAt  int64 `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt  int64 `protobuf:"varint,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt int64 `protobuf:"varint,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// set once the job succeeded, and only by GetJob
+	//
+	// Types that are valid to be assigned to Result:
+	//
+	//	*Job_Graph
+	//	*Job_Estimate
+	Result        isJob_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Job) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Job) GetProgress() *JobProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *Job) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Job) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Job) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *Job) GetResult() isJob_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Job) GetGraph() *v1alpha1.CausalGraph {
+	if x != nil {
+		if x, ok := x.Result.(*Job_Graph); ok {
+			return x.Graph
+		}
+	}
+	return nil
+}
+
+func (x *Job) GetEstimate() *v1alpha1.EstimateResponse {
+	if x != nil {
+		if x, ok := x.Result.(*Job_Estimate); ok {
+			return x.Estimate
+		}
+	}
+	return nil
+}
+
+type isJob_Result interface {
+	isJob_Result()
+}
+
+type Job_Graph struct {
+	Graph *v1alpha1.CausalGraph `protobuf:"bytes,9,opt,name=graph,proto3,oneof"`
+}
+
+type Job_Estimate struct {
+	Estimate *v1alpha1.EstimateResponse `protobuf:"bytes,10,opt,name=estimate,proto3,oneof"`
+}
+
+func (*Job_Graph) isJob_Result() {}
+
+func (*Job_Estimate) isJob_Result() {}
+
+// JobProgress is done of the total steps of the job's current stage:
+// "fetch" (one per fetched variable), then "discover" or "estimate".
+type JobProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stage         string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Done          int32                  `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobProgress) Reset() {
+	*x = JobProgress{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProgress) ProtoMessage() {}
+
+func (x *JobProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProgress.ProtoReflect.Descriptor instead.
+func (*JobProgress) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JobProgress) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *JobProgress) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *JobProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only jobs in this state; empty for all
+	State string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// at most this many jobs; 0 for all
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListJobsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListJobsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// newest first, without results
+	Jobs          []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type JobLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// only entries after this seq, e.g. the last one seen; 0 for all
+	After uint64 `protobuf:"varint,2,opt,name=after,proto3" json:"after,omitempty"`
+	// keep streaming new entries until the job finishes
+	Follow        bool `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobLogsRequest) Reset() {
+	*x = JobLogsRequest{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobLogsRequest) ProtoMessage() {}
+
+func (x *JobLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobLogsRequest.ProtoReflect.Descriptor instead.
+func (*JobLogsRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JobLogsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobLogsRequest) GetAfter() uint64 {
+	if x != nil {
+		return x.After
+	}
+	return 0
+}
+
+func (x *JobLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+type LogEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1 for the first entry of a job
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// unix milliseconds
+	Time          int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_v1alpha1_caus_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha1_caus_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_v1alpha1_caus_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LogEntry) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *LogEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *LogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_v1alpha1_caus_proto protoreflect.FileDescriptor
+
+const file_v1alpha1_caus_proto_rawDesc = "" +
+	"\n" +
+	"\x13v1alpha1/caus.proto\x12\rcaus.v1alpha1\x1a\fcausal.proto\"\x98\x01\n" +
+	"\x03Run\x12\x12\n" +
+	"\x04vars\x18\x01 \x01(\tR\x04vars\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\tR\x03end\x12\x12\n" +
+	"\x04step\x18\x04 \x01(\tR\x04step\x12'\n" +
+	"\x0fmake_stationary\x18\x05 \x01(\bR\x0emakeStationary\x12\x18\n" +
+	"\aexclude\x18\x06 \x03(\tR\aexclude\"\x8e\x01\n" +
+	"\x0fDiscoverRequest\x12$\n" +
+	"\x03run\x18\x01 \x01(\v2\x12.caus.v1alpha1.RunR\x03run\x12\x17\n" +
+	"\amax_lag\x18\x02 \x01(\x05R\x06maxLag\x12\x19\n" +
+	"\bpc_alpha\x18\x03 \x01(\x02R\apcAlpha\x12!\n" +
+	"\fmin_strength\x18\x04 \x01(\x02R\vminStrength\"\xfb\x01\n" +
+	"\x0fEstimateRequest\x12$\n" +
+	"\x03run\x18\x01 \x01(\v2\x12.caus.v1alpha1.RunR\x03run\x122\n" +
+	"\x05graph\x18\x02 \x01(\v2\x1c.causal.v1alpha1.CausalGraphR\x05graph\x12)\n" +
+	"\x10allow_unoriented\x18\x03 \x01(\bR\x0fallowUnoriented\x12)\n" +
+	"\x10confidence_level\x18\x04 \x01(\x02R\x0fconfidenceLevel\x128\n" +
+	"\tbootstrap\x18\x05 \x01(\v2\x1a.causal.v1alpha1.BootstrapR\tbootstrap\"\xed\x02\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x126\n" +
+	"\bprogress\x18\x04 \x01(\v2\x1a.caus.v1alpha1.JobProgressR\bprogress\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\b \x01(\x03R\n" +
+	"finishedAt\x124\n" +
+	"\x05graph\x18\t \x01(\v2\x1c.causal.v1alpha1.CausalGraphH\x00R\x05graph\x12?\n" +
+	"\bestimate\x18\n" +
+	" \x01(\v2!.causal.v1alpha1.EstimateResponseH\x00R\bestimateB\b\n" +
+	"\x06result\"M\n" +
+	"\vJobProgress\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\x05R\x04done\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\x1f\n" +
+	"\rGetJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x0fListJobsRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\":\n" +
+	"\x10ListJobsResponse\x12&\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x12.caus.v1alpha1.JobR\x04jobs\"\"\n" +
+	"\x10CancelJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"N\n" +
+	"\x0eJobLogsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05after\x18\x02 \x01(\x04R\x05after\x12\x16\n" +
+	"\x06follow\x18\x03 \x01(\bR\x06follow\"J\n" +
+	"\bLogEntry\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage2\xcb\x04\n" +
+	"\x04Caus\x12J\n" +
+	"\bDiscover\x12\x1e.caus.v1alpha1.DiscoverRequest\x1a\x1c.causal.v1alpha1.CausalGraph\"\x00\x12O\n" +
+	"\bEstimate\x12\x1e.caus.v1alpha1.EstimateRequest\x1a!.causal.v1alpha1.EstimateResponse\"\x00\x12F\n" +
+	"\x0eSubmitDiscover\x12\x1e.caus.v1alpha1.DiscoverRequest\x1a\x12.caus.v1alpha1.Job\"\x00\x12F\n" +
+	"\x0eSubmitEstimate\x12\x1e.caus.v1alpha1.EstimateRequest\x1a\x12.caus.v1alpha1.Job\"\x00\x12<\n" +
+	"\x06GetJob\x12\x1c.caus.v1alpha1.GetJobRequest\x1a\x12.caus.v1alpha1.Job\"\x00\x12M\n" +
+	"\bListJobs\x12\x1e.caus.v1alpha1.ListJobsRequest\x1a\x1f.caus.v1alpha1.ListJobsResponse\"\x00\x12B\n" +
+	"\tCancelJob\x12\x1f.caus.v1alpha1.CancelJobRequest\x1a\x12.caus.v1alpha1.Job\"\x00\x12E\n" +
+	"\aJobLogs\x12\x1d.caus.v1alpha1.JobLogsRequest\x1a\x17.caus.v1alpha1.LogEntry\"\x000\x01B)Z'github.com/w-h-a/caus/api/caus/v1alpha1b\x06proto3"
+
+var (
+	file_v1alpha1_caus_proto_rawDescOnce sync.Once
+	file_v1alpha1_caus_proto_rawDescData []byte
+)
+
+func file_v1alpha1_caus_proto_rawDescGZIP() []byte {
+	file_v1alpha1_caus_proto_rawDescOnce.Do(func() {
+		file_v1alpha1_caus_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v1alpha1_caus_proto_rawDesc), len(file_v1alpha1_caus_proto_rawDesc)))
+	})
+	return file_v1alpha1_caus_proto_rawDescData
+}
+
+var file_v1alpha1_caus_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_v1alpha1_caus_proto_goTypes = []any{
+	(*Run)(nil),                       // 0: caus.v1alpha1.Run
+	(*DiscoverRequest)(nil),           // 1: caus.v1alpha1.DiscoverRequest
+	(*EstimateRequest)(nil),           // 2: caus.v1alpha1.EstimateRequest
+	(*Job)(nil),                       // 3: caus.v1alpha1.Job
+	(*JobProgress)(nil),               // 4: caus.v1alpha1.JobProgress
+	(*GetJobRequest)(nil),             // 5: caus.v1alpha1.GetJobRequest
+	(*ListJobsRequest)(nil),           // 6: caus.v1alpha1.ListJobsRequest
+	(*ListJobsResponse)(nil),          // 7: caus.v1alpha1.ListJobsResponse
+	(*CancelJobRequest)(nil),          // 8: caus.v1alpha1.CancelJobRequest
+	(*JobLogsRequest)(nil),            // 9: caus.v1alpha1.JobLogsRequest
+	(*LogEntry)(nil),                  // 10: caus.v1alpha1.LogEntry
+	(*v1alpha1.CausalGraph)(nil),      // 11: causal.v1alpha1.CausalGraph
+	(*v1alpha1.Bootstrap)(nil),        // 12: causal.v1alpha1.Bootstrap
+	(*v1alpha1.EstimateResponse)(nil), // 13: causal.v1alpha1.EstimateResponse
+}
+var file_v1alpha1_caus_proto_depIdxs = []int32{
+	0,  // 0: caus.v1alpha1.DiscoverRequest.run:type_name -> caus.v1alpha1.Run
+	0,  // 1: caus.v1alpha1.EstimateRequest.run:type_name -> caus.v1alpha1.Run
+	11, // 2: caus.v1alpha1.EstimateRequest.graph:type_name -> causal.v1alpha1.CausalGraph
+	12, // 3: caus.v1alpha1.EstimateRequest.bootstrap:type_name -> causal.v1alpha1.Bootstrap
+	4,  // 4: caus.v1alpha1.Job.progress:type_name -> caus.v1alpha1.JobProgress
+	11, // 5: caus.v1alpha1.Job.graph:type_name -> causal.v1alpha1.CausalGraph
+	13, // 6: caus.v1alpha1.Job.estimate:type_name -> causal.v1alpha1.EstimateResponse
+	3,  // 7: caus.v1alpha1.ListJobsResponse.jobs:type_name -> caus.v1alpha1.Job
+	1,  // 8: caus.v1alpha1.Caus.Discover:input_type -> caus.v1alpha1.DiscoverRequest
+	2,  // 9: caus.v1alpha1.Caus.Estimate:input_type -> caus.v1alpha1.EstimateRequest
+	1,  // 10: caus.v1alpha1.Caus.SubmitDiscover:input_type -> caus.v1alpha1.DiscoverRequest
+	2,  // 11: caus.v1alpha1.Caus.SubmitEstimate:input_type -> caus.v1alpha1.EstimateRequest
+	5,  // 12: caus.v1alpha1.Caus.GetJob:input_type -> caus.v1alpha1.GetJobRequest
+	6,  // 13: caus.v1alpha1.Caus.ListJobs:input_type -> caus.v1alpha1.ListJobsRequest
+	8,  // 14: caus.v1alpha1.Caus.CancelJob:input_type -> caus.v1alpha1.CancelJobRequest
+	9,  // 15: caus.v1alpha1.Caus.JobLogs:input_type -> caus.v1alpha1.JobLogsRequest
+	11, // 16: caus.v1alpha1.Caus.Discover:output_type -> causal.v1alpha1.CausalGraph
+	13, // 17: caus.v1alpha1.Caus.Estimate:output_type -> causal.v1alpha1.EstimateResponse
+	3,  // 18: caus.v1alpha1.Caus.SubmitDiscover:output_type -> caus.v1alpha1.Job
+	3,  // 19: caus.v1alpha1.Caus.SubmitEstimate:output_type -> caus.v1alpha1.Job
+	3,  // 20: caus.v1alpha1.Caus.GetJob:output_type -> caus.v1alpha1.Job
+	7,  // 21: caus.v1alpha1.Caus.ListJobs:output_type -> caus.v1alpha1.ListJobsResponse
+	3,  // 22: caus.v1alpha1.Caus.CancelJob:output_type -> caus.v1alpha1.Job
+	10, // 23: caus.v1alpha1.Caus.JobLogs:output_type -> caus.v1alpha1.LogEntry
+	16, // [16:24] is the sub-list for method output_type
+	8,  // [8:16] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_v1alpha1_caus_proto_init() }
+func file_v1alpha1_caus_proto_init() {
+	if File_v1alpha1_caus_proto != nil {
+		return
+	}
+	file_v1alpha1_caus_proto_msgTypes[3].OneofWrappers = []any{
+		(*Job_Graph)(nil),
+		(*Job_Estimate)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1alpha1_caus_proto_rawDesc), len(file_v1alpha1_caus_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v1alpha1_caus_proto_goTypes,
+		DependencyIndexes: file_v1alpha1_caus_proto_depIdxs,
+		MessageInfos:      file_v1alpha1_caus_proto_msgTypes,
+	}.Build()
+	File_v1alpha1_caus_proto = out.File
+	file_v1alpha1_caus_proto_goTypes = nil
+	file_v1alpha1_caus_proto_depIdxs = nil
+}
@@ -0,0 +1,406 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.9
+// source: v1alpha1/caus.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	v1alpha1 "github.com/w-h-a/caus/api/causal/v1alpha1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Caus_Discover_FullMethodName       = "/caus.v1alpha1.Caus/Discover"
+	Caus_Estimate_FullMethodName       = "/caus.v1alpha1.Caus/Estimate"
+	Caus_SubmitDiscover_FullMethodName = "/caus.v1alpha1.Caus/SubmitDiscover"
+	Caus_SubmitEstimate_FullMethodName = "/caus.v1alpha1.Caus/SubmitEstimate"
+	Caus_GetJob_FullMethodName         = "/caus.v1alpha1.Caus/GetJob"
+	Caus_ListJobs_FullMethodName       = "/caus.v1alpha1.Caus/ListJobs"
+	Caus_CancelJob_FullMethodName      = "/caus.v1alpha1.Caus/CancelJob"
+	Caus_JobLogs_FullMethodName        = "/caus.v1alpha1.Caus/JobLogs"
+)
+
+// CausClient is the client API for Caus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Caus runs the orchestrator for other tools, e.g. an incident bot, with the
+// data source credentials of the server. Served by `caus serve`, which also
+// exposes every rpc as JSON over HTTP under /v1alpha1/.
+type CausClient interface {
+	Discover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*v1alpha1.CausalGraph, error)
+	Estimate(ctx context.Context, in *EstimateRequest, opts ...grpc.CallOption) (*v1alpha1.EstimateResponse, error)
+	// SubmitDiscover and SubmitEstimate run in the background as a job and
+	// answer at once, for runs that would outlast the caller's deadline. The
+	// server keeps jobs, their logs and their results across restarts.
+	SubmitDiscover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*Job, error)
+	SubmitEstimate(ctx context.Context, in *EstimateRequest, opts ...grpc.CallOption) (*Job, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error)
+	JobLogs(ctx context.Context, in *JobLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error)
+}
+
+type causClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCausClient(cc grpc.ClientConnInterface) CausClient {
+	return &causClient{cc}
+}
+
+func (c *causClient) Discover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*v1alpha1.CausalGraph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1alpha1.CausalGraph)
+	err := c.cc.Invoke(ctx, Caus_Discover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *causClient) Estimate(ctx context.Context, in *EstimateRequest, opts ...grpc.CallOption) (*v1alpha1.EstimateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1alpha1.EstimateResponse)
+	err := c.cc.Invoke(ctx, Caus_Estimate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *causClient) SubmitDiscover(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Caus_SubmitDiscover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *causClient) SubmitEstimate(ctx context.Context, in *EstimateRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Caus_SubmitEstimate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *causClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Caus_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *causClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, Caus_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *causClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Caus_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *causClient) JobLogs(ctx context.Context, in *JobLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Caus_ServiceDesc.Streams[0], Caus_JobLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JobLogsRequest, LogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Caus_JobLogsClient = grpc.ServerStreamingClient[LogEntry]
+
+// CausServer is the server API for Caus service.
+// All implementations must embed UnimplementedCausServer
+// for forward compatibility.
+//
+// Caus runs the orchestrator for other tools, e.g. an incident bot, with the
+// data source credentials of the server. Served by `caus serve`, which also
+// exposes every rpc as JSON over HTTP under /v1alpha1/.
+type CausServer interface {
+	Discover(context.Context, *DiscoverRequest) (*v1alpha1.CausalGraph, error)
+	Estimate(context.Context, *EstimateRequest) (*v1alpha1.EstimateResponse, error)
+	// SubmitDiscover and SubmitEstimate run in the background as a job and
+	// answer at once, for runs that would outlast the caller's deadline. The
+	// server keeps jobs, their logs and their results across restarts.
+	SubmitDiscover(context.Context, *DiscoverRequest) (*Job, error)
+	SubmitEstimate(context.Context, *EstimateRequest) (*Job, error)
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	CancelJob(context.Context, *CancelJobRequest) (*Job, error)
+	JobLogs(*JobLogsRequest, grpc.ServerStreamingServer[LogEntry]) error
+	mustEmbedUnimplementedCausServer()
+}
+
+// UnimplementedCausServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCausServer struct{}
+
+func (UnimplementedCausServer) Discover(context.Context, *DiscoverRequest) (*v1alpha1.CausalGraph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Discover not implemented")
+}
+func (UnimplementedCausServer) Estimate(context.Context, *EstimateRequest) (*v1alpha1.EstimateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Estimate not implemented")
+}
+func (UnimplementedCausServer) SubmitDiscover(context.Context, *DiscoverRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitDiscover not implemented")
+}
+func (UnimplementedCausServer) SubmitEstimate(context.Context, *EstimateRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitEstimate not implemented")
+}
+func (UnimplementedCausServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedCausServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedCausServer) CancelJob(context.Context, *CancelJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedCausServer) JobLogs(*JobLogsRequest, grpc.ServerStreamingServer[LogEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method JobLogs not implemented")
+}
+func (UnimplementedCausServer) mustEmbedUnimplementedCausServer() {}
+func (UnimplementedCausServer) testEmbeddedByValue()              {}
+
+// UnsafeCausServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CausServer will
+// result in compilation errors.
+type UnsafeCausServer interface {
+	mustEmbedUnimplementedCausServer()
+}
+
+func RegisterCausServer(s grpc.ServiceRegistrar, srv CausServer) {
+	// If the following call pancis, it indicates UnimplementedCausServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Caus_ServiceDesc, srv)
+}
+
+func _Caus_Discover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CausServer).Discover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caus_Discover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CausServer).Discover(ctx, req.(*DiscoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Caus_Estimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CausServer).Estimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caus_Estimate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CausServer).Estimate(ctx, req.(*EstimateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Caus_SubmitDiscover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CausServer).SubmitDiscover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caus_SubmitDiscover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CausServer).SubmitDiscover(ctx, req.(*DiscoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Caus_SubmitEstimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CausServer).SubmitEstimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caus_SubmitEstimate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CausServer).SubmitEstimate(ctx, req.(*EstimateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Caus_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CausServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caus_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CausServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Caus_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CausServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caus_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CausServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Caus_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CausServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caus_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CausServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Caus_JobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JobLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CausServer).JobLogs(m, &grpc.GenericServerStream[JobLogsRequest, LogEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Caus_JobLogsServer = grpc.ServerStreamingServer[LogEntry]
+
+// Caus_ServiceDesc is the grpc.ServiceDesc for Caus service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Caus_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "caus.v1alpha1.Caus",
+	HandlerType: (*CausServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Discover",
+			Handler:    _Caus_Discover_Handler,
+		},
+		{
+			MethodName: "Estimate",
+			Handler:    _Caus_Estimate_Handler,
+		},
+		{
+			MethodName: "SubmitDiscover",
+			Handler:    _Caus_SubmitDiscover_Handler,
+		},
+		{
+			MethodName: "SubmitEstimate",
+			Handler:    _Caus_SubmitEstimate_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _Caus_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _Caus_ListJobs_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _Caus_CancelJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "JobLogs",
+			Handler:       _Caus_JobLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "v1alpha1/caus.proto",
+}
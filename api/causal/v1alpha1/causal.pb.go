@@ -1,17 +1,17 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.36.10
 // 	protoc        v3.21.9
 // source: causal.proto
 
 package v1alpha1
 
 import (
-	reflect "reflect"
-	sync "sync"
-
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -22,22 +22,22 @@ const (
 )
 
 type DiscoverRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	CsvData string                 `protobuf:"bytes,1,opt,name=csv_data,json=csvData,proto3" json:"csv_data,omitempty"`
+	MaxLag  int32                  `protobuf:"varint,2,opt,name=max_lag,json=maxLag,proto3" json:"max_lag,omitempty"`
+	PcAlpha float32                `protobuf:"fixed32,3,opt,name=pc_alpha,json=pcAlpha,proto3" json:"pc_alpha,omitempty"`
+	// rows of csv_data where an excluded window starts or ends; no lagged
+	// sample pairs a row before a break with one at or after it
+	Breaks        []int32 `protobuf:"varint,4,rep,packed,name=breaks,proto3" json:"breaks,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	CsvData string  `protobuf:"bytes,1,opt,name=csv_data,json=csvData,proto3" json:"csv_data,omitempty"`
-	MaxLag  int32   `protobuf:"varint,2,opt,name=max_lag,json=maxLag,proto3" json:"max_lag,omitempty"`
-	PcAlpha float32 `protobuf:"fixed32,3,opt,name=pc_alpha,json=pcAlpha,proto3" json:"pc_alpha,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DiscoverRequest) Reset() {
 	*x = DiscoverRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_causal_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_causal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *DiscoverRequest) String() string {
@@ -48,7 +48,7 @@ func (*DiscoverRequest) ProtoMessage() {}
 
 func (x *DiscoverRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_causal_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -84,22 +84,28 @@ func (x *DiscoverRequest) GetPcAlpha() float32 {
 	return 0
 }
 
+func (x *DiscoverRequest) GetBreaks() []int32 {
+	if x != nil {
+		return x.Breaks
+	}
+	return nil
+}
+
 type CausalGraph struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Nodes []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges []*Edge                `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// data the graph was discovered from
+	Window        *Window `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Edges []*Edge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CausalGraph) Reset() {
 	*x = CausalGraph{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_causal_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_causal_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CausalGraph) String() string {
@@ -110,7 +116,7 @@ func (*CausalGraph) ProtoMessage() {}
 
 func (x *CausalGraph) ProtoReflect() protoreflect.Message {
 	mi := &file_causal_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -139,22 +145,94 @@ func (x *CausalGraph) GetEdges() []*Edge {
 	return nil
 }
 
-type Node struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *CausalGraph) GetWindow() *Window {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// Window is the resolved, step-aligned range of the data behind a result, so
+// that a rerun with the same --start, --end and --step sees the same rows.
+type Window struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC3339, inclusive
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// RFC3339, inclusive
+	End string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Go duration, e.g. "1m0s"
+	Step          string `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	Id    int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+func (x *Window) Reset() {
+	*x = Window{}
+	mi := &file_causal_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_causal_proto_msgTypes[2]
+func (x *Window) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Window) ProtoMessage() {}
+
+func (x *Window) ProtoReflect() protoreflect.Message {
+	mi := &file_causal_proto_msgTypes[2]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Window.ProtoReflect.Descriptor instead.
+func (*Window) Descriptor() ([]byte, []int) {
+	return file_causal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Window) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *Window) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *Window) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+type Node struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// transforms applied to the variable before analysis, in order (e.g.,
+	// "rate", "rolling_mean(window=5)")
+	Transforms    []string `protobuf:"bytes,3,rep,name=transforms,proto3" json:"transforms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_causal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Node) String() string {
@@ -164,8 +242,8 @@ func (x *Node) String() string {
 func (*Node) ProtoMessage() {}
 
 func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_causal_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_causal_proto_msgTypes[3]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -177,7 +255,7 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Node.ProtoReflect.Descriptor instead.
 func (*Node) Descriptor() ([]byte, []int) {
-	return file_causal_proto_rawDescGZIP(), []int{2}
+	return file_causal_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Node) GetId() int32 {
@@ -194,24 +272,36 @@ func (x *Node) GetLabel() string {
 	return ""
 }
 
+func (x *Node) GetTransforms() []string {
+	if x != nil {
+		return x.Transforms
+	}
+	return nil
+}
+
 type Edge struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Source string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// "directed" (-->), "undirected" (o-o), "bidirected" (<->) or
+	// "conflicting" (x-x); only directed edges can be estimated
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Lag  int32  `protobuf:"varint,4,opt,name=lag,proto3" json:"lag,omitempty"`
+	// t statistic of the conditional independence test behind the edge
+	Statistic float32 `protobuf:"fixed32,5,opt,name=statistic,proto3" json:"statistic,omitempty"`
+	PValue    float32 `protobuf:"fixed32,6,opt,name=p_value,json=pValue,proto3" json:"p_value,omitempty"`
+	// partial correlation of source and target given the conditioning set;
+	// its magnitude is the edge strength
+	PartialCorr   float32 `protobuf:"fixed32,7,opt,name=partial_corr,json=partialCorr,proto3" json:"partial_corr,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	Type   string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Lag    int32  `protobuf:"varint,4,opt,name=lag,proto3" json:"lag,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Edge) Reset() {
 	*x = Edge{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_causal_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_causal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Edge) String() string {
@@ -221,8 +311,8 @@ func (x *Edge) String() string {
 func (*Edge) ProtoMessage() {}
 
 func (x *Edge) ProtoReflect() protoreflect.Message {
-	mi := &file_causal_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_causal_proto_msgTypes[4]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -234,7 +324,7 @@ func (x *Edge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Edge.ProtoReflect.Descriptor instead.
 func (*Edge) Descriptor() ([]byte, []int) {
-	return file_causal_proto_rawDescGZIP(), []int{3}
+	return file_causal_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Edge) GetSource() string {
@@ -265,22 +355,48 @@ func (x *Edge) GetLag() int32 {
 	return 0
 }
 
+func (x *Edge) GetStatistic() float32 {
+	if x != nil {
+		return x.Statistic
+	}
+	return 0
+}
+
+func (x *Edge) GetPValue() float32 {
+	if x != nil {
+		return x.PValue
+	}
+	return 0
+}
+
+func (x *Edge) GetPartialCorr() float32 {
+	if x != nil {
+		return x.PartialCorr
+	}
+	return 0
+}
+
 type EstimateRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	CsvData string                 `protobuf:"bytes,1,opt,name=csv_data,json=csvData,proto3" json:"csv_data,omitempty"`
+	Graph   *CausalGraph           `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	// confidence level for the coefficient intervals; 0 means 0.95
+	ConfidenceLevel float32 `protobuf:"fixed32,3,opt,name=confidence_level,json=confidenceLevel,proto3" json:"confidence_level,omitempty"`
+	// when set, coefficient uncertainty comes from a moving block bootstrap
+	// instead of the analytic OLS formulas
+	Bootstrap *Bootstrap `protobuf:"bytes,4,opt,name=bootstrap,proto3" json:"bootstrap,omitempty"`
+	// rows of csv_data where an excluded window starts or ends; no lagged
+	// sample pairs a row before a break with one at or after it
+	Breaks        []int32 `protobuf:"varint,5,rep,packed,name=breaks,proto3" json:"breaks,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	CsvData string       `protobuf:"bytes,1,opt,name=csv_data,json=csvData,proto3" json:"csv_data,omitempty"`
-	Graph   *CausalGraph `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EstimateRequest) Reset() {
 	*x = EstimateRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_causal_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_causal_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *EstimateRequest) String() string {
@@ -290,8 +406,8 @@ func (x *EstimateRequest) String() string {
 func (*EstimateRequest) ProtoMessage() {}
 
 func (x *EstimateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_causal_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_causal_proto_msgTypes[5]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -303,7 +419,7 @@ func (x *EstimateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EstimateRequest.ProtoReflect.Descriptor instead.
 func (*EstimateRequest) Descriptor() ([]byte, []int) {
-	return file_causal_proto_rawDescGZIP(), []int{4}
+	return file_causal_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *EstimateRequest) GetCsvData() string {
@@ -320,21 +436,106 @@ func (x *EstimateRequest) GetGraph() *CausalGraph {
 	return nil
 }
 
-type EstimateResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *EstimateRequest) GetConfidenceLevel() float32 {
+	if x != nil {
+		return x.ConfidenceLevel
+	}
+	return 0
+}
+
+func (x *EstimateRequest) GetBootstrap() *Bootstrap {
+	if x != nil {
+		return x.Bootstrap
+	}
+	return nil
+}
+
+func (x *EstimateRequest) GetBreaks() []int32 {
+	if x != nil {
+		return x.Breaks
+	}
+	return nil
+}
+
+type Bootstrap struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Samples int32                  `protobuf:"varint,1,opt,name=samples,proto3" json:"samples,omitempty"`
+	// rows per block; 0 means n^(1/3)
+	BlockSize int32 `protobuf:"varint,2,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	// seeds the resampling, so that a run can be reproduced; 0 picks a fresh
+	// seed every time
+	Seed          uint64 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	Models map[string]*ModelInfo `protobuf:"bytes,2,rep,name=models,proto3" json:"models,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (x *Bootstrap) Reset() {
+	*x = Bootstrap{}
+	mi := &file_causal_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *EstimateResponse) Reset() {
-	*x = EstimateResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_causal_proto_msgTypes[5]
+func (x *Bootstrap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bootstrap) ProtoMessage() {}
+
+func (x *Bootstrap) ProtoReflect() protoreflect.Message {
+	mi := &file_causal_proto_msgTypes[6]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bootstrap.ProtoReflect.Descriptor instead.
+func (*Bootstrap) Descriptor() ([]byte, []int) {
+	return file_causal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Bootstrap) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *Bootstrap) GetBlockSize() int32 {
+	if x != nil {
+		return x.BlockSize
 	}
+	return 0
+}
+
+func (x *Bootstrap) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type EstimateResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Models map[string]*ModelInfo  `protobuf:"bytes,2,rep,name=models,proto3" json:"models,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// data the models were fitted to
+	Window *Window `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	// variables the models were fitted to, with their transforms
+	Nodes         []*Node `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateResponse) Reset() {
+	*x = EstimateResponse{}
+	mi := &file_causal_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *EstimateResponse) String() string {
@@ -344,8 +545,8 @@ func (x *EstimateResponse) String() string {
 func (*EstimateResponse) ProtoMessage() {}
 
 func (x *EstimateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_causal_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_causal_proto_msgTypes[7]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -357,7 +558,7 @@ func (x *EstimateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EstimateResponse.ProtoReflect.Descriptor instead.
 func (*EstimateResponse) Descriptor() ([]byte, []int) {
-	return file_causal_proto_rawDescGZIP(), []int{5}
+	return file_causal_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *EstimateResponse) GetModels() map[string]*ModelInfo {
@@ -367,23 +568,46 @@ func (x *EstimateResponse) GetModels() map[string]*ModelInfo {
 	return nil
 }
 
-type ModelInfo struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *EstimateResponse) GetWindow() *Window {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
 
-	Features     []string  `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
-	Coefficients []float32 `protobuf:"fixed32,2,rep,packed,name=coefficients,proto3" json:"coefficients,omitempty"`
-	Intercept    float32   `protobuf:"fixed32,3,opt,name=intercept,proto3" json:"intercept,omitempty"`
+func (x *EstimateResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type ModelInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Features     []string               `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	Coefficients []float32              `protobuf:"fixed32,2,rep,packed,name=coefficients,proto3" json:"coefficients,omitempty"`
+	Intercept    float32                `protobuf:"fixed32,3,opt,name=intercept,proto3" json:"intercept,omitempty"`
+	// per feature, aligned with coefficients
+	StandardErrors   []float32 `protobuf:"fixed32,4,rep,packed,name=standard_errors,json=standardErrors,proto3" json:"standard_errors,omitempty"`
+	TStatistics      []float32 `protobuf:"fixed32,5,rep,packed,name=t_statistics,json=tStatistics,proto3" json:"t_statistics,omitempty"`
+	PValues          []float32 `protobuf:"fixed32,6,rep,packed,name=p_values,json=pValues,proto3" json:"p_values,omitempty"`
+	CiLower          []float32 `protobuf:"fixed32,7,rep,packed,name=ci_lower,json=ciLower,proto3" json:"ci_lower,omitempty"`
+	CiUpper          []float32 `protobuf:"fixed32,8,rep,packed,name=ci_upper,json=ciUpper,proto3" json:"ci_upper,omitempty"`
+	RSquared         float32   `protobuf:"fixed32,9,opt,name=r_squared,json=rSquared,proto3" json:"r_squared,omitempty"`
+	ResidualVariance float32   `protobuf:"fixed32,10,opt,name=residual_variance,json=residualVariance,proto3" json:"residual_variance,omitempty"`
+	NObs             int32     `protobuf:"varint,11,opt,name=n_obs,json=nObs,proto3" json:"n_obs,omitempty"`
+	// "analytic" or "bootstrap"
+	Inference       string  `protobuf:"bytes,12,opt,name=inference,proto3" json:"inference,omitempty"`
+	ConfidenceLevel float32 `protobuf:"fixed32,13,opt,name=confidence_level,json=confidenceLevel,proto3" json:"confidence_level,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ModelInfo) Reset() {
 	*x = ModelInfo{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_causal_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_causal_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ModelInfo) String() string {
@@ -393,8 +617,8 @@ func (x *ModelInfo) String() string {
 func (*ModelInfo) ProtoMessage() {}
 
 func (x *ModelInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_causal_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_causal_proto_msgTypes[8]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -406,7 +630,7 @@ func (x *ModelInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelInfo.ProtoReflect.Descriptor instead.
 func (*ModelInfo) Descriptor() ([]byte, []int) {
-	return file_causal_proto_rawDescGZIP(), []int{6}
+	return file_causal_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ModelInfo) GetFeatures() []string {
@@ -430,113 +654,190 @@ func (x *ModelInfo) GetIntercept() float32 {
 	return 0
 }
 
-var File_causal_proto protoreflect.FileDescriptor
+func (x *ModelInfo) GetStandardErrors() []float32 {
+	if x != nil {
+		return x.StandardErrors
+	}
+	return nil
+}
 
-var file_causal_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x63, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f,
-	0x63, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x22,
-	0x60, 0x0a, 0x0f, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x73, 0x76, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x73, 0x76, 0x44, 0x61, 0x74, 0x61, 0x12, 0x17, 0x0a,
-	0x07, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x6d, 0x61, 0x78, 0x4c, 0x61, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x63, 0x5f, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x70, 0x63, 0x41, 0x6c, 0x70, 0x68,
-	0x61, 0x22, 0x67, 0x0a, 0x0b, 0x43, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x47, 0x72, 0x61, 0x70, 0x68,
-	0x12, 0x2b, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x63, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2b, 0x0a,
-	0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63,
-	0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45,
-	0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x22, 0x2c, 0x0a, 0x04, 0x4e, 0x6f,
-	0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x5c, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x03, 0x6c, 0x61, 0x67, 0x22, 0x60, 0x0a, 0x0f, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x73, 0x76,
-	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x73, 0x76,
-	0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x47, 0x72, 0x61, 0x70,
-	0x68, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x22, 0xb0, 0x01, 0x0a, 0x10, 0x45, 0x73, 0x74,
-	0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a,
-	0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
-	0x63, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x73, 0x1a, 0x55, 0x0a, 0x0b, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x69, 0x0a, 0x09, 0x4d,
-	0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69,
-	0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x0c, 0x63, 0x6f, 0x65, 0x66,
-	0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x63, 0x65, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x63, 0x65, 0x70, 0x74, 0x32, 0x5f, 0x0a, 0x0f, 0x43, 0x61, 0x75, 0x73, 0x61, 0x6c,
-	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x4c, 0x0a, 0x08, 0x44, 0x69, 0x73,
-	0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x63, 0x61, 0x75, 0x73, 0x61, 0x6c, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x61, 0x75, 0x73, 0x61, 0x6c,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x61, 0x75, 0x73, 0x61, 0x6c,
-	0x47, 0x72, 0x61, 0x70, 0x68, 0x22, 0x00, 0x32, 0x65, 0x0a, 0x10, 0x43, 0x61, 0x75, 0x73, 0x61,
-	0x6c, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x08, 0x45,
-	0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x63, 0x61, 0x75, 0x73, 0x61, 0x6c,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x63, 0x61, 0x75, 0x73,
-	0x61, 0x6c, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x73, 0x74, 0x69,
-	0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b,
-	0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x2d, 0x68,
-	0x2d, 0x61, 0x2f, 0x63, 0x61, 0x75, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x61, 0x75, 0x73,
-	0x61, 0x6c, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+func (x *ModelInfo) GetTStatistics() []float32 {
+	if x != nil {
+		return x.TStatistics
+	}
+	return nil
 }
 
+func (x *ModelInfo) GetPValues() []float32 {
+	if x != nil {
+		return x.PValues
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetCiLower() []float32 {
+	if x != nil {
+		return x.CiLower
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetCiUpper() []float32 {
+	if x != nil {
+		return x.CiUpper
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetRSquared() float32 {
+	if x != nil {
+		return x.RSquared
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetResidualVariance() float32 {
+	if x != nil {
+		return x.ResidualVariance
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetNObs() int32 {
+	if x != nil {
+		return x.NObs
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetInference() string {
+	if x != nil {
+		return x.Inference
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetConfidenceLevel() float32 {
+	if x != nil {
+		return x.ConfidenceLevel
+	}
+	return 0
+}
+
+var File_causal_proto protoreflect.FileDescriptor
+
+const file_causal_proto_rawDesc = "" +
+	"\n" +
+	"\fcausal.proto\x12\x0fcausal.v1alpha1\"x\n" +
+	"\x0fDiscoverRequest\x12\x19\n" +
+	"\bcsv_data\x18\x01 \x01(\tR\acsvData\x12\x17\n" +
+	"\amax_lag\x18\x02 \x01(\x05R\x06maxLag\x12\x19\n" +
+	"\bpc_alpha\x18\x03 \x01(\x02R\apcAlpha\x12\x16\n" +
+	"\x06breaks\x18\x04 \x03(\x05R\x06breaks\"\x98\x01\n" +
+	"\vCausalGraph\x12+\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x15.causal.v1alpha1.NodeR\x05nodes\x12+\n" +
+	"\x05edges\x18\x02 \x03(\v2\x15.causal.v1alpha1.EdgeR\x05edges\x12/\n" +
+	"\x06window\x18\x03 \x01(\v2\x17.causal.v1alpha1.WindowR\x06window\"D\n" +
+	"\x06Window\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\tR\x04step\"L\n" +
+	"\x04Node\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1e\n" +
+	"\n" +
+	"transforms\x18\x03 \x03(\tR\n" +
+	"transforms\"\xb6\x01\n" +
+	"\x04Edge\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x10\n" +
+	"\x03lag\x18\x04 \x01(\x05R\x03lag\x12\x1c\n" +
+	"\tstatistic\x18\x05 \x01(\x02R\tstatistic\x12\x17\n" +
+	"\ap_value\x18\x06 \x01(\x02R\x06pValue\x12!\n" +
+	"\fpartial_corr\x18\a \x01(\x02R\vpartialCorr\"\xdd\x01\n" +
+	"\x0fEstimateRequest\x12\x19\n" +
+	"\bcsv_data\x18\x01 \x01(\tR\acsvData\x122\n" +
+	"\x05graph\x18\x02 \x01(\v2\x1c.causal.v1alpha1.CausalGraphR\x05graph\x12)\n" +
+	"\x10confidence_level\x18\x03 \x01(\x02R\x0fconfidenceLevel\x128\n" +
+	"\tbootstrap\x18\x04 \x01(\v2\x1a.causal.v1alpha1.BootstrapR\tbootstrap\x12\x16\n" +
+	"\x06breaks\x18\x05 \x03(\x05R\x06breaks\"X\n" +
+	"\tBootstrap\x12\x18\n" +
+	"\asamples\x18\x01 \x01(\x05R\asamples\x12\x1d\n" +
+	"\n" +
+	"block_size\x18\x02 \x01(\x05R\tblockSize\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x04R\x04seed\"\x8e\x02\n" +
+	"\x10EstimateResponse\x12E\n" +
+	"\x06models\x18\x02 \x03(\v2-.causal.v1alpha1.EstimateResponse.ModelsEntryR\x06models\x12/\n" +
+	"\x06window\x18\x03 \x01(\v2\x17.causal.v1alpha1.WindowR\x06window\x12+\n" +
+	"\x05nodes\x18\x04 \x03(\v2\x15.causal.v1alpha1.NodeR\x05nodes\x1aU\n" +
+	"\vModelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.causal.v1alpha1.ModelInfoR\x05value:\x028\x01\"\xae\x03\n" +
+	"\tModelInfo\x12\x1a\n" +
+	"\bfeatures\x18\x01 \x03(\tR\bfeatures\x12\"\n" +
+	"\fcoefficients\x18\x02 \x03(\x02R\fcoefficients\x12\x1c\n" +
+	"\tintercept\x18\x03 \x01(\x02R\tintercept\x12'\n" +
+	"\x0fstandard_errors\x18\x04 \x03(\x02R\x0estandardErrors\x12!\n" +
+	"\ft_statistics\x18\x05 \x03(\x02R\vtStatistics\x12\x19\n" +
+	"\bp_values\x18\x06 \x03(\x02R\apValues\x12\x19\n" +
+	"\bci_lower\x18\a \x03(\x02R\aciLower\x12\x19\n" +
+	"\bci_upper\x18\b \x03(\x02R\aciUpper\x12\x1b\n" +
+	"\tr_squared\x18\t \x01(\x02R\brSquared\x12+\n" +
+	"\x11residual_variance\x18\n" +
+	" \x01(\x02R\x10residualVariance\x12\x13\n" +
+	"\x05n_obs\x18\v \x01(\x05R\x04nObs\x12\x1c\n" +
+	"\tinference\x18\f \x01(\tR\tinference\x12)\n" +
+	"\x10confidence_level\x18\r \x01(\x02R\x0fconfidenceLevel2_\n" +
+	"\x0fCausalDiscovery\x12L\n" +
+	"\bDiscover\x12 .causal.v1alpha1.DiscoverRequest\x1a\x1c.causal.v1alpha1.CausalGraph\"\x002e\n" +
+	"\x10CausalEstimation\x12Q\n" +
+	"\bEstimate\x12 .causal.v1alpha1.EstimateRequest\x1a!.causal.v1alpha1.EstimateResponse\"\x00B+Z)github.com/w-h-a/caus/api/causal/v1alpha1b\x06proto3"
+
 var (
 	file_causal_proto_rawDescOnce sync.Once
-	file_causal_proto_rawDescData = file_causal_proto_rawDesc
+	file_causal_proto_rawDescData []byte
 )
 
 func file_causal_proto_rawDescGZIP() []byte {
 	file_causal_proto_rawDescOnce.Do(func() {
-		file_causal_proto_rawDescData = protoimpl.X.CompressGZIP(file_causal_proto_rawDescData)
+		file_causal_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_causal_proto_rawDesc), len(file_causal_proto_rawDesc)))
 	})
 	return file_causal_proto_rawDescData
 }
 
-var file_causal_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_causal_proto_goTypes = []interface{}{
+var file_causal_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_causal_proto_goTypes = []any{
 	(*DiscoverRequest)(nil),  // 0: causal.v1alpha1.DiscoverRequest
 	(*CausalGraph)(nil),      // 1: causal.v1alpha1.CausalGraph
-	(*Node)(nil),             // 2: causal.v1alpha1.Node
-	(*Edge)(nil),             // 3: causal.v1alpha1.Edge
-	(*EstimateRequest)(nil),  // 4: causal.v1alpha1.EstimateRequest
-	(*EstimateResponse)(nil), // 5: causal.v1alpha1.EstimateResponse
-	(*ModelInfo)(nil),        // 6: causal.v1alpha1.ModelInfo
-	nil,                      // 7: causal.v1alpha1.EstimateResponse.ModelsEntry
+	(*Window)(nil),           // 2: causal.v1alpha1.Window
+	(*Node)(nil),             // 3: causal.v1alpha1.Node
+	(*Edge)(nil),             // 4: causal.v1alpha1.Edge
+	(*EstimateRequest)(nil),  // 5: causal.v1alpha1.EstimateRequest
+	(*Bootstrap)(nil),        // 6: causal.v1alpha1.Bootstrap
+	(*EstimateResponse)(nil), // 7: causal.v1alpha1.EstimateResponse
+	(*ModelInfo)(nil),        // 8: causal.v1alpha1.ModelInfo
+	nil,                      // 9: causal.v1alpha1.EstimateResponse.ModelsEntry
 }
 var file_causal_proto_depIdxs = []int32{
-	2, // 0: causal.v1alpha1.CausalGraph.nodes:type_name -> causal.v1alpha1.Node
-	3, // 1: causal.v1alpha1.CausalGraph.edges:type_name -> causal.v1alpha1.Edge
-	1, // 2: causal.v1alpha1.EstimateRequest.graph:type_name -> causal.v1alpha1.CausalGraph
-	7, // 3: causal.v1alpha1.EstimateResponse.models:type_name -> causal.v1alpha1.EstimateResponse.ModelsEntry
-	6, // 4: causal.v1alpha1.EstimateResponse.ModelsEntry.value:type_name -> causal.v1alpha1.ModelInfo
-	0, // 5: causal.v1alpha1.CausalDiscovery.Discover:input_type -> causal.v1alpha1.DiscoverRequest
-	4, // 6: causal.v1alpha1.CausalEstimation.Estimate:input_type -> causal.v1alpha1.EstimateRequest
-	1, // 7: causal.v1alpha1.CausalDiscovery.Discover:output_type -> causal.v1alpha1.CausalGraph
-	5, // 8: causal.v1alpha1.CausalEstimation.Estimate:output_type -> causal.v1alpha1.EstimateResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	3,  // 0: causal.v1alpha1.CausalGraph.nodes:type_name -> causal.v1alpha1.Node
+	4,  // 1: causal.v1alpha1.CausalGraph.edges:type_name -> causal.v1alpha1.Edge
+	2,  // 2: causal.v1alpha1.CausalGraph.window:type_name -> causal.v1alpha1.Window
+	1,  // 3: causal.v1alpha1.EstimateRequest.graph:type_name -> causal.v1alpha1.CausalGraph
+	6,  // 4: causal.v1alpha1.EstimateRequest.bootstrap:type_name -> causal.v1alpha1.Bootstrap
+	9,  // 5: causal.v1alpha1.EstimateResponse.models:type_name -> causal.v1alpha1.EstimateResponse.ModelsEntry
+	2,  // 6: causal.v1alpha1.EstimateResponse.window:type_name -> causal.v1alpha1.Window
+	3,  // 7: causal.v1alpha1.EstimateResponse.nodes:type_name -> causal.v1alpha1.Node
+	8,  // 8: causal.v1alpha1.EstimateResponse.ModelsEntry.value:type_name -> causal.v1alpha1.ModelInfo
+	0,  // 9: causal.v1alpha1.CausalDiscovery.Discover:input_type -> causal.v1alpha1.DiscoverRequest
+	5,  // 10: causal.v1alpha1.CausalEstimation.Estimate:input_type -> causal.v1alpha1.EstimateRequest
+	1,  // 11: causal.v1alpha1.CausalDiscovery.Discover:output_type -> causal.v1alpha1.CausalGraph
+	7,  // 12: causal.v1alpha1.CausalEstimation.Estimate:output_type -> causal.v1alpha1.EstimateResponse
+	11, // [11:13] is the sub-list for method output_type
+	9,  // [9:11] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_causal_proto_init() }
@@ -544,99 +845,13 @@ func file_causal_proto_init() {
 	if File_causal_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_causal_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DiscoverRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_causal_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CausalGraph); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_causal_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Node); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_causal_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Edge); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_causal_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EstimateRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_causal_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EstimateResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_causal_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ModelInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_causal_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_causal_proto_rawDesc), len(file_causal_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -645,7 +860,6 @@ func file_causal_proto_init() {
 		MessageInfos:      file_causal_proto_msgTypes,
 	}.Build()
 	File_causal_proto = out.File
-	file_causal_proto_rawDesc = nil
 	file_causal_proto_goTypes = nil
 	file_causal_proto_depIdxs = nil
 }
@@ -0,0 +1,1230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.9
+// source: v1alpha2/causal.proto
+
+package v1alpha2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DiscoverRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DiscoverRequest_Params
+	//	*DiscoverRequest_Chunk
+	Payload       isDiscoverRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverRequest) Reset() {
+	*x = DiscoverRequest{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverRequest) ProtoMessage() {}
+
+func (x *DiscoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DiscoverRequest) GetPayload() isDiscoverRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DiscoverRequest) GetParams() *DiscoverParams {
+	if x != nil {
+		if x, ok := x.Payload.(*DiscoverRequest_Params); ok {
+			return x.Params
+		}
+	}
+	return nil
+}
+
+func (x *DiscoverRequest) GetChunk() *DataChunk {
+	if x != nil {
+		if x, ok := x.Payload.(*DiscoverRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDiscoverRequest_Payload interface {
+	isDiscoverRequest_Payload()
+}
+
+type DiscoverRequest_Params struct {
+	Params *DiscoverParams `protobuf:"bytes,1,opt,name=params,proto3,oneof"`
+}
+
+type DiscoverRequest_Chunk struct {
+	Chunk *DataChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DiscoverRequest_Params) isDiscoverRequest_Payload() {}
+
+func (*DiscoverRequest_Chunk) isDiscoverRequest_Payload() {}
+
+type DiscoverParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *Schema                `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	MaxLag        int32                  `protobuf:"varint,2,opt,name=max_lag,json=maxLag,proto3" json:"max_lag,omitempty"`
+	PcAlpha       float32                `protobuf:"fixed32,3,opt,name=pc_alpha,json=pcAlpha,proto3" json:"pc_alpha,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverParams) Reset() {
+	*x = DiscoverParams{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverParams) ProtoMessage() {}
+
+func (x *DiscoverParams) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverParams.ProtoReflect.Descriptor instead.
+func (*DiscoverParams) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiscoverParams) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *DiscoverParams) GetMaxLag() int32 {
+	if x != nil {
+		return x.MaxLag
+	}
+	return 0
+}
+
+func (x *DiscoverParams) GetPcAlpha() float32 {
+	if x != nil {
+		return x.PcAlpha
+	}
+	return 0
+}
+
+type EstimateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*EstimateRequest_Params
+	//	*EstimateRequest_Chunk
+	Payload       isEstimateRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateRequest) Reset() {
+	*x = EstimateRequest{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateRequest) ProtoMessage() {}
+
+func (x *EstimateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateRequest.ProtoReflect.Descriptor instead.
+func (*EstimateRequest) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EstimateRequest) GetPayload() isEstimateRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *EstimateRequest) GetParams() *EstimateParams {
+	if x != nil {
+		if x, ok := x.Payload.(*EstimateRequest_Params); ok {
+			return x.Params
+		}
+	}
+	return nil
+}
+
+func (x *EstimateRequest) GetChunk() *DataChunk {
+	if x != nil {
+		if x, ok := x.Payload.(*EstimateRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isEstimateRequest_Payload interface {
+	isEstimateRequest_Payload()
+}
+
+type EstimateRequest_Params struct {
+	Params *EstimateParams `protobuf:"bytes,1,opt,name=params,proto3,oneof"`
+}
+
+type EstimateRequest_Chunk struct {
+	Chunk *DataChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*EstimateRequest_Params) isEstimateRequest_Payload() {}
+
+func (*EstimateRequest_Chunk) isEstimateRequest_Payload() {}
+
+type EstimateParams struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Schema *Schema                `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Graph  *CausalGraph           `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	// confidence level for the coefficient intervals; 0 means 0.95
+	ConfidenceLevel float32 `protobuf:"fixed32,3,opt,name=confidence_level,json=confidenceLevel,proto3" json:"confidence_level,omitempty"`
+	// when set, coefficient uncertainty comes from a moving block bootstrap
+	// instead of the analytic OLS formulas
+	Bootstrap     *Bootstrap `protobuf:"bytes,4,opt,name=bootstrap,proto3" json:"bootstrap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateParams) Reset() {
+	*x = EstimateParams{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateParams) ProtoMessage() {}
+
+func (x *EstimateParams) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateParams.ProtoReflect.Descriptor instead.
+func (*EstimateParams) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EstimateParams) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *EstimateParams) GetGraph() *CausalGraph {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+func (x *EstimateParams) GetConfidenceLevel() float32 {
+	if x != nil {
+		return x.ConfidenceLevel
+	}
+	return 0
+}
+
+func (x *EstimateParams) GetBootstrap() *Bootstrap {
+	if x != nil {
+		return x.Bootstrap
+	}
+	return nil
+}
+
+// Schema describes the rows that the chunks of a call add up to.
+type Schema struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// column names, in the order of DataChunk.columns
+	Columns []string `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	// total rows across all chunks
+	Rows int64 `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	// Go duration, e.g. "1m0s"
+	Step string `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	// rows where an excluded window starts or ends; no lagged sample pairs a
+	// row before a break with one at or after it
+	Breaks        []int64 `protobuf:"varint,4,rep,packed,name=breaks,proto3" json:"breaks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schema) Reset() {
+	*x = Schema{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schema) ProtoMessage() {}
+
+func (x *Schema) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schema.ProtoReflect.Descriptor instead.
+func (*Schema) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Schema) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *Schema) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *Schema) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *Schema) GetBreaks() []int64 {
+	if x != nil {
+		return x.Breaks
+	}
+	return nil
+}
+
+// DataChunk is a run of consecutive rows. Chunks arrive in order.
+type DataChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unix milliseconds, one per row; empty when the data has no timestamps
+	Timestamps []int64 `protobuf:"varint,1,rep,packed,name=timestamps,proto3" json:"timestamps,omitempty"`
+	// one per schema column, each holding every row of the chunk
+	Columns       []*Column `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataChunk) Reset() {
+	*x = DataChunk{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataChunk) ProtoMessage() {}
+
+func (x *DataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataChunk.ProtoReflect.Descriptor instead.
+func (*DataChunk) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DataChunk) GetTimestamps() []int64 {
+	if x != nil {
+		return x.Timestamps
+	}
+	return nil
+}
+
+func (x *DataChunk) GetColumns() []*Column {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+type Column struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Values []float64              `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	// true where the value is missing; empty when nothing in the chunk is
+	Missing       []bool `protobuf:"varint,2,rep,packed,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Column) Reset() {
+	*x = Column{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Column) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Column) ProtoMessage() {}
+
+func (x *Column) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Column.ProtoReflect.Descriptor instead.
+func (*Column) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Column) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Column) GetMissing() []bool {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+type CausalGraph struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Nodes []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges []*Edge                `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// data the graph was discovered from
+	Window        *Window `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CausalGraph) Reset() {
+	*x = CausalGraph{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CausalGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CausalGraph) ProtoMessage() {}
+
+func (x *CausalGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CausalGraph.ProtoReflect.Descriptor instead.
+func (*CausalGraph) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CausalGraph) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *CausalGraph) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *CausalGraph) GetWindow() *Window {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// Window is the resolved, step-aligned range of the data behind a result, so
+// that a rerun with the same --start, --end and --step sees the same rows.
+type Window struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC3339, inclusive
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// RFC3339, inclusive
+	End string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Go duration, e.g. "1m0s"
+	Step          string `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Window) Reset() {
+	*x = Window{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Window) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Window) ProtoMessage() {}
+
+func (x *Window) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Window.ProtoReflect.Descriptor instead.
+func (*Window) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Window) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *Window) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *Window) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+type Node struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// transforms applied to the variable before analysis, in order (e.g.,
+	// "rate", "rolling_mean(window=5)")
+	Transforms    []string `protobuf:"bytes,3,rep,name=transforms,proto3" json:"transforms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Node) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Node) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Node) GetTransforms() []string {
+	if x != nil {
+		return x.Transforms
+	}
+	return nil
+}
+
+type Edge struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Source string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// "directed" (-->), "undirected" (o-o), "bidirected" (<->) or
+	// "conflicting" (x-x); only directed edges can be estimated
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Lag  int32  `protobuf:"varint,4,opt,name=lag,proto3" json:"lag,omitempty"`
+	// t statistic of the conditional independence test behind the edge
+	Statistic float32 `protobuf:"fixed32,5,opt,name=statistic,proto3" json:"statistic,omitempty"`
+	PValue    float32 `protobuf:"fixed32,6,opt,name=p_value,json=pValue,proto3" json:"p_value,omitempty"`
+	// partial correlation of source and target given the conditioning set;
+	// its magnitude is the edge strength
+	PartialCorr   float32 `protobuf:"fixed32,7,opt,name=partial_corr,json=partialCorr,proto3" json:"partial_corr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Edge) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Edge) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Edge) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Edge) GetLag() int32 {
+	if x != nil {
+		return x.Lag
+	}
+	return 0
+}
+
+func (x *Edge) GetStatistic() float32 {
+	if x != nil {
+		return x.Statistic
+	}
+	return 0
+}
+
+func (x *Edge) GetPValue() float32 {
+	if x != nil {
+		return x.PValue
+	}
+	return 0
+}
+
+func (x *Edge) GetPartialCorr() float32 {
+	if x != nil {
+		return x.PartialCorr
+	}
+	return 0
+}
+
+type Bootstrap struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Samples int32                  `protobuf:"varint,1,opt,name=samples,proto3" json:"samples,omitempty"`
+	// rows per block; 0 means n^(1/3)
+	BlockSize int32 `protobuf:"varint,2,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	// seeds the resampling, so that a run can be reproduced; 0 picks a fresh
+	// seed every time
+	Seed          uint64 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bootstrap) Reset() {
+	*x = Bootstrap{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bootstrap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bootstrap) ProtoMessage() {}
+
+func (x *Bootstrap) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bootstrap.ProtoReflect.Descriptor instead.
+func (*Bootstrap) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Bootstrap) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *Bootstrap) GetBlockSize() int32 {
+	if x != nil {
+		return x.BlockSize
+	}
+	return 0
+}
+
+func (x *Bootstrap) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type EstimateResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Models map[string]*ModelInfo  `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// data the models were fitted to
+	Window *Window `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	// variables the models were fitted to, with their transforms
+	Nodes         []*Node `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateResponse) Reset() {
+	*x = EstimateResponse{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateResponse) ProtoMessage() {}
+
+func (x *EstimateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateResponse.ProtoReflect.Descriptor instead.
+func (*EstimateResponse) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EstimateResponse) GetModels() map[string]*ModelInfo {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *EstimateResponse) GetWindow() *Window {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *EstimateResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type ModelInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Features     []string               `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	Coefficients []float32              `protobuf:"fixed32,2,rep,packed,name=coefficients,proto3" json:"coefficients,omitempty"`
+	Intercept    float32                `protobuf:"fixed32,3,opt,name=intercept,proto3" json:"intercept,omitempty"`
+	// per feature, aligned with coefficients
+	StandardErrors   []float32 `protobuf:"fixed32,4,rep,packed,name=standard_errors,json=standardErrors,proto3" json:"standard_errors,omitempty"`
+	TStatistics      []float32 `protobuf:"fixed32,5,rep,packed,name=t_statistics,json=tStatistics,proto3" json:"t_statistics,omitempty"`
+	PValues          []float32 `protobuf:"fixed32,6,rep,packed,name=p_values,json=pValues,proto3" json:"p_values,omitempty"`
+	CiLower          []float32 `protobuf:"fixed32,7,rep,packed,name=ci_lower,json=ciLower,proto3" json:"ci_lower,omitempty"`
+	CiUpper          []float32 `protobuf:"fixed32,8,rep,packed,name=ci_upper,json=ciUpper,proto3" json:"ci_upper,omitempty"`
+	RSquared         float32   `protobuf:"fixed32,9,opt,name=r_squared,json=rSquared,proto3" json:"r_squared,omitempty"`
+	ResidualVariance float32   `protobuf:"fixed32,10,opt,name=residual_variance,json=residualVariance,proto3" json:"residual_variance,omitempty"`
+	NObs             int32     `protobuf:"varint,11,opt,name=n_obs,json=nObs,proto3" json:"n_obs,omitempty"`
+	// "analytic" or "bootstrap"
+	Inference       string  `protobuf:"bytes,12,opt,name=inference,proto3" json:"inference,omitempty"`
+	ConfidenceLevel float32 `protobuf:"fixed32,13,opt,name=confidence_level,json=confidenceLevel,proto3" json:"confidence_level,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ModelInfo) Reset() {
+	*x = ModelInfo{}
+	mi := &file_v1alpha2_causal_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelInfo) ProtoMessage() {}
+
+func (x *ModelInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_v1alpha2_causal_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelInfo.ProtoReflect.Descriptor instead.
+func (*ModelInfo) Descriptor() ([]byte, []int) {
+	return file_v1alpha2_causal_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ModelInfo) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetCoefficients() []float32 {
+	if x != nil {
+		return x.Coefficients
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetIntercept() float32 {
+	if x != nil {
+		return x.Intercept
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetStandardErrors() []float32 {
+	if x != nil {
+		return x.StandardErrors
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetTStatistics() []float32 {
+	if x != nil {
+		return x.TStatistics
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetPValues() []float32 {
+	if x != nil {
+		return x.PValues
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetCiLower() []float32 {
+	if x != nil {
+		return x.CiLower
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetCiUpper() []float32 {
+	if x != nil {
+		return x.CiUpper
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetRSquared() float32 {
+	if x != nil {
+		return x.RSquared
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetResidualVariance() float32 {
+	if x != nil {
+		return x.ResidualVariance
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetNObs() int32 {
+	if x != nil {
+		return x.NObs
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetInference() string {
+	if x != nil {
+		return x.Inference
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetConfidenceLevel() float32 {
+	if x != nil {
+		return x.ConfidenceLevel
+	}
+	return 0
+}
+
+var File_v1alpha2_causal_proto protoreflect.FileDescriptor
+
+const file_v1alpha2_causal_proto_rawDesc = "" +
+	"\n" +
+	"\x15v1alpha2/causal.proto\x12\x0fcausal.v1alpha2\"\x8b\x01\n" +
+	"\x0fDiscoverRequest\x129\n" +
+	"\x06params\x18\x01 \x01(\v2\x1f.causal.v1alpha2.DiscoverParamsH\x00R\x06params\x122\n" +
+	"\x05chunk\x18\x02 \x01(\v2\x1a.causal.v1alpha2.DataChunkH\x00R\x05chunkB\t\n" +
+	"\apayload\"u\n" +
+	"\x0eDiscoverParams\x12/\n" +
+	"\x06schema\x18\x01 \x01(\v2\x17.causal.v1alpha2.SchemaR\x06schema\x12\x17\n" +
+	"\amax_lag\x18\x02 \x01(\x05R\x06maxLag\x12\x19\n" +
+	"\bpc_alpha\x18\x03 \x01(\x02R\apcAlpha\"\x8b\x01\n" +
+	"\x0fEstimateRequest\x129\n" +
+	"\x06params\x18\x01 \x01(\v2\x1f.causal.v1alpha2.EstimateParamsH\x00R\x06params\x122\n" +
+	"\x05chunk\x18\x02 \x01(\v2\x1a.causal.v1alpha2.DataChunkH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xda\x01\n" +
+	"\x0eEstimateParams\x12/\n" +
+	"\x06schema\x18\x01 \x01(\v2\x17.causal.v1alpha2.SchemaR\x06schema\x122\n" +
+	"\x05graph\x18\x02 \x01(\v2\x1c.causal.v1alpha2.CausalGraphR\x05graph\x12)\n" +
+	"\x10confidence_level\x18\x03 \x01(\x02R\x0fconfidenceLevel\x128\n" +
+	"\tbootstrap\x18\x04 \x01(\v2\x1a.causal.v1alpha2.BootstrapR\tbootstrap\"b\n" +
+	"\x06Schema\x12\x18\n" +
+	"\acolumns\x18\x01 \x03(\tR\acolumns\x12\x12\n" +
+	"\x04rows\x18\x02 \x01(\x03R\x04rows\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\tR\x04step\x12\x16\n" +
+	"\x06breaks\x18\x04 \x03(\x03R\x06breaks\"^\n" +
+	"\tDataChunk\x12\x1e\n" +
+	"\n" +
+	"timestamps\x18\x01 \x03(\x03R\n" +
+	"timestamps\x121\n" +
+	"\acolumns\x18\x02 \x03(\v2\x17.causal.v1alpha2.ColumnR\acolumns\":\n" +
+	"\x06Column\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x01R\x06values\x12\x18\n" +
+	"\amissing\x18\x02 \x03(\bR\amissing\"\x98\x01\n" +
+	"\vCausalGraph\x12+\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x15.causal.v1alpha2.NodeR\x05nodes\x12+\n" +
+	"\x05edges\x18\x02 \x03(\v2\x15.causal.v1alpha2.EdgeR\x05edges\x12/\n" +
+	"\x06window\x18\x03 \x01(\v2\x17.causal.v1alpha2.WindowR\x06window\"D\n" +
+	"\x06Window\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\tR\x04step\"L\n" +
+	"\x04Node\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1e\n" +
+	"\n" +
+	"transforms\x18\x03 \x03(\tR\n" +
+	"transforms\"\xb6\x01\n" +
+	"\x04Edge\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x10\n" +
+	"\x03lag\x18\x04 \x01(\x05R\x03lag\x12\x1c\n" +
+	"\tstatistic\x18\x05 \x01(\x02R\tstatistic\x12\x17\n" +
+	"\ap_value\x18\x06 \x01(\x02R\x06pValue\x12!\n" +
+	"\fpartial_corr\x18\a \x01(\x02R\vpartialCorr\"X\n" +
+	"\tBootstrap\x12\x18\n" +
+	"\asamples\x18\x01 \x01(\x05R\asamples\x12\x1d\n" +
+	"\n" +
+	"block_size\x18\x02 \x01(\x05R\tblockSize\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x04R\x04seed\"\x8e\x02\n" +
+	"\x10EstimateResponse\x12E\n" +
+	"\x06models\x18\x01 \x03(\v2-.causal.v1alpha2.EstimateResponse.ModelsEntryR\x06models\x12/\n" +
+	"\x06window\x18\x02 \x01(\v2\x17.causal.v1alpha2.WindowR\x06window\x12+\n" +
+	"\x05nodes\x18\x03 \x03(\v2\x15.causal.v1alpha2.NodeR\x05nodes\x1aU\n" +
+	"\vModelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.causal.v1alpha2.ModelInfoR\x05value:\x028\x01\"\xae\x03\n" +
+	"\tModelInfo\x12\x1a\n" +
+	"\bfeatures\x18\x01 \x03(\tR\bfeatures\x12\"\n" +
+	"\fcoefficients\x18\x02 \x03(\x02R\fcoefficients\x12\x1c\n" +
+	"\tintercept\x18\x03 \x01(\x02R\tintercept\x12'\n" +
+	"\x0fstandard_errors\x18\x04 \x03(\x02R\x0estandardErrors\x12!\n" +
+	"\ft_statistics\x18\x05 \x03(\x02R\vtStatistics\x12\x19\n" +
+	"\bp_values\x18\x06 \x03(\x02R\apValues\x12\x19\n" +
+	"\bci_lower\x18\a \x03(\x02R\aciLower\x12\x19\n" +
+	"\bci_upper\x18\b \x03(\x02R\aciUpper\x12\x1b\n" +
+	"\tr_squared\x18\t \x01(\x02R\brSquared\x12+\n" +
+	"\x11residual_variance\x18\n" +
+	" \x01(\x02R\x10residualVariance\x12\x13\n" +
+	"\x05n_obs\x18\v \x01(\x05R\x04nObs\x12\x1c\n" +
+	"\tinference\x18\f \x01(\tR\tinference\x12)\n" +
+	"\x10confidence_level\x18\r \x01(\x02R\x0fconfidenceLevel2a\n" +
+	"\x0fCausalDiscovery\x12N\n" +
+	"\bDiscover\x12 .causal.v1alpha2.DiscoverRequest\x1a\x1c.causal.v1alpha2.CausalGraph\"\x00(\x012g\n" +
+	"\x10CausalEstimation\x12S\n" +
+	"\bEstimate\x12 .causal.v1alpha2.EstimateRequest\x1a!.causal.v1alpha2.EstimateResponse\"\x00(\x01B+Z)github.com/w-h-a/caus/api/causal/v1alpha2b\x06proto3"
+
+var (
+	file_v1alpha2_causal_proto_rawDescOnce sync.Once
+	file_v1alpha2_causal_proto_rawDescData []byte
+)
+
+func file_v1alpha2_causal_proto_rawDescGZIP() []byte {
+	file_v1alpha2_causal_proto_rawDescOnce.Do(func() {
+		file_v1alpha2_causal_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v1alpha2_causal_proto_rawDesc), len(file_v1alpha2_causal_proto_rawDesc)))
+	})
+	return file_v1alpha2_causal_proto_rawDescData
+}
+
+var file_v1alpha2_causal_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_v1alpha2_causal_proto_goTypes = []any{
+	(*DiscoverRequest)(nil),  // 0: causal.v1alpha2.DiscoverRequest
+	(*DiscoverParams)(nil),   // 1: causal.v1alpha2.DiscoverParams
+	(*EstimateRequest)(nil),  // 2: causal.v1alpha2.EstimateRequest
+	(*EstimateParams)(nil),   // 3: causal.v1alpha2.EstimateParams
+	(*Schema)(nil),           // 4: causal.v1alpha2.Schema
+	(*DataChunk)(nil),        // 5: causal.v1alpha2.DataChunk
+	(*Column)(nil),           // 6: causal.v1alpha2.Column
+	(*CausalGraph)(nil),      // 7: causal.v1alpha2.CausalGraph
+	(*Window)(nil),           // 8: causal.v1alpha2.Window
+	(*Node)(nil),             // 9: causal.v1alpha2.Node
+	(*Edge)(nil),             // 10: causal.v1alpha2.Edge
+	(*Bootstrap)(nil),        // 11: causal.v1alpha2.Bootstrap
+	(*EstimateResponse)(nil), // 12: causal.v1alpha2.EstimateResponse
+	(*ModelInfo)(nil),        // 13: causal.v1alpha2.ModelInfo
+	nil,                      // 14: causal.v1alpha2.EstimateResponse.ModelsEntry
+}
+var file_v1alpha2_causal_proto_depIdxs = []int32{
+	1,  // 0: causal.v1alpha2.DiscoverRequest.params:type_name -> causal.v1alpha2.DiscoverParams
+	5,  // 1: causal.v1alpha2.DiscoverRequest.chunk:type_name -> causal.v1alpha2.DataChunk
+	4,  // 2: causal.v1alpha2.DiscoverParams.schema:type_name -> causal.v1alpha2.Schema
+	3,  // 3: causal.v1alpha2.EstimateRequest.params:type_name -> causal.v1alpha2.EstimateParams
+	5,  // 4: causal.v1alpha2.EstimateRequest.chunk:type_name -> causal.v1alpha2.DataChunk
+	4,  // 5: causal.v1alpha2.EstimateParams.schema:type_name -> causal.v1alpha2.Schema
+	7,  // 6: causal.v1alpha2.EstimateParams.graph:type_name -> causal.v1alpha2.CausalGraph
+	11, // 7: causal.v1alpha2.EstimateParams.bootstrap:type_name -> causal.v1alpha2.Bootstrap
+	6,  // 8: causal.v1alpha2.DataChunk.columns:type_name -> causal.v1alpha2.Column
+	9,  // 9: causal.v1alpha2.CausalGraph.nodes:type_name -> causal.v1alpha2.Node
+	10, // 10: causal.v1alpha2.CausalGraph.edges:type_name -> causal.v1alpha2.Edge
+	8,  // 11: causal.v1alpha2.CausalGraph.window:type_name -> causal.v1alpha2.Window
+	14, // 12: causal.v1alpha2.EstimateResponse.models:type_name -> causal.v1alpha2.EstimateResponse.ModelsEntry
+	8,  // 13: causal.v1alpha2.EstimateResponse.window:type_name -> causal.v1alpha2.Window
+	9,  // 14: causal.v1alpha2.EstimateResponse.nodes:type_name -> causal.v1alpha2.Node
+	13, // 15: causal.v1alpha2.EstimateResponse.ModelsEntry.value:type_name -> causal.v1alpha2.ModelInfo
+	0,  // 16: causal.v1alpha2.CausalDiscovery.Discover:input_type -> causal.v1alpha2.DiscoverRequest
+	2,  // 17: causal.v1alpha2.CausalEstimation.Estimate:input_type -> causal.v1alpha2.EstimateRequest
+	7,  // 18: causal.v1alpha2.CausalDiscovery.Discover:output_type -> causal.v1alpha2.CausalGraph
+	12, // 19: causal.v1alpha2.CausalEstimation.Estimate:output_type -> causal.v1alpha2.EstimateResponse
+	18, // [18:20] is the sub-list for method output_type
+	16, // [16:18] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_v1alpha2_causal_proto_init() }
+func file_v1alpha2_causal_proto_init() {
+	if File_v1alpha2_causal_proto != nil {
+		return
+	}
+	file_v1alpha2_causal_proto_msgTypes[0].OneofWrappers = []any{
+		(*DiscoverRequest_Params)(nil),
+		(*DiscoverRequest_Chunk)(nil),
+	}
+	file_v1alpha2_causal_proto_msgTypes[2].OneofWrappers = []any{
+		(*EstimateRequest_Params)(nil),
+		(*EstimateRequest_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1alpha2_causal_proto_rawDesc), len(file_v1alpha2_causal_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_v1alpha2_causal_proto_goTypes,
+		DependencyIndexes: file_v1alpha2_causal_proto_depIdxs,
+		MessageInfos:      file_v1alpha2_causal_proto_msgTypes,
+	}.Build()
+	File_v1alpha2_causal_proto = out.File
+	file_v1alpha2_causal_proto_goTypes = nil
+	file_v1alpha2_causal_proto_depIdxs = nil
+}
@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.9
+// source: v1alpha2/causal.proto
+
+package v1alpha2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CausalDiscovery_Discover_FullMethodName = "/causal.v1alpha2.CausalDiscovery/Discover"
+)
+
+// CausalDiscoveryClient is the client API for CausalDiscovery service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CausalDiscoveryClient interface {
+	Discover(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[DiscoverRequest, CausalGraph], error)
+}
+
+type causalDiscoveryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCausalDiscoveryClient(cc grpc.ClientConnInterface) CausalDiscoveryClient {
+	return &causalDiscoveryClient{cc}
+}
+
+func (c *causalDiscoveryClient) Discover(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[DiscoverRequest, CausalGraph], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CausalDiscovery_ServiceDesc.Streams[0], CausalDiscovery_Discover_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DiscoverRequest, CausalGraph]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CausalDiscovery_DiscoverClient = grpc.ClientStreamingClient[DiscoverRequest, CausalGraph]
+
+// CausalDiscoveryServer is the server API for CausalDiscovery service.
+// All implementations must embed UnimplementedCausalDiscoveryServer
+// for forward compatibility.
+type CausalDiscoveryServer interface {
+	Discover(grpc.ClientStreamingServer[DiscoverRequest, CausalGraph]) error
+	mustEmbedUnimplementedCausalDiscoveryServer()
+}
+
+// UnimplementedCausalDiscoveryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCausalDiscoveryServer struct{}
+
+func (UnimplementedCausalDiscoveryServer) Discover(grpc.ClientStreamingServer[DiscoverRequest, CausalGraph]) error {
+	return status.Errorf(codes.Unimplemented, "method Discover not implemented")
+}
+func (UnimplementedCausalDiscoveryServer) mustEmbedUnimplementedCausalDiscoveryServer() {}
+func (UnimplementedCausalDiscoveryServer) testEmbeddedByValue()                         {}
+
+// UnsafeCausalDiscoveryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CausalDiscoveryServer will
+// result in compilation errors.
+type UnsafeCausalDiscoveryServer interface {
+	mustEmbedUnimplementedCausalDiscoveryServer()
+}
+
+func RegisterCausalDiscoveryServer(s grpc.ServiceRegistrar, srv CausalDiscoveryServer) {
+	// If the following call pancis, it indicates UnimplementedCausalDiscoveryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CausalDiscovery_ServiceDesc, srv)
+}
+
+func _CausalDiscovery_Discover_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CausalDiscoveryServer).Discover(&grpc.GenericServerStream[DiscoverRequest, CausalGraph]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CausalDiscovery_DiscoverServer = grpc.ClientStreamingServer[DiscoverRequest, CausalGraph]
+
+// CausalDiscovery_ServiceDesc is the grpc.ServiceDesc for CausalDiscovery service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CausalDiscovery_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "causal.v1alpha2.CausalDiscovery",
+	HandlerType: (*CausalDiscoveryServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Discover",
+			Handler:       _CausalDiscovery_Discover_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "v1alpha2/causal.proto",
+}
+
+const (
+	CausalEstimation_Estimate_FullMethodName = "/causal.v1alpha2.CausalEstimation/Estimate"
+)
+
+// CausalEstimationClient is the client API for CausalEstimation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CausalEstimationClient interface {
+	Estimate(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EstimateRequest, EstimateResponse], error)
+}
+
+type causalEstimationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCausalEstimationClient(cc grpc.ClientConnInterface) CausalEstimationClient {
+	return &causalEstimationClient{cc}
+}
+
+func (c *causalEstimationClient) Estimate(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EstimateRequest, EstimateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CausalEstimation_ServiceDesc.Streams[0], CausalEstimation_Estimate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EstimateRequest, EstimateResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CausalEstimation_EstimateClient = grpc.ClientStreamingClient[EstimateRequest, EstimateResponse]
+
+// CausalEstimationServer is the server API for CausalEstimation service.
+// All implementations must embed UnimplementedCausalEstimationServer
+// for forward compatibility.
+type CausalEstimationServer interface {
+	Estimate(grpc.ClientStreamingServer[EstimateRequest, EstimateResponse]) error
+	mustEmbedUnimplementedCausalEstimationServer()
+}
+
+// UnimplementedCausalEstimationServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCausalEstimationServer struct{}
+
+func (UnimplementedCausalEstimationServer) Estimate(grpc.ClientStreamingServer[EstimateRequest, EstimateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Estimate not implemented")
+}
+func (UnimplementedCausalEstimationServer) mustEmbedUnimplementedCausalEstimationServer() {}
+func (UnimplementedCausalEstimationServer) testEmbeddedByValue()                          {}
+
+// UnsafeCausalEstimationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CausalEstimationServer will
+// result in compilation errors.
+type UnsafeCausalEstimationServer interface {
+	mustEmbedUnimplementedCausalEstimationServer()
+}
+
+func RegisterCausalEstimationServer(s grpc.ServiceRegistrar, srv CausalEstimationServer) {
+	// If the following call pancis, it indicates UnimplementedCausalEstimationServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CausalEstimation_ServiceDesc, srv)
+}
+
+func _CausalEstimation_Estimate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CausalEstimationServer).Estimate(&grpc.GenericServerStream[EstimateRequest, EstimateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CausalEstimation_EstimateServer = grpc.ClientStreamingServer[EstimateRequest, EstimateResponse]
+
+// CausalEstimation_ServiceDesc is the grpc.ServiceDesc for CausalEstimation service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CausalEstimation_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "causal.v1alpha2.CausalEstimation",
+	HandlerType: (*CausalEstimationServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Estimate",
+			Handler:       _CausalEstimation_Estimate_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "v1alpha2/causal.proto",
+}
@@ -3,12 +3,17 @@ package v1alpha1
 import (
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 )
 
 var (
 	SupportedImplementations = map[string][]string{
 		"metrics": {"mock", "random", "csv", "prometheus", "datadog"},
-		"traces":  {"mock", "random", "csv", "clickhouse", "datadog"}, // TODO: honeycomb
+		"traces":  {"mock", "random", "csv", "clickhouse", "datadog", "honeycomb"},
+		"events":  {"mock", "file", "http", "prometheus"},
+		// annotations sources only back exclusions
+		"annotations": {"mock", "file", "http", "grafana"},
 	}
 
 	SupportedDimensions = []string{"calls", "duration"}
@@ -19,10 +24,106 @@ var (
 	}
 
 	SupportedAttributeQueryOperators = []string{"equals", "contains", "isnotnull"}
+
+	SupportedFillStrategies = []string{FillForward, FillLinear, FillZero, FillMean, FillDrop, FillNone}
+
+	SupportedOtherAggregations = []string{"sum", "avg", "min", "max"}
+
+	SupportedIndicators = []string{IndicatorBinary, IndicatorCount}
+
+	SupportedResampleAggregations = []string{ResampleMean, ResampleSum, ResampleMin, ResampleMax, ResampleFirst, ResampleLast}
+
+	SupportedResampleLabels = []string{LabelStart, LabelEnd}
+
+	SupportedUpsampleStrategies = []string{UpsampleHold, UpsampleLinear, UpsampleNone}
+
+	SupportedTransforms = []string{
+		TransformRate, TransformDerivative, TransformDiff, TransformLog, TransformLog1p, TransformZScore,
+		TransformRollingMean, TransformRollingMedian, TransformWinsorize, TransformSeasonalDiff,
+	}
+)
+
+const (
+	// FillForward carries the last observation forward.
+	FillForward = "ffill"
+	// FillLinear interpolates between the observations either side of a gap.
+	FillLinear = "linear"
+	// FillZero treats a missing bucket as zero, e.g. no calls.
+	FillZero = "zero"
+	// FillMean uses the mean of the observed values.
+	FillMean = "mean"
+	// FillDrop drops every row where the variable is missing.
+	FillDrop = "drop"
+	// FillNone leaves missing values for the analysis to skip.
+	FillNone = "none"
+)
+
+const (
+	ResampleMean  = "mean"
+	ResampleSum   = "sum"
+	ResampleMin   = "min"
+	ResampleMax   = "max"
+	ResampleFirst = "first"
+	ResampleLast  = "last"
+)
+
+const (
+	// LabelStart means a point's timestamp marks the start of the interval
+	// it describes, e.g. ClickHouse's toStartOfInterval.
+	LabelStart = "start"
+	// LabelEnd means a point's timestamp marks the end of the interval it
+	// describes, e.g. a Prometheus rate over the preceding window.
+	LabelEnd = "end"
+)
+
+const (
+	// UpsampleHold repeats a coarse point over every step its interval
+	// covers, spreading it evenly for sum.
+	UpsampleHold = "hold"
+	// UpsampleLinear interpolates between consecutive coarse points.
+	UpsampleLinear = "linear"
+	// UpsampleNone leaves the steps between coarse points missing.
+	UpsampleNone = "none"
+)
+
+const (
+	// IndicatorBinary is 1 for a step with any event and 0 otherwise.
+	IndicatorBinary = "binary"
+	// IndicatorCount is the number of events in a step.
+	IndicatorCount = "count"
+)
+
+const (
+	// TransformRate is the per-second rate of a counter, allowing for resets.
+	TransformRate = "rate"
+	// TransformDerivative is the per-second change of a gauge.
+	TransformDerivative = "derivative"
+	// TransformDiff is the change from the previous step.
+	TransformDiff  = "diff"
+	TransformLog   = "log"
+	TransformLog1p = "log1p"
+	// TransformZScore standardizes to zero mean and unit variance.
+	TransformZScore = "zscore"
+	// TransformRollingMean is the trailing mean over Window steps.
+	TransformRollingMean = "rolling_mean"
+	// TransformRollingMedian is the trailing median over Window steps.
+	TransformRollingMedian = "rolling_median"
+	// TransformWinsorize clips to the Lower and Upper quantiles.
+	TransformWinsorize = "winsorize"
+	// TransformSeasonalDiff is the change from Period steps earlier.
+	TransformSeasonalDiff = "seasonal_diff"
 )
 
 type DiscoveryConfig struct {
 	Variables []VariableDefinition `yaml:"variables"`
+	// MaxMissingRatio fails the run when any variable is missing more than
+	// this fraction of its steps before filling. Zero means no limit.
+	MaxMissingRatio float64 `yaml:"max_missing_ratio,omitempty"`
+	// Quality rules that fail the run after the data quality report.
+	Quality *QualityRules `yaml:"quality,omitempty"`
+	// Exclusions are windows, e.g. deploys or load tests, whose steps are
+	// left out of the analysis.
+	Exclusions []Exclusion `yaml:"exclusions,omitempty"`
 }
 
 func (c *DiscoveryConfig) Validate() error {
@@ -30,6 +131,22 @@ func (c *DiscoveryConfig) Validate() error {
 		return fmt.Errorf("no variables defined")
 	}
 
+	if c.MaxMissingRatio < 0 || c.MaxMissingRatio > 1 {
+		return fmt.Errorf("max_missing_ratio must be between 0 and 1")
+	}
+
+	if c.Quality != nil {
+		if err := c.Quality.Validate(); err != nil {
+			return fmt.Errorf("quality invalid: %w", err)
+		}
+	}
+
+	for i, e := range c.Exclusions {
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("exclusions[%d] invalid: %w", i, err)
+		}
+	}
+
 	for i, v := range c.Variables {
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf("variable[%d] '%s' invalid: %w", i, v.Name, err)
@@ -39,11 +156,160 @@ func (c *DiscoveryConfig) Validate() error {
 	return nil
 }
 
+// QualityRules fail a run whose fetched data is unfit for analysis. The
+// ratios are fractions of the steps in the window; zero disables a rule.
+type QualityRules struct {
+	FailOnConstant    bool    `yaml:"fail_on_constant,omitempty"`
+	MaxFilledRatio    float64 `yaml:"max_filled_ratio,omitempty"`
+	MaxOutlierRatio   float64 `yaml:"max_outlier_ratio,omitempty"`
+	MaxDuplicateRatio float64 `yaml:"max_duplicate_ratio,omitempty"`
+}
+
+func (q *QualityRules) Validate() error {
+	if q.MaxFilledRatio < 0 || q.MaxFilledRatio > 1 {
+		return fmt.Errorf("max_filled_ratio must be between 0 and 1")
+	}
+
+	if q.MaxOutlierRatio < 0 || q.MaxOutlierRatio > 1 {
+		return fmt.Errorf("max_outlier_ratio must be between 0 and 1")
+	}
+
+	if q.MaxDuplicateRatio < 0 || q.MaxDuplicateRatio > 1 {
+		return fmt.Errorf("max_duplicate_ratio must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// Exclusion is either a literal window, or every annotation of a source
+// that carries all of Tags.
+type Exclusion struct {
+	Start  time.Time `yaml:"start,omitempty"`
+	End    time.Time `yaml:"end,omitempty"`
+	Reason string    `yaml:"reason,omitempty"`
+	Source *Source   `yaml:"source,omitempty"`
+	Tags   []string  `yaml:"tags,omitempty"`
+	// Padding widens each window on both sides, e.g. to cover the warm-up
+	// after a deploy.
+	Padding time.Duration `yaml:"padding,omitempty"`
+}
+
+func (e *Exclusion) Validate() error {
+	if e.Padding < 0 {
+		return fmt.Errorf("padding must not be negative")
+	}
+
+	if e.Source == nil {
+		if len(e.Tags) > 0 {
+			return fmt.Errorf("tags require a source")
+		}
+		if e.Start.IsZero() || e.End.IsZero() {
+			return fmt.Errorf("start and end are required without a source")
+		}
+		if !e.End.After(e.Start) {
+			return fmt.Errorf("end must be after start")
+		}
+		return nil
+	}
+
+	if !e.Start.IsZero() || !e.End.IsZero() {
+		return fmt.Errorf("start and end can't be combined with a source")
+	}
+
+	if e.Source.Type != "annotations" {
+		return fmt.Errorf("source type must be 'annotations'")
+	}
+
+	if err := e.Source.Validate(); err != nil {
+		return fmt.Errorf("source invalid: %w", err)
+	}
+
+	return nil
+}
+
 type VariableDefinition struct {
 	Name         string             `yaml:"name"`
 	Source       *Source            `yaml:"source"`
 	MetricsQuery string             `yaml:"metrics_query,omitempty"`
 	TraceQuery   *TraceQueryDetails `yaml:"trace_query,omitempty"`
+	EventQuery   *EventQueryDetails `yaml:"event_query,omitempty"`
+	Fill         *FillPolicy        `yaml:"fill,omitempty"`
+	// Resample is how the fetched points are brought onto the step grid.
+	Resample *ResamplePolicy `yaml:"resample,omitempty"`
+	// Expression computes a derived variable from other variables, e.g.
+	// "errors / calls".
+	Expression string `yaml:"expression,omitempty"`
+	// SplitBy turns each series of a multi-series query into its own
+	// variable, e.g. one per pod.
+	SplitBy []string `yaml:"split_by,omitempty"`
+	// SeriesName names the split variables, e.g. "cpu_{{pod}}". The default
+	// joins the name and the split_by label values with '_'.
+	SeriesName string `yaml:"series_name,omitempty"`
+	// MaxSeries keeps the series with the largest means. Zero means 20.
+	MaxSeries int `yaml:"max_series,omitempty"`
+	// OtherSeries aggregates the series beyond MaxSeries into a
+	// "<name>_other" variable. Empty means they're dropped.
+	OtherSeries string `yaml:"other_series,omitempty"`
+	// Transforms are applied in order after the gaps are filled.
+	Transforms []Transform `yaml:"transforms,omitempty"`
+}
+
+// Split reports whether v fans out into one variable per series.
+func (v *VariableDefinition) Split() bool {
+	return len(v.SplitBy) > 0
+}
+
+func (v *VariableDefinition) validateSplit() error {
+	if !v.Split() {
+		if len(v.SeriesName) > 0 || v.MaxSeries != 0 || len(v.OtherSeries) > 0 {
+			return fmt.Errorf("series_name, max_series and other_series require split_by")
+		}
+		return nil
+	}
+
+	if v.Source.Type != "metrics" {
+		return fmt.Errorf("split_by only applies to source type 'metrics'")
+	}
+
+	for _, label := range v.SplitBy {
+		if len(label) == 0 {
+			return fmt.Errorf("split_by has an empty label")
+		}
+	}
+
+	if len(v.SeriesName) > 0 {
+		rest := v.SeriesName
+		for {
+			_, after, ok := strings.Cut(rest, "{{")
+			if !ok {
+				break
+			}
+			label, after, ok := strings.Cut(after, "}}")
+			if !ok {
+				return fmt.Errorf("series_name has an unclosed '{{'")
+			}
+			if !slices.Contains(v.SplitBy, strings.TrimSpace(label)) {
+				return fmt.Errorf("series_name references '%s', which is not in split_by", strings.TrimSpace(label))
+			}
+			rest = after
+		}
+	}
+
+	if v.MaxSeries < 0 {
+		return fmt.Errorf("max_series must not be negative")
+	}
+
+	if len(v.OtherSeries) > 0 && !slices.Contains(SupportedOtherAggregations, v.OtherSeries) {
+		return fmt.Errorf("unsupported other_series '%s'. Supported: %v", v.OtherSeries, SupportedOtherAggregations)
+	}
+
+	return nil
+}
+
+// Derived reports whether v is computed from other variables rather than
+// fetched.
+func (v *VariableDefinition) Derived() bool {
+	return v.Source != nil && v.Source.Type == "derived"
 }
 
 func (v *VariableDefinition) Validate() error {
@@ -71,8 +337,142 @@ func (v *VariableDefinition) Validate() error {
 		if err := v.TraceQuery.Validate(); err != nil {
 			return fmt.Errorf("trace_query invalid: %w", err)
 		}
+	case "events":
+		if v.EventQuery == nil {
+			return fmt.Errorf("event_query is required for source type 'events'")
+		}
+		if err := v.EventQuery.Validate(v.Source.Impl); err != nil {
+			return fmt.Errorf("event_query invalid: %w", err)
+		}
+	case "derived":
+		if len(v.Expression) == 0 {
+			return fmt.Errorf("expression is required for source type 'derived'")
+		}
 	default:
-		return fmt.Errorf("unknown source type '%s' (supported: metrics, traces)", v.Source.Type)
+		return fmt.Errorf("unknown source type '%s' (supported: metrics, traces, events, derived)", v.Source.Type)
+	}
+
+	if len(v.Expression) > 0 && !v.Derived() {
+		return fmt.Errorf("expression only applies to source type 'derived'")
+	}
+
+	if err := v.validateSplit(); err != nil {
+		return err
+	}
+
+	if v.Fill != nil {
+		if err := v.Fill.Validate(); err != nil {
+			return fmt.Errorf("fill invalid: %w", err)
+		}
+	}
+
+	if v.Resample != nil {
+		if v.Derived() {
+			return fmt.Errorf("resample doesn't apply to derived variables")
+		}
+		if err := v.Resample.Validate(); err != nil {
+			return fmt.Errorf("resample invalid: %w", err)
+		}
+	}
+
+	for i, t := range v.Transforms {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("transforms[%d] invalid: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// FillPolicy is how missing steps of a variable are filled in after the
+// fetch.
+type FillPolicy struct {
+	Strategy string `yaml:"strategy"` // e.g., "ffill", "linear", "zero", "mean", "drop", "none"
+	// MaxGap is the longest run of missing steps ffill and linear will
+	// bridge; longer gaps stay missing. Zero means no limit.
+	MaxGap int `yaml:"max_gap,omitempty"`
+}
+
+func (f *FillPolicy) Validate() error {
+	if !slices.Contains(SupportedFillStrategies, f.Strategy) {
+		return fmt.Errorf("unsupported strategy '%s'. Supported: %v", f.Strategy, SupportedFillStrategies)
+	}
+
+	if f.MaxGap < 0 {
+		return fmt.Errorf("max_gap must not be negative")
+	}
+
+	if f.MaxGap > 0 && f.Strategy != FillForward && f.Strategy != FillLinear {
+		return fmt.Errorf("max_gap only applies to '%s' and '%s'", FillForward, FillLinear)
+	}
+
+	return nil
+}
+
+// ResamplePolicy is how the points a fetcher returns at their native
+// resolution become one value per step. Empty fields take the defaults.
+type ResamplePolicy struct {
+	// Aggregation combines the points that fall into one step when the
+	// native resolution is finer than the step.
+	Aggregation string `yaml:"aggregation,omitempty"`
+	// Label is which end of its interval a point's timestamp marks.
+	Label string `yaml:"label,omitempty"`
+	// Upsample spreads a point over the steps its interval covers when the
+	// native resolution is coarser than the step.
+	Upsample string `yaml:"upsample,omitempty"`
+}
+
+func (r *ResamplePolicy) Validate() error {
+	if len(r.Aggregation) > 0 && !slices.Contains(SupportedResampleAggregations, r.Aggregation) {
+		return fmt.Errorf("unsupported aggregation '%s'. Supported: %v", r.Aggregation, SupportedResampleAggregations)
+	}
+
+	if len(r.Label) > 0 && !slices.Contains(SupportedResampleLabels, r.Label) {
+		return fmt.Errorf("unsupported label '%s'. Supported: %v", r.Label, SupportedResampleLabels)
+	}
+
+	if len(r.Upsample) > 0 && !slices.Contains(SupportedUpsampleStrategies, r.Upsample) {
+		return fmt.Errorf("unsupported upsample '%s'. Supported: %v", r.Upsample, SupportedUpsampleStrategies)
+	}
+
+	return nil
+}
+
+type Transform struct {
+	Type   string  `yaml:"type"`             // e.g., "rate", "log1p", "rolling_mean", etc
+	Window int     `yaml:"window,omitempty"` // rolling_mean, rolling_median
+	Period int     `yaml:"period,omitempty"` // seasonal_diff
+	Lower  float64 `yaml:"lower,omitempty"`  // winsorize
+	Upper  float64 `yaml:"upper,omitempty"`  // winsorize
+}
+
+func (t *Transform) Validate() error {
+	if !slices.Contains(SupportedTransforms, t.Type) {
+		return fmt.Errorf("unsupported transform '%s'. Supported: %v", t.Type, SupportedTransforms)
+	}
+
+	rolling := t.Type == TransformRollingMean || t.Type == TransformRollingMedian
+
+	if rolling && t.Window < 2 {
+		return fmt.Errorf("%s requires a window of at least 2 steps", t.Type)
+	}
+	if !rolling && t.Window != 0 {
+		return fmt.Errorf("window only applies to '%s' and '%s'", TransformRollingMean, TransformRollingMedian)
+	}
+
+	if t.Type == TransformSeasonalDiff && t.Period < 1 {
+		return fmt.Errorf("%s requires a period of at least 1 step", t.Type)
+	}
+	if t.Type != TransformSeasonalDiff && t.Period != 0 {
+		return fmt.Errorf("period only applies to '%s'", TransformSeasonalDiff)
+	}
+
+	if t.Type == TransformWinsorize {
+		if t.Lower < 0 || t.Upper > 1 || t.Lower >= t.Upper {
+			return fmt.Errorf("%s requires 0 <= lower < upper <= 1", t.Type)
+		}
+	} else if t.Lower != 0 || t.Upper != 0 {
+		return fmt.Errorf("lower and upper only apply to '%s'", TransformWinsorize)
 	}
 
 	return nil
@@ -91,6 +491,11 @@ func (s *Source) Validate() error {
 		return fmt.Errorf("type is required")
 	}
 
+	// derived variables are computed in-process
+	if s.Type == "derived" {
+		return nil
+	}
+
 	if len(s.Impl) == 0 {
 		return fmt.Errorf("impl is required")
 	}
@@ -111,6 +516,41 @@ func (s *Source) Validate() error {
 	return nil
 }
 
+// EventQueryDetails selects events, e.g. deploys or feature flag flips, and
+// how they become a variable.
+type EventQueryDetails struct {
+	// Tags select the events of a file or http source that carry all of
+	// them.
+	Tags []string `yaml:"tags,omitempty"`
+	// Query is a Prometheus series selector whose value changes with every
+	// event, e.g. a deployment's generation. Each change is an event.
+	Query string `yaml:"query,omitempty"`
+	// Indicator is binary (the default) or count.
+	Indicator string `yaml:"indicator,omitempty"`
+}
+
+func (e *EventQueryDetails) Validate(impl string) error {
+	if len(e.Indicator) > 0 && !slices.Contains(SupportedIndicators, e.Indicator) {
+		return fmt.Errorf("unsupported indicator '%s'. Supported: %v", e.Indicator, SupportedIndicators)
+	}
+
+	switch impl {
+	case "prometheus":
+		if len(e.Query) == 0 {
+			return fmt.Errorf("query is required for impl 'prometheus'")
+		}
+		if len(e.Tags) > 0 {
+			return fmt.Errorf("tags don't apply to impl 'prometheus'")
+		}
+	default:
+		if len(e.Query) > 0 {
+			return fmt.Errorf("query only applies to impl 'prometheus'")
+		}
+	}
+
+	return nil
+}
+
 type TraceQueryDetails struct {
 	ServiceName       string           `yaml:"service"`
 	Dimension         string           `yaml:"dimension"` // e.g., "duration", "calls", etc
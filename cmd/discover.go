@@ -3,36 +3,47 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
 	"github.com/w-h-a/caus/internal/client/discoverer"
-	"github.com/w-h-a/caus/internal/client/discoverer/v1alpha1"
 	"github.com/w-h-a/caus/internal/client/estimator/noop"
-	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/render"
 	"github.com/w-h-a/caus/internal/service/orchestrator"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 func Discover(c *cli.Context) error {
 	ctx := c.Context
 
 	// 1. Parse inputs
-	configPath := c.String("vars")
-	cfg, err := config.LoadConfig(configPath)
+	format, err := discoveryFormat(c)
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return err
+	}
+
+	if len(c.String("dataset")) > 0 {
+		return discoverFromDataset(c, format)
 	}
 
-	step := c.Duration("step")
-	now := time.Now().UTC().Truncate(step)
-	start := now.Add(-1 * c.Duration("start"))
-	end := now.Add(-1 * c.Duration("end"))
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+
+	start, end, step, err := resolveWindow(c)
+	if err != nil {
+		return err
+	}
 
 	args := orchestrator.DiscoveryArgs{
-		MaxLag:  int32(c.Int("lag")),
-		PcAlpha: float32(c.Float64("alpha")),
+		MaxLag:      int32(c.Int("lag")),
+		PcAlpha:     float32(c.Float64("alpha")),
+		MinStrength: float32(c.Float64("min-strength")),
 	}
 
 	log.Printf("Starting Discovery on %d variables...", len(cfg.Variables))
@@ -44,15 +55,20 @@ func Discover(c *cli.Context) error {
 		return err
 	}
 
-	// TODO: pass in discoverer config and location via cli or expand variable cfg
-	v1alpha1Discoverer := v1alpha1.NewDiscoverer(
-		discoverer.WithLocation("localhost:50051"),
-	)
+	orchestratorOpts, err := initOrchestratorOptions(c, cfg)
+	if err != nil {
+		return err
+	}
+
+	discovererImpl, err := initDiscoverer(c)
+	if err != nil {
+		return err
+	}
 
 	noopEstimator := noop.NewEstimator()
 
 	// 3. Build services
-	o := orchestrator.New(fetchers, v1alpha1Discoverer, noopEstimator)
+	o := orchestrator.New(fetchers, discovererImpl, noopEstimator, orchestratorOpts...)
 
 	// 4. Run Discover
 	graph, err := o.Discover(
@@ -67,26 +83,98 @@ func Discover(c *cli.Context) error {
 		return err
 	}
 
-	// 5. Print graph (json or pretty)
+	// 5. Print graph
+	return printDiscovery(graph, step, format)
+}
+
+func discoverFromDataset(c *cli.Context, format string) error {
+	ctx := c.Context
+
+	// 1. Parse inputs
+	ds, err := loadDataset(c)
+	if err != nil {
+		return err
+	}
+
+	args := orchestrator.DiscoveryArgs{
+		MaxLag:      int32(c.Int("lag")),
+		PcAlpha:     float32(c.Float64("alpha")),
+		MinStrength: float32(c.Float64("min-strength")),
+	}
+
+	// 2. Build clients
+	discovererImpl, err := initDiscoverer(c)
+	if err != nil {
+		return err
+	}
+
+	noopEstimator := noop.NewEstimator()
+
+	// 3. Build services
+	o := orchestrator.New(nil, discovererImpl, noopEstimator)
+
+	// 4. Run Discover
+	graph, err := o.DiscoverDataset(ctx, ds, args)
+	if err != nil {
+		return err
+	}
+
+	// 5. Print graph
+	return printDiscovery(graph, ds.Step, format)
+}
+
+// discoveryFormat is how to print a graph: 'text', 'json' or one of the
+// render formats. --json is short for --format=json.
+func discoveryFormat(c *cli.Context) (string, error) {
 	if c.Bool("json") {
-		opts := protojson.MarshalOptions{
-			Multiline:       true,
-			Indent:          "  ",
-			EmitUnpopulated: true,
+		return "json", nil
+	}
+
+	switch format := strings.ToLower(c.String("format")); format {
+	case "", "text":
+		return "text", nil
+	case "json":
+		return format, nil
+	default:
+		if _, err := render.ParseFormat(format); err != nil {
+			return "", fmt.Errorf("unknown graph format '%s' (supported: text, json, dot, mermaid, graphml)", c.String("format"))
 		}
-		bs, _ := opts.Marshal(graph)
-		fmt.Println(string(bs))
-	} else {
+		return format, nil
+	}
+}
+
+func printDiscovery(graph *causal.CausalGraph, step time.Duration, format string) error {
+	switch format {
+	case "json":
+		printJSON(graph)
+		return nil
+	case "text":
 		printGraph(graph, step)
+		return nil
+	default:
+		return render.Write(os.Stdout, graph, nil, render.Format(format))
 	}
+}
 
-	return nil
+func printJSON(m proto.Message) {
+	opts := protojson.MarshalOptions{
+		Multiline:       true,
+		Indent:          "  ",
+		EmitUnpopulated: true,
+	}
+	bs, _ := opts.Marshal(m)
+	fmt.Println(string(bs))
 }
 
 func printGraph(graph *causal.CausalGraph, step time.Duration) {
 	fmt.Println("\n--- Causal Graph Results ---")
+	printWindow(graph.Window)
 	fmt.Println("Nodes:")
 	for _, node := range graph.Nodes {
+		if len(node.Transforms) > 0 {
+			fmt.Printf("  - %s = %s\n", node.Label, render.DescribeTransforms(node))
+			continue
+		}
 		fmt.Printf("  - %s\n", node.Label)
 	}
 	fmt.Println("\nDiscovered Edges:")
@@ -95,8 +183,32 @@ func printGraph(graph *causal.CausalGraph, step time.Duration) {
 	} else {
 		for _, edge := range graph.Edges {
 			lagTime := time.Duration(edge.Lag) * step
-			fmt.Printf("  - %s --> %s (lag: %d = %s)\n", edge.Source, edge.Target, edge.Lag, lagTime)
+			fmt.Printf(
+				"  - %s %s %s (lag: %d = %s) [strength: %.3f, t: %.2f, p: %.3g]\n",
+				edge.Source,
+				discoverer.EdgeArrow(edge.Type),
+				edge.Target,
+				edge.Lag,
+				lagTime,
+				edge.PartialCorr,
+				edge.Statistic,
+				edge.PValue,
+			)
 		}
 	}
+	if n := unoriented(graph); n > 0 {
+		fmt.Printf("\n%d edges could not be oriented (o-o undirected, <-> hidden common cause, x-x conflicting).\n", n)
+		fmt.Println("Orient or remove them before passing the graph to 'estimate', or pass --allow-unoriented to ignore them.")
+	}
 	fmt.Println("--------------------------")
 }
+
+func unoriented(graph *causal.CausalGraph) int {
+	n := 0
+	for _, edge := range graph.Edges {
+		if edge.Type != discoverer.EdgeDirected && len(edge.Type) > 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -5,14 +5,13 @@ import (
 	"log"
 	"math"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/urfave/cli/v2"
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
 	"github.com/w-h-a/caus/internal/client/discoverer/noop"
-	"github.com/w-h-a/caus/internal/client/estimator"
-	"github.com/w-h-a/caus/internal/client/estimator/v1alpha1"
-	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/render"
 	"github.com/w-h-a/caus/internal/service/orchestrator"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -21,30 +20,27 @@ func Estimate(c *cli.Context) error {
 	ctx := c.Context
 
 	// 1. Parse inputs
-	configPath := c.String("vars")
-	cfg, err := config.LoadConfig(configPath)
-	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+	if len(c.String("dataset")) > 0 {
+		return estimateFromDataset(c)
 	}
 
-	bs, err := os.ReadFile(c.String("graph"))
+	cfg, err := loadConfig(c)
 	if err != nil {
-		return fmt.Errorf("failed to read graph: %w", err)
-	}
-	var graph causal.CausalGraph
-	if err := protojson.Unmarshal(bs, &graph); err != nil {
-		return fmt.Errorf("invalid graph: %w", err)
+		return err
 	}
 
-	step := c.Duration("step")
-	now := time.Now().UTC().Truncate(step)
-	start := now.Add(-1 * c.Duration("start"))
-	end := now.Add(-1 * c.Duration("end"))
+	graph, err := loadGraph(c)
+	if err != nil {
+		return err
+	}
 
-	args := orchestrator.EstimateArgs{
-		Graph: &graph,
+	start, end, step, err := resolveWindow(c)
+	if err != nil {
+		return err
 	}
 
+	args := estimateArgs(c, graph)
+
 	log.Printf("Starting Estimation on %d variables...", len(cfg.Variables))
 	log.Printf("Window: %s -> %s (Step: %s)", start.Format(time.RFC3339), end.Format(time.RFC3339), step)
 
@@ -54,15 +50,20 @@ func Estimate(c *cli.Context) error {
 		return err
 	}
 
+	orchestratorOpts, err := initOrchestratorOptions(c, cfg)
+	if err != nil {
+		return err
+	}
+
 	noopDiscoverer := noop.NewDiscoverer()
 
-	// TODO: pass in discoverer config and location via cli or expand variable cfg
-	v1alpha1Estimator := v1alpha1.NewEstimator(
-		estimator.WithLocation("localhost:50051"),
-	)
+	estimatorImpl, err := initEstimator(c)
+	if err != nil {
+		return err
+	}
 
 	// 3. Build services
-	o := orchestrator.New(fetchers, noopDiscoverer, v1alpha1Estimator)
+	o := orchestrator.New(fetchers, noopDiscoverer, estimatorImpl, orchestratorOpts...)
 
 	// 4. Run Estimate
 	results, err := o.Estimate(
@@ -78,27 +79,170 @@ func Estimate(c *cli.Context) error {
 	}
 
 	// 5. Display results
+	if c.Bool("json") {
+		printJSON(results)
+		return nil
+	}
+
 	return printEstimationResults(results)
 }
 
+func estimateFromDataset(c *cli.Context) error {
+	ctx := c.Context
+
+	// 1. Parse inputs
+	ds, err := loadDataset(c)
+	if err != nil {
+		return err
+	}
+
+	graph, err := loadGraph(c)
+	if err != nil {
+		return err
+	}
+
+	args := estimateArgs(c, graph)
+
+	// 2. Build clients
+	noopDiscoverer := noop.NewDiscoverer()
+
+	estimatorImpl, err := initEstimator(c)
+	if err != nil {
+		return err
+	}
+
+	// 3. Build services
+	o := orchestrator.New(nil, noopDiscoverer, estimatorImpl)
+
+	// 4. Run Estimate
+	results, err := o.EstimateDataset(ctx, ds, args)
+	if err != nil {
+		return err
+	}
+
+	// 5. Display results
+	if c.Bool("json") {
+		printJSON(results)
+		return nil
+	}
+
+	return printEstimationResults(results)
+}
+
+func loadGraph(c *cli.Context) (*causal.CausalGraph, error) {
+	bs, err := os.ReadFile(c.String("graph"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read graph: %w", err)
+	}
+
+	var graph causal.CausalGraph
+	if err := protojson.Unmarshal(bs, &graph); err != nil {
+		return nil, fmt.Errorf("invalid graph: %w", err)
+	}
+
+	return &graph, nil
+}
+
+func loadEstimate(c *cli.Context) (*causal.EstimateResponse, error) {
+	bs, err := os.ReadFile(c.String("estimate"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read estimate: %w", err)
+	}
+
+	var estimate causal.EstimateResponse
+	if err := protojson.Unmarshal(bs, &estimate); err != nil {
+		return nil, fmt.Errorf("invalid estimate: %w", err)
+	}
+
+	return &estimate, nil
+}
+
+func estimateArgs(c *cli.Context, graph *causal.CausalGraph) orchestrator.EstimateArgs {
+	return orchestrator.EstimateArgs{
+		Graph:           graph,
+		ConfidenceLevel: float32(c.Float64("confidence")),
+		Bootstrap: orchestrator.BootstrapArgs{
+			Samples:   int32(c.Int("bootstrap")),
+			BlockSize: int32(c.Int("block-size")),
+			Seed:      c.Uint64("seed"),
+		},
+		AllowUnoriented: c.Bool("allow-unoriented"),
+	}
+}
+
 func printEstimationResults(results *causal.EstimateResponse) error {
 	fmt.Printf("\n--- Causal Physics (Discovered Coefficients) ---\n")
+	printWindow(results.Window)
+	fmt.Println("")
+
+	nodes := make([]string, 0, len(results.Models))
+	for node := range results.Models {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		model := results.Models[node]
 
-	for node, model := range results.Models {
 		fmt.Printf("Node: %s\n", node)
 		fmt.Printf("  Intercept: %.4f\n", model.Intercept)
+		fmt.Printf("  R²: %.4f  Residual variance: %.4f  n: %d  (%s, %.0f%% CI)\n", model.RSquared, model.ResidualVariance, model.NObs, model.Inference, 100*model.ConfidenceLevel)
+		fmt.Printf("  %-24s %10s %10s %8s %10s  %s\n", "feature", "coef", "std err", "t", "p", "interval")
 
 		for i, feature := range model.Features {
-			coeff := model.Coefficients[i]
-			strength := ""
-			if math.Abs(float64(coeff)) > 1.0 {
-				strength = " (STRONG)"
-			}
-			fmt.Printf("  -> %s: %.4f%s\n", feature, coeff, strength)
+			fmt.Printf(
+				"  %-24s %10.4f %10.4f %8.2f %10.4g  [%9.4f, %9.4f] %s\n",
+				feature,
+				model.Coefficients[i],
+				at(model.StandardErrors, i),
+				at(model.TStatistics, i),
+				at(model.PValues, i),
+				at(model.CiLower, i),
+				at(model.CiUpper, i),
+				stars(at(model.PValues, i), len(model.PValues) > i),
+			)
 		}
 
 		fmt.Println("")
 	}
 
+	fmt.Println("Significance: *** p < 0.001, ** p < 0.01, * p < 0.05")
+
+	var transformed []*causal.Node
+	for _, n := range results.Nodes {
+		if len(n.Transforms) > 0 {
+			transformed = append(transformed, n)
+		}
+	}
+	if len(transformed) > 0 {
+		fmt.Println("\nCoefficients are in the units of the transformed variables:")
+		for _, n := range transformed {
+			fmt.Printf("  %s = %s\n", n.Label, render.DescribeTransforms(n))
+		}
+	}
+
 	return nil
 }
+
+// at tolerates responses from workers that predate the inference fields.
+func at(xs []float32, i int) float32 {
+	if i < len(xs) {
+		return xs[i]
+	}
+	return float32(math.NaN())
+}
+
+func stars(p float32, ok bool) string {
+	switch {
+	case !ok:
+		return ""
+	case p < 0.001:
+		return "***"
+	case p < 0.01:
+		return "**"
+	case p < 0.05:
+		return "*"
+	default:
+		return ""
+	}
+}
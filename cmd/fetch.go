@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	discoverernoop "github.com/w-h-a/caus/internal/client/discoverer/noop"
+	estimatornoop "github.com/w-h-a/caus/internal/client/estimator/noop"
+	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+)
+
+func Fetch(c *cli.Context) error {
+	ctx := c.Context
+
+	// 1. Parse inputs
+	configPath := c.String("vars")
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	start, end, step, err := resolveWindow(c)
+	if err != nil {
+		return err
+	}
+
+	out := c.String("out")
+	toStdout := len(out) == 0 || out == "-"
+
+	format := dataset.FormatFromPath(out)
+	if c.IsSet("format") {
+		format, err = dataset.ParseFormat(c.String("format"))
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Starting Fetch on %d variables...", len(cfg.Variables))
+	log.Printf("Window: %s -> %s (Step: %s)", start.Format(time.RFC3339), end.Format(time.RFC3339), step)
+
+	// 2. Build clients
+	fetchers, err := initFetchers(cfg)
+	if err != nil {
+		return err
+	}
+
+	orchestratorOpts, err := initOrchestratorOptions(c, cfg)
+	if err != nil {
+		return err
+	}
+
+	noopDiscoverer := discoverernoop.NewDiscoverer()
+
+	noopEstimator := estimatornoop.NewEstimator()
+
+	// 3. Build services
+	o := orchestrator.New(fetchers, noopDiscoverer, noopEstimator, orchestratorOpts...)
+
+	// 4. Run Fetch
+	ds, err := o.Fetch(
+		ctx,
+		cfg.Variables,
+		start,
+		end,
+		step,
+	)
+	if err != nil {
+		return err
+	}
+
+	// 5. Write dataset
+	if toStdout {
+		return dataset.Write(os.Stdout, ds, format)
+	}
+
+	if err := writeFile(out, func(w io.Writer) error { return dataset.Write(w, ds, format) }); err != nil {
+		return err
+	}
+
+	// parquet carries its metadata in the footer
+	if format != dataset.FormatParquet {
+		if err := writeFile(out+".meta.json", func(w io.Writer) error { return dataset.WriteMetadata(w, ds) }); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Wrote %d rows of %d variables to %s (%s)", ds.Len(), len(ds.Columns), out, format)
+
+	return nil
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
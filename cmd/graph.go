@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/render"
+)
+
+// RenderGraph draws a graph saved with `discover --json` as DOT, Mermaid or
+// GraphML, with the coefficients of an estimate of it when --estimate is set.
+func RenderGraph(c *cli.Context) error {
+	// 1. Parse inputs
+	out := c.String("out")
+
+	format := render.FormatFromPath(out)
+	if c.IsSet("format") {
+		var err error
+		if format, err = render.ParseFormat(c.String("format")); err != nil {
+			return err
+		}
+	}
+
+	graph, err := loadGraph(c)
+	if err != nil {
+		return err
+	}
+
+	var estimate *causal.EstimateResponse
+	if len(c.String("estimate")) > 0 {
+		if estimate, err = loadEstimate(c); err != nil {
+			return err
+		}
+	}
+
+	// 2. Render
+	write := func(w io.Writer) error { return render.Write(w, graph, estimate, format) }
+
+	if out == "-" {
+		return write(os.Stdout)
+	}
+
+	if err := writeFile(out, write); err != nil {
+		return err
+	}
+
+	log.Printf("Wrote %d nodes and %d edges to %s (%s)", len(graph.Nodes), len(graph.Edges), out, format)
+
+	return nil
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/service/jobs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// JobDiscover submits a discovery job to a `caus serve` and prints its ID,
+// or with --wait, its log and then its graph.
+func JobDiscover(c *cli.Context) error {
+	vars, err := os.ReadFile(c.String("vars"))
+	if err != nil {
+		return fmt.Errorf("failed to read vars: %w", err)
+	}
+
+	req := &caus.DiscoverRequest{
+		Run:         jobRun(c, string(vars)),
+		MaxLag:      int32(c.Int("lag")),
+		PcAlpha:     float32(c.Float64("alpha")),
+		MinStrength: float32(c.Float64("min-strength")),
+	}
+
+	return submitJob(c, func(ctx context.Context, client caus.CausClient) (*caus.Job, error) {
+		return client.SubmitDiscover(ctx, req)
+	})
+}
+
+// JobEstimate submits an estimation job to a `caus serve` and prints its ID,
+// or with --wait, its log and then its coefficients.
+func JobEstimate(c *cli.Context) error {
+	vars, err := os.ReadFile(c.String("vars"))
+	if err != nil {
+		return fmt.Errorf("failed to read vars: %w", err)
+	}
+
+	graph, err := loadGraph(c)
+	if err != nil {
+		return err
+	}
+
+	req := &caus.EstimateRequest{
+		Run:             jobRun(c, string(vars)),
+		Graph:           graph,
+		AllowUnoriented: c.Bool("allow-unoriented"),
+		ConfidenceLevel: float32(c.Float64("confidence")),
+	}
+
+	if c.Int("bootstrap") > 0 {
+		req.Bootstrap = &causal.Bootstrap{
+			Samples:   int32(c.Int("bootstrap")),
+			BlockSize: int32(c.Int("block-size")),
+			Seed:      c.Uint64("seed"),
+		}
+	}
+
+	return submitJob(c, func(ctx context.Context, client caus.CausClient) (*caus.Job, error) {
+		return client.SubmitEstimate(ctx, req)
+	})
+}
+
+// jobRun passes the window flags on as they are, so that relative times are
+// resolved on the server's clock when the job is submitted.
+func jobRun(c *cli.Context, vars string) *caus.Run {
+	return &caus.Run{
+		Vars:           vars,
+		Start:          c.String("start"),
+		End:            c.String("end"),
+		Step:           c.Duration("step").String(),
+		MakeStationary: c.Bool("make-stationary"),
+		Exclude:        c.StringSlice("exclude"),
+	}
+}
+
+func submitJob(c *cli.Context, submit func(ctx context.Context, client caus.CausClient) (*caus.Job, error)) error {
+	ctx, client, closeConn, err := dialServer(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	job, err := submit(ctx, client)
+	if err != nil {
+		return fmt.Errorf("failed to submit job: %w", err)
+	}
+
+	if !c.Bool("wait") {
+		fmt.Println(job.Id)
+		return nil
+	}
+
+	log.Printf("Submitted job %s", job.Id)
+
+	if err := followLogs(ctx, client, job.Id, true); err != nil {
+		return err
+	}
+
+	return printJobResult(ctx, c, client, job.Id)
+}
+
+func JobStatus(c *cli.Context) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, client, closeConn, err := dialServer(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	job, err := client.GetJob(ctx, &caus.GetJobRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	fmt.Printf("ID:       %s\n", job.Id)
+	fmt.Printf("Kind:     %s\n", job.Kind)
+	fmt.Printf("State:    %s\n", job.State)
+	if p := job.Progress; p != nil {
+		fmt.Printf("Progress: %s %d/%d\n", p.Stage, p.Done, p.Total)
+	}
+	fmt.Printf("Created:  %s\n", jobTime(job.CreatedAt))
+	fmt.Printf("Started:  %s\n", jobTime(job.StartedAt))
+	fmt.Printf("Finished: %s\n", jobTime(job.FinishedAt))
+	if len(job.Error) > 0 {
+		fmt.Printf("Error:    %s\n", job.Error)
+	}
+
+	return nil
+}
+
+func JobList(c *cli.Context) error {
+	ctx, client, closeConn, err := dialServer(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	rsp, err := client.ListJobs(ctx, &caus.ListJobsRequest{State: c.String("state"), Limit: int32(c.Int("limit"))})
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	fmt.Printf("%-16s  %-8s  %-9s  %-20s  %-20s  %s\n", "id", "kind", "state", "progress", "created", "error")
+	for _, job := range rsp.Jobs {
+		progress := "-"
+		if p := job.Progress; p != nil {
+			progress = fmt.Sprintf("%s %d/%d", p.Stage, p.Done, p.Total)
+		}
+		fmt.Printf("%-16s  %-8s  %-9s  %-20s  %-20s  %s\n", job.Id, job.Kind, job.State, progress, jobTime(job.CreatedAt), job.Error)
+	}
+
+	return nil
+}
+
+func JobLogs(c *cli.Context) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, client, closeConn, err := dialServer(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	return followLogs(ctx, client, id, c.Bool("follow"))
+}
+
+func JobCancel(c *cli.Context) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, client, closeConn, err := dialServer(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	if _, err := client.CancelJob(ctx, &caus.CancelJobRequest{Id: id}); err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	log.Printf("Cancelling job %s", id)
+
+	return nil
+}
+
+func JobResult(c *cli.Context) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, client, closeConn, err := dialServer(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	return printJobResult(ctx, c, client, id)
+}
+
+// followLogs prints a job's log to stderr, and with follow, keeps printing
+// it until the job finishes.
+func followLogs(ctx context.Context, client caus.CausClient, id string, follow bool) error {
+	stream, err := client.JobLogs(ctx, &caus.JobLogsRequest{Id: id, Follow: follow})
+	if err != nil {
+		return fmt.Errorf("failed to read job log: %w", err)
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read job log: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "%s %s\n", time.UnixMilli(entry.Time).UTC().Format(time.RFC3339), entry.Message)
+	}
+}
+
+func printJobResult(ctx context.Context, c *cli.Context, client caus.CausClient, id string) error {
+	job, err := client.GetJob(ctx, &caus.GetJobRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	switch job.State {
+	case jobs.StateSucceeded:
+	case jobs.StateFailed, jobs.StateCancelled:
+		return fmt.Errorf("job %s %s: %s", job.Id, job.State, job.Error)
+	default:
+		return fmt.Errorf("job %s is still %s", job.Id, job.State)
+	}
+
+	switch result := job.Result.(type) {
+	case *caus.Job_Graph:
+		format, err := discoveryFormat(c)
+		if err != nil {
+			return err
+		}
+		step, _ := time.ParseDuration(result.Graph.GetWindow().GetStep())
+		return printDiscovery(result.Graph, step, format)
+	case *caus.Job_Estimate:
+		if c.Bool("json") {
+			printJSON(result.Estimate)
+			return nil
+		}
+		return printEstimationResults(result.Estimate)
+	default:
+		return fmt.Errorf("job %s has no result", job.Id)
+	}
+}
+
+func jobID(c *cli.Context) (string, error) {
+	if c.NArg() != 1 {
+		return "", errors.New("expected exactly one job ID")
+	}
+	return c.Args().First(), nil
+}
+
+func jobTime(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+// dialServer connects to the `caus serve` at --server and returns a context
+// that carries --token.
+func dialServer(c *cli.Context) (context.Context, caus.CausClient, func(), error) {
+	creds := insecure.NewCredentials()
+
+	if c.Bool("server-tls") || len(c.String("server-ca")) > 0 {
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if path := c.String("server-ca"); len(path) > 0 {
+			pem, err := os.ReadFile(path)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to read server CA: %w", err)
+			}
+			cfg.RootCAs = x509.NewCertPool()
+			if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, nil, nil, fmt.Errorf("server CA '%s' has no PEM certificates", path)
+			}
+		}
+
+		creds = credentials.NewTLS(cfg)
+	}
+
+	conn, err := grpc.NewClient(c.String("server"), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create client for '%s': %w", c.String("server"), err)
+	}
+
+	ctx := c.Context
+	if token := c.String("token"); len(token) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+strings.TrimSpace(token))
+	}
+
+	return ctx, caus.NewCausClient(conn), func() { conn.Close() }, nil
+}
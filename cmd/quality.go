@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+)
+
+// qualityReporter prints the data quality report of each fetch to stderr, so
+// that it never mixes with results written to stdout.
+func qualityReporter(c *cli.Context) (func(*orchestrator.QualityReport), error) {
+	switch format := c.String("quality"); format {
+	case "table":
+		return func(r *orchestrator.QualityReport) { printQualityTable(os.Stderr, r) }, nil
+	case "json":
+		return func(r *orchestrator.QualityReport) { printQualityJSON(os.Stderr, r) }, nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown quality report format '%s' (supported: table, json, none)", format)
+	}
+}
+
+func printQualityTable(w io.Writer, r *orchestrator.QualityReport) {
+	fmt.Fprintf(w, "\n--- Data Quality (%d steps) ---\n", r.Steps)
+	fmt.Fprintf(w, "  %-24s %-16s %10s %8s %8s %8s %9s %8s %8s %8s %8s  %s\n", "variable", "fill", "resolution", "points", "unused", "dupes", "upsampled", "observed", "filled", "missing", "outliers", "flags")
+	for _, q := range r.Variables {
+		var flags []string
+		if q.Constant {
+			flags = append(flags, "constant")
+		}
+		if q.Observed == 0 {
+			flags = append(flags, "empty")
+		}
+		fmt.Fprintf(w, "  %-24s %-16s %10s %8d %8d %8d %9d %8d %8d %8d %8d  %s\n", q.Variable, q.Fill, q.Resolution, q.Points, q.Unused, q.Duplicates, q.Upsampled, q.Observed, q.Filled, q.Missing, q.Outliers, strings.Join(flags, ","))
+	}
+	fmt.Fprintf(w, "\n  %-24s %10s %10s %10s %10s  %-13s %s\n", "variable", "adf", "adf crit", "kpss", "kpss crit", "verdict", "remedy")
+	for _, q := range r.Variables {
+		st := q.Stationarity
+		if st == nil {
+			continue
+		}
+		remedy := st.Remedy
+		if st.Applied {
+			remedy += " (applied)"
+		}
+		if st.Verdict == orchestrator.VerdictInconclusive && st.ADF == 0 && st.KPSS == 0 {
+			fmt.Fprintf(w, "  %-24s %10s %10s %10s %10s  %-13s %s\n", q.Variable, "-", "-", "-", "-", st.Verdict, remedy)
+			continue
+		}
+		fmt.Fprintf(w, "  %-24s %10.3f %10.3f %10.3f %10.3f  %-13s %s\n", q.Variable, st.ADF, st.ADFCritical, st.KPSS, st.KPSSCritical, st.Verdict, remedy)
+	}
+	for _, e := range r.Excluded {
+		reason := ""
+		if len(e.Reason) > 0 {
+			reason = fmt.Sprintf(" (%s)", e.Reason)
+		}
+		fmt.Fprintf(w, "  excluded %d steps: %s -> %s%s\n", e.Steps, e.Start.Format(time.RFC3339), e.End.Format(time.RFC3339), reason)
+	}
+	for _, v := range r.Violations {
+		fmt.Fprintf(w, "  FAIL %s\n", v)
+	}
+	fmt.Fprintln(w, "-------------------------------")
+}
+
+func printQualityJSON(w io.Writer, r *orchestrator.QualityReport) {
+	bs, _ := json.MarshalIndent(r, "", "  ")
+	fmt.Fprintln(w, string(bs))
+}
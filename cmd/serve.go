@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/jobstore"
+	"github.com/w-h-a/caus/internal/client/jobstore/bolt"
+	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/server"
+	"github.com/w-h-a/caus/internal/service/jobs"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const shutdownTimeout = 30 * time.Second
+
+func Serve(c *cli.Context) error {
+	// 1. Parse inputs
+	sources, err := config.LoadSources(c.String("sources"))
+	if err != nil {
+		return err
+	}
+
+	concurrencyOpts, err := initConcurrencyOptions(c)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := server.TLSConfig(c.String("tls-cert"), c.String("tls-key"))
+	if err != nil {
+		return err
+	}
+
+	token := c.String("token")
+	for _, addr := range []string{c.String("grpc-addr"), c.String("http-addr")} {
+		if len(addr) == 0 {
+			continue
+		}
+		if err := server.CheckExposure(addr, token); err != nil {
+			return err
+		}
+		if !server.Loopback(addr) && tlsConfig == nil {
+			log.Printf("WARNING: serving on %s without --tls-cert, so the token travels in cleartext", addr)
+		}
+	}
+
+	// 2. Build clients
+	discovererImpl, err := initDiscoverer(c)
+	if err != nil {
+		return err
+	}
+
+	estimatorImpl, err := initEstimator(c)
+	if err != nil {
+		return err
+	}
+
+	// 3. Build services
+	factory := func(cfg *variable.DiscoveryConfig, opts ...orchestrator.Option) (*orchestrator.Service, error) {
+		fetchers, err := initFetchers(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		annotators, err := initAnnotators(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(slices.Clone(concurrencyOpts), opts...)
+		for key, a := range annotators {
+			opts = append(opts, orchestrator.WithAnnotator(key, a))
+		}
+
+		return orchestrator.New(fetchers, discovererImpl, estimatorImpl, opts...), nil
+	}
+
+	serverOpts := []server.Option{
+		server.WithSources(sources...),
+		server.WithToken(token),
+	}
+
+	var jobsSvc *jobs.Service
+	if path := c.String("jobs-db"); len(path) > 0 {
+		store, err := bolt.NewStore(jobstore.WithLocation(path))
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		if jobsSvc, err = jobs.New(store, jobs.WithMaxRunning(c.Int("max-jobs")), jobs.WithTimeout(c.Duration("job-timeout"))); err != nil {
+			return err
+		}
+		serverOpts = append(serverOpts, server.WithJobs(jobsSvc))
+	}
+
+	srv := server.New(factory, serverOpts...)
+
+	grpcOpts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(srv.UnaryInterceptor()),
+		grpc.StreamInterceptor(srv.StreamInterceptor()),
+	}
+	if tlsConfig != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(grpcOpts...)
+	caus.RegisterCausServer(grpcServer, srv)
+
+	lis, err := net.Listen("tcp", c.String("grpc-addr"))
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", c.String("grpc-addr"), err)
+	}
+
+	httpServer := &http.Server{
+		Addr:              c.String("http-addr"),
+		Handler:           srv.Handler(),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// 4. Serve until interrupted
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 2)
+
+	go func() {
+		errs <- grpcServer.Serve(lis)
+	}()
+	log.Printf("Serving gRPC on %s (%d sources, TLS: %t)", lis.Addr(), len(sources), tlsConfig != nil)
+
+	if len(httpServer.Addr) > 0 {
+		go func() {
+			var err error
+			if tlsConfig != nil {
+				// the certificate is already in TLSConfig
+				err = httpServer.ListenAndServeTLS("", "")
+			} else {
+				err = httpServer.ListenAndServe()
+			}
+			if !errors.Is(err, http.ErrServerClosed) {
+				errs <- err
+			}
+		}()
+		log.Printf("Serving JSON/HTTP on %s (TLS: %t)", httpServer.Addr, tlsConfig != nil)
+	}
+
+	select {
+	case <-ctx.Done():
+		log.Printf("Shutting down...")
+	case err = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// stop the jobs first, which also ends the calls following their logs
+	if jobsSvc != nil {
+		if shutdownErr := jobsSvc.Close(shutdownCtx); shutdownErr != nil {
+			log.Printf("Failed to stop jobs: %v", shutdownErr)
+		}
+	}
+
+	if shutdownErr := httpServer.Shutdown(shutdownCtx); shutdownErr != nil {
+		log.Printf("Failed to shut down JSON/HTTP: %v", shutdownErr)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
+
+	if err != nil {
+		return fmt.Errorf("server failed: %w", err)
+	}
+
+	return nil
+}
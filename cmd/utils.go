@@ -2,20 +2,48 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/urfave/cli/v2"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
 	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/annotator"
+	"github.com/w-h-a/caus/internal/client/annotator/file"
+	"github.com/w-h-a/caus/internal/client/annotator/grafana"
+	httpannotator "github.com/w-h-a/caus/internal/client/annotator/http"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+	nativediscoverer "github.com/w-h-a/caus/internal/client/discoverer/native"
+	discovererv1alpha1 "github.com/w-h-a/caus/internal/client/discoverer/v1alpha1"
+	discovererv1alpha2 "github.com/w-h-a/caus/internal/client/discoverer/v1alpha2"
+	"github.com/w-h-a/caus/internal/client/estimator"
+	nativeestimator "github.com/w-h-a/caus/internal/client/estimator/native"
+	estimatorv1alpha1 "github.com/w-h-a/caus/internal/client/estimator/v1alpha1"
+	estimatorv1alpha2 "github.com/w-h-a/caus/internal/client/estimator/v1alpha2"
 	"github.com/w-h-a/caus/internal/client/fetcher"
 	"github.com/w-h-a/caus/internal/client/fetcher/clickhouse"
 	"github.com/w-h-a/caus/internal/client/fetcher/csv"
 	"github.com/w-h-a/caus/internal/client/fetcher/datadog"
+	"github.com/w-h-a/caus/internal/client/fetcher/events"
+	"github.com/w-h-a/caus/internal/client/fetcher/honeycomb"
 	"github.com/w-h-a/caus/internal/client/fetcher/prometheus"
 	"github.com/w-h-a/caus/internal/client/fetcher/random"
+	"github.com/w-h-a/caus/internal/client/worker"
+	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
 )
 
+// initFetchers builds a fetcher per source of the variables, keyed by type
+// and then orchestrator.SourceKey.
 func initFetchers(cfg *variable.DiscoveryConfig) (map[string]map[string]fetcher.Fetcher, error) {
 	fetchers := map[string]map[string]fetcher.Fetcher{
 		"metrics": {},
 		"traces":  {},
+		"events":  {},
 	}
 
 	factories := map[string]map[string]func(loc, apiKey, appKey string) fetcher.Fetcher{
@@ -35,13 +63,29 @@ func initFetchers(cfg *variable.DiscoveryConfig) (map[string]map[string]fetcher.
 				return csv.NewFetcher(fetcher.WithLocation(loc))
 			},
 			"clickhouse": func(loc, _, _ string) fetcher.Fetcher { return clickhouse.NewFetcher(fetcher.WithLocation(loc)) },
+			"honeycomb": func(loc, apiKey, _ string) fetcher.Fetcher {
+				return honeycomb.NewFetcher(fetcher.WithLocation(loc), fetcher.WithApiKey(apiKey))
+			},
 			"datadog": func(loc, apiKey, appKey string) fetcher.Fetcher {
 				return datadog.NewFetcher(fetcher.WithLocation(loc), fetcher.WithApiKey(apiKey), fetcher.WithAppKey(appKey))
 			},
 		},
+		"events": {
+			"file": func(loc, _, _ string) fetcher.Fetcher {
+				return events.NewFetcher(fetcher.WithLocation(loc), events.WithAnnotator(file.NewAnnotator(annotator.WithLocation(loc))))
+			},
+			"http": func(loc, apiKey, _ string) fetcher.Fetcher {
+				return events.NewFetcher(fetcher.WithLocation(loc), events.WithAnnotator(httpannotator.NewAnnotator(annotator.WithLocation(loc), annotator.WithApiKey(apiKey))))
+			},
+			"prometheus": func(loc, _, _ string) fetcher.Fetcher { return prometheus.NewFetcher(fetcher.WithLocation(loc)) },
+		},
 	}
 
 	for _, v := range cfg.Variables {
+		if v.Derived() {
+			continue
+		}
+
 		impls, typeOk := factories[v.Source.Type]
 		if !typeOk {
 			return nil, fmt.Errorf("unsupported source type: %s", v.Source.Type)
@@ -52,12 +96,335 @@ func initFetchers(cfg *variable.DiscoveryConfig) (map[string]map[string]fetcher.
 			return nil, fmt.Errorf("unsupported implementation '%s' for type '%s'", v.Source.Impl, v.Source.Type)
 		}
 
-		if _, exists := fetchers[v.Source.Type][v.Source.Impl]; exists {
+		// one fetcher per location, so that e.g. two event files or two
+		// endpoints with their own keys never share a client
+		key := orchestrator.SourceKey(v.Source.Impl, v.Source.Loc)
+		if _, exists := fetchers[v.Source.Type][key]; exists {
 			continue
 		}
 
-		fetchers[v.Source.Type][v.Source.Impl] = factory(v.Source.Loc, v.Source.ApiKey, v.Source.AppKey)
+		fetchers[v.Source.Type][key] = factory(v.Source.Loc, v.Source.ApiKey, v.Source.AppKey)
 	}
 
 	return fetchers, nil
 }
+
+// initAnnotators builds an annotator per exclusion source, keyed by
+// orchestrator.SourceKey.
+func initAnnotators(cfg *variable.DiscoveryConfig) (map[string]annotator.Annotator, error) {
+	annotators := map[string]annotator.Annotator{}
+
+	factories := map[string]func(loc, apiKey string) annotator.Annotator{
+		"file": func(loc, _ string) annotator.Annotator {
+			return file.NewAnnotator(annotator.WithLocation(loc))
+		},
+		"http": func(loc, apiKey string) annotator.Annotator {
+			return httpannotator.NewAnnotator(annotator.WithLocation(loc), annotator.WithApiKey(apiKey))
+		},
+		"grafana": func(loc, apiKey string) annotator.Annotator {
+			return grafana.NewAnnotator(annotator.WithLocation(loc), annotator.WithApiKey(apiKey))
+		},
+	}
+
+	for _, e := range cfg.Exclusions {
+		if e.Source == nil {
+			continue
+		}
+
+		factory, ok := factories[e.Source.Impl]
+		if !ok {
+			return nil, fmt.Errorf("unsupported implementation '%s' for type 'annotations'", e.Source.Impl)
+		}
+
+		key := orchestrator.SourceKey(e.Source.Impl, e.Source.Loc)
+		if _, exists := annotators[key]; exists {
+			continue
+		}
+
+		annotators[key] = factory(e.Source.Loc, e.Source.ApiKey)
+	}
+
+	return annotators, nil
+}
+
+// resolveWindow turns --start/--end, or --around/--radius, into an absolute
+// window. The returned times are not yet aligned to the step.
+func resolveWindow(c *cli.Context) (time.Time, time.Time, time.Duration, error) {
+	step := c.Duration("step")
+	if step <= 0 {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("step must be positive")
+	}
+
+	now := time.Now().UTC().Truncate(step)
+
+	var start, end time.Time
+
+	if c.IsSet("around") {
+		if c.IsSet("start") || c.IsSet("end") {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("--around cannot be combined with --start or --end")
+		}
+
+		around, err := config.ParseTime(c.String("around"), now)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("invalid --around: %w", err)
+		}
+
+		radius := c.Duration("radius")
+		if radius <= 0 {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("radius must be positive")
+		}
+
+		start = around.Add(-radius)
+		end = around.Add(radius)
+
+		if end.After(now) {
+			log.Printf("Window end %s is in the future, using %s", end.Format(time.RFC3339), now.Format(time.RFC3339))
+			end = now
+		}
+	} else {
+		var err error
+
+		start, err = config.ParseTime(c.String("start"), now)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("invalid --start: %w", err)
+		}
+
+		end, err = config.ParseTime(c.String("end"), now)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("invalid --end: %w", err)
+		}
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("window start %s is not before its end %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	return start, end, step, nil
+}
+
+// loadDataset reads --dataset in place of fetching, keeping only the --vars
+// columns when a config is given.
+func loadDataset(c *cli.Context) (*dataset.Dataset, error) {
+	for _, flag := range []string{"start", "end", "around", "radius", "step"} {
+		if c.IsSet(flag) {
+			return nil, fmt.Errorf("--%s cannot be combined with --dataset, which already fixes the window", flag)
+		}
+	}
+
+	if c.IsSet("exclude") {
+		return nil, fmt.Errorf("--exclude cannot be combined with --dataset, exclude the window when fetching instead")
+	}
+
+	path := c.String("dataset")
+
+	ds, err := dataset.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.IsSet("vars") {
+		cfg, err := config.LoadConfig(c.String("vars"))
+		if err != nil {
+			return nil, fmt.Errorf("loading config: %w", err)
+		}
+
+		var names []string
+		for _, v := range cfg.Variables {
+			names = append(names, columnNames(ds, v)...)
+		}
+
+		ds, err = ds.Select(names)
+		if err != nil {
+			return nil, fmt.Errorf("dataset %s does not match %s: %w", path, c.String("vars"), err)
+		}
+	}
+
+	log.Printf("Loaded %d rows of %d variables from %s", ds.Len(), len(ds.Columns), path)
+	log.Printf("Window: %s -> %s (Step: %s)", ds.Timestamps[0].Format(time.RFC3339), ds.Timestamps[len(ds.Timestamps)-1].Format(time.RFC3339), ds.Step)
+
+	return ds, nil
+}
+
+// columnNames lists the columns v was exported as: its own name, or for a
+// split variable every column whose provenance shows it is one of v's
+// series.
+func columnNames(ds *dataset.Dataset, v variable.VariableDefinition) []string {
+	if !v.Split() {
+		return []string{v.Name}
+	}
+
+	names := ds.SeriesOf(v.Name)
+	if len(names) == 0 {
+		// let Select report it as missing
+		return []string{v.Name}
+	}
+
+	return names
+}
+
+// loadConfig reads --vars, which is required whenever data is fetched.
+func loadConfig(c *cli.Context) (*variable.DiscoveryConfig, error) {
+	if !c.IsSet("vars") {
+		return nil, fmt.Errorf("--vars is required unless --dataset is given")
+	}
+
+	cfg, err := config.LoadConfig(c.String("vars"))
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func printWindow(w *causal.Window) {
+	if w == nil {
+		return
+	}
+	fmt.Printf("Window: %s -> %s (step: %s)\n", w.Start, w.End, w.Step)
+	fmt.Printf("Rerun with: --start=%s --end=%s --step=%s\n", w.Start, w.End, w.Step)
+}
+
+func initOrchestratorOptions(c *cli.Context, cfg *variable.DiscoveryConfig) ([]orchestrator.Option, error) {
+	opts := []orchestrator.Option{
+		orchestrator.WithMaxMissingRatio(cfg.MaxMissingRatio),
+		orchestrator.WithMakeStationary(c.Bool("make-stationary")),
+	}
+
+	if cfg.Quality != nil {
+		opts = append(opts, orchestrator.WithQualityRules(*cfg.Quality))
+	}
+
+	reporter, err := qualityReporter(c)
+	if err != nil {
+		return nil, err
+	}
+	if reporter != nil {
+		opts = append(opts, orchestrator.WithQualityReport(reporter))
+	}
+
+	exclusions, err := config.ParseExclusions(c.StringSlice("exclude"), "--exclude")
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, orchestrator.WithExclusions(append(slices.Clone(cfg.Exclusions), exclusions...)...))
+
+	annotators, err := initAnnotators(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for key, a := range annotators {
+		opts = append(opts, orchestrator.WithAnnotator(key, a))
+	}
+
+	concurrency, err := initConcurrencyOptions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(opts, concurrency...), nil
+}
+
+// initConcurrencyOptions bounds the fetches in flight with --concurrency and
+// --source-concurrency.
+func initConcurrencyOptions(c *cli.Context) ([]orchestrator.Option, error) {
+	opts := []orchestrator.Option{
+		orchestrator.WithConcurrency(c.Int("concurrency")),
+	}
+
+	for _, spec := range c.StringSlice("source-concurrency") {
+		impl, limit, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid source concurrency '%s' (expected impl=n)", spec)
+		}
+
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source concurrency '%s': %w", spec, err)
+		}
+
+		opts = append(opts, orchestrator.WithImplConcurrency(impl, n))
+	}
+
+	return opts, nil
+}
+
+// workerOptions are the connection settings of the python gRPC worker, from
+// the worker-* flags.
+type workerOptions struct {
+	location string
+	timeout  time.Duration
+	tls      worker.TLS
+	token    string
+	retry    worker.Retry
+}
+
+func initWorkerOptions(c *cli.Context) workerOptions {
+	return workerOptions{
+		location: c.String("worker-addr"),
+		timeout:  c.Duration("worker-timeout"),
+		tls: worker.TLS{
+			Enabled:  c.Bool("worker-tls"),
+			CAFile:   c.String("worker-ca"),
+			CertFile: c.String("worker-cert"),
+			KeyFile:  c.String("worker-key"),
+		},
+		token: c.String("worker-token"),
+		retry: worker.Retry{
+			Retries:        c.Int("worker-retries"),
+			InitialBackoff: c.Duration("worker-backoff"),
+			MaxBackoff:     c.Duration("worker-max-backoff"),
+		},
+	}
+}
+
+func initDiscoverer(c *cli.Context) (discoverer.Discoverer, error) {
+	switch impl := c.String("discoverer"); impl {
+	case "worker":
+		w := initWorkerOptions(c)
+		opts := []discoverer.Option{
+			discoverer.WithLocation(w.location),
+			discoverer.WithTimeout(w.timeout),
+			discoverer.WithTLS(w.tls),
+			discoverer.WithToken(w.token),
+			discoverer.WithRetry(w.retry),
+		}
+		switch workerAPI := c.String("worker-api"); workerAPI {
+		case "v1alpha2":
+			return discovererv1alpha2.NewDiscoverer(opts...)
+		case "v1alpha1":
+			return discovererv1alpha1.NewDiscoverer(opts...)
+		default:
+			return nil, fmt.Errorf("unsupported worker api '%s' (supported: v1alpha2, v1alpha1)", workerAPI)
+		}
+	case "native":
+		return nativediscoverer.NewDiscoverer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported discoverer '%s' (supported: worker, native)", impl)
+	}
+}
+
+func initEstimator(c *cli.Context) (estimator.Estimator, error) {
+	switch impl := c.String("estimator"); impl {
+	case "worker":
+		w := initWorkerOptions(c)
+		opts := []estimator.Option{
+			estimator.WithLocation(w.location),
+			estimator.WithTimeout(w.timeout),
+			estimator.WithTLS(w.tls),
+			estimator.WithToken(w.token),
+			estimator.WithRetry(w.retry),
+		}
+		switch workerAPI := c.String("worker-api"); workerAPI {
+		case "v1alpha2":
+			return estimatorv1alpha2.NewEstimator(opts...)
+		case "v1alpha1":
+			return estimatorv1alpha1.NewEstimator(opts...)
+		default:
+			return nil, fmt.Errorf("unsupported worker api '%s' (supported: v1alpha2, v1alpha1)", workerAPI)
+		}
+	case "native":
+		return nativeestimator.NewEstimator(), nil
+	default:
+		return nil, fmt.Errorf("unsupported estimator '%s' (supported: worker, native)", impl)
+	}
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/discoverer/noop"
+	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/render"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+)
+
+func WhatIf(c *cli.Context) error {
+	ctx := c.Context
+
+	// 1. Parse inputs
+	configPath := c.String("vars")
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	graph, err := loadGraph(c)
+	if err != nil {
+		return err
+	}
+
+	start, end, step, err := resolveWindow(c)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Truncate(step)
+
+	var doStart, doEnd time.Time
+	if c.IsSet("do-start") {
+		doStart, err = config.ParseTime(c.String("do-start"), now)
+		if err != nil {
+			return fmt.Errorf("invalid --do-start: %w", err)
+		}
+	}
+	if c.IsSet("do-end") {
+		doEnd, err = config.ParseTime(c.String("do-end"), now)
+		if err != nil {
+			return fmt.Errorf("invalid --do-end: %w", err)
+		}
+	}
+
+	var interventions []orchestrator.Intervention
+	for _, spec := range c.StringSlice("do") {
+		i, err := parseIntervention(spec)
+		if err != nil {
+			return err
+		}
+		i.Start = doStart
+		i.End = doEnd
+		interventions = append(interventions, i)
+	}
+	if len(interventions) == 0 {
+		return fmt.Errorf("at least one --do intervention is required")
+	}
+
+	args := orchestrator.WhatIfArgs{
+		Graph:            graph,
+		Interventions:    interventions,
+		Outcomes:         c.StringSlice("outcome"),
+		AllowUnoriented:  c.Bool("allow-unoriented"),
+		AllowTransformed: c.Bool("allow-transformed"),
+	}
+
+	log.Printf("Starting What-If on %d variables...", len(cfg.Variables))
+	log.Printf("Window: %s -> %s (Step: %s)", start.Format(time.RFC3339), end.Format(time.RFC3339), step)
+
+	// 2. Build clients
+	fetchers, err := initFetchers(cfg)
+	if err != nil {
+		return err
+	}
+
+	orchestratorOpts, err := initOrchestratorOptions(c, cfg)
+	if err != nil {
+		return err
+	}
+
+	noopDiscoverer := noop.NewDiscoverer()
+
+	estimatorImpl, err := initEstimator(c)
+	if err != nil {
+		return err
+	}
+
+	// 3. Build services
+	o := orchestrator.New(fetchers, noopDiscoverer, estimatorImpl, orchestratorOpts...)
+
+	// 4. Run What-If
+	result, err := o.WhatIf(
+		ctx,
+		cfg.Variables,
+		start,
+		end,
+		step,
+		args,
+	)
+	if err != nil {
+		return err
+	}
+
+	// 5. Display results
+	if c.Bool("json") {
+		return printWhatIfJSON(result, interventions)
+	}
+
+	printWhatIfResults(result, interventions, c.Bool("series"))
+
+	return nil
+}
+
+// parseIntervention parses "name=*k" (scale), "name=+k" (shift),
+// "name=set:k" or "name=k" (set).
+func parseIntervention(spec string) (orchestrator.Intervention, error) {
+	name, expr, ok := strings.Cut(spec, "=")
+	if !ok || len(name) == 0 || len(expr) == 0 {
+		return orchestrator.Intervention{}, fmt.Errorf("invalid intervention '%s' (expected name=*k, name=+k or name=set:k)", spec)
+	}
+
+	i := orchestrator.Intervention{Variable: strings.TrimSpace(name), Op: orchestrator.InterventionSet}
+
+	expr = strings.TrimSpace(expr)
+	switch {
+	case strings.HasPrefix(expr, "*"):
+		i.Op = orchestrator.InterventionScale
+		expr = expr[1:]
+	case strings.HasPrefix(expr, "+"):
+		i.Op = orchestrator.InterventionShift
+		expr = expr[1:]
+	case strings.HasPrefix(expr, "set:"):
+		expr = expr[len("set:"):]
+	}
+
+	val, err := strconv.ParseFloat(expr, 64)
+	if err != nil {
+		return orchestrator.Intervention{}, fmt.Errorf("invalid intervention '%s': %w", spec, err)
+	}
+	i.Value = val
+
+	return i, nil
+}
+
+func describeIntervention(i orchestrator.Intervention) string {
+	var desc string
+	switch i.Op {
+	case orchestrator.InterventionScale:
+		desc = fmt.Sprintf("do(%s := %s * %g)", i.Variable, i.Variable, i.Value)
+	case orchestrator.InterventionShift:
+		desc = fmt.Sprintf("do(%s := %s + %g)", i.Variable, i.Variable, i.Value)
+	default:
+		desc = fmt.Sprintf("do(%s := %g)", i.Variable, i.Value)
+	}
+
+	from, to := "start", "end"
+	if !i.Start.IsZero() {
+		from = i.Start.Format(time.RFC3339)
+	}
+	if !i.End.IsZero() {
+		to = i.End.Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("%s from %s to %s", desc, from, to)
+}
+
+func printWhatIfResults(result *orchestrator.WhatIfResult, interventions []orchestrator.Intervention, series bool) {
+	fmt.Printf("\n--- What If (Counterfactual Simulation) ---\n")
+
+	fmt.Println("Interventions:")
+	for _, i := range interventions {
+		fmt.Printf("  - %s\n", describeIntervention(i))
+	}
+	fmt.Println("")
+
+	for _, o := range result.Outcomes {
+		fmt.Printf("Outcome: %s\n", o.Variable)
+		if len(o.Transforms) > 0 {
+			fmt.Printf("  Units:               %s\n", units(o))
+		}
+		fmt.Printf("  Observed mean:       %.4f\n", o.ObservedMean)
+		fmt.Printf("  Counterfactual mean: %.4f\n", o.CounterfactualMean)
+		fmt.Printf("  Delta:               %+.4f (%+.2f%%)\n", o.Delta, o.DeltaPct)
+
+		if series {
+			fmt.Printf("  %-20s  %14s  %14s\n", "time", "observed", "counterfactual")
+			for t, ts := range result.Timestamps {
+				fmt.Printf("  %-20s  %14.4f  %14.4f\n", ts.Format(time.RFC3339), o.Observed[t], o.Counterfactual[t])
+			}
+		}
+
+		fmt.Println("")
+	}
+}
+
+func printWhatIfJSON(result *orchestrator.WhatIfResult, interventions []orchestrator.Intervention) error {
+	type outcome struct {
+		Variable           string     `json:"variable"`
+		Units              string     `json:"units,omitempty"`
+		ObservedMean       float64    `json:"observed_mean"`
+		CounterfactualMean float64    `json:"counterfactual_mean"`
+		Delta              float64    `json:"delta"`
+		DeltaPct           float64    `json:"delta_pct"`
+		Observed           []*float64 `json:"observed"`
+		Counterfactual     []*float64 `json:"counterfactual"`
+	}
+
+	out := struct {
+		Interventions []string  `json:"interventions"`
+		Timestamps    []string  `json:"timestamps"`
+		Outcomes      []outcome `json:"outcomes"`
+	}{}
+
+	for _, i := range interventions {
+		out.Interventions = append(out.Interventions, describeIntervention(i))
+	}
+	for _, ts := range result.Timestamps {
+		out.Timestamps = append(out.Timestamps, ts.Format(time.RFC3339))
+	}
+	for _, o := range result.Outcomes {
+		out.Outcomes = append(out.Outcomes, outcome{
+			Variable:           o.Variable,
+			Units:              units(o),
+			ObservedMean:       o.ObservedMean,
+			CounterfactualMean: o.CounterfactualMean,
+			Delta:              o.Delta,
+			DeltaPct:           o.DeltaPct,
+			Observed:           nullable(o.Observed),
+			Counterfactual:     nullable(o.Counterfactual),
+		})
+	}
+
+	bs, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bs))
+
+	return nil
+}
+
+// units spells out the transforms an outcome's values are in, e.g.
+// "log1p(rate(requests))", or "" if it wasn't transformed.
+func units(o orchestrator.OutcomeResult) string {
+	if len(o.Transforms) == 0 {
+		return ""
+	}
+	return render.DescribeTransforms(&causal.Node{Label: o.Variable, Transforms: o.Transforms})
+}
+
+// nullable maps missing values to nil so that they encode as json null.
+func nullable(xs []float64) []*float64 {
+	out := make([]*float64, len(xs))
+	for i := range xs {
+		if !dataset.Missing(xs[i]) {
+			out[i] = &xs[i]
+		}
+	}
+	return out
+}
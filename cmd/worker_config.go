@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadWorkerConfig fills the worker-* flags from the YAML file at
+// --worker-config, e.g.
+//
+//	worker-addr: worker.internal:50051
+//	worker-ca: /etc/caus/ca.pem
+//
+// A flag passed on the command line or set through its environment variable
+// wins over the file.
+func LoadWorkerConfig(c *cli.Context) error {
+	path := c.String("worker-config")
+	if len(path) == 0 {
+		return nil
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read worker config: %w", err)
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal(bs, &values); err != nil {
+		return fmt.Errorf("failed to parse worker config: %w", err)
+	}
+
+	var supported []string
+	for _, f := range c.Command.Flags {
+		if name := f.Names()[0]; strings.HasPrefix(name, "worker-") && name != "worker-config" {
+			supported = append(supported, name)
+		}
+	}
+
+	for name, value := range values {
+		if !slices.Contains(supported, name) {
+			return fmt.Errorf("unsupported key '%s' in worker config (supported: %s)", name, strings.Join(supported, ", "))
+		}
+		if c.IsSet(name) {
+			continue
+		}
+		if err := c.Set(name, fmt.Sprint(value)); err != nil {
+			return fmt.Errorf("worker config key '%s' invalid: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package annotator
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Annotator lists the annotations, e.g. deploys or incidents, that a
+// source records in a window.
+type Annotator interface {
+	Annotations(ctx context.Context, tags []string, start time.Time, end time.Time) ([]Annotation, error)
+}
+
+// Annotation marks a point in time, or a region when End is after Start.
+type Annotation struct {
+	Start time.Time
+	End   time.Time
+	Text  string
+	Tags  []string
+}
+
+// HasTags reports whether have contains every tag of want.
+func HasTags(have []string, want []string) bool {
+	for _, tag := range want {
+		if !slices.Contains(have, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/w-h-a/caus/internal/client/annotator"
+	"gopkg.in/yaml.v3"
+)
+
+type fileAnnotator struct {
+	options annotator.Options
+}
+
+// annotation is one entry of the file. JSON is valid YAML, so the file may
+// be either.
+type annotation struct {
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end"`
+	Text  string    `yaml:"text"`
+	Tags  []string  `yaml:"tags"`
+}
+
+// Annotations reads the file on every call, so that edits apply to the
+// next run without a restart.
+func (a *fileAnnotator) Annotations(ctx context.Context, tags []string, start time.Time, end time.Time) ([]annotator.Annotation, error) {
+	bs, err := os.ReadFile(a.options.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotations file: %w", err)
+	}
+
+	var entries []annotation
+	if err := yaml.Unmarshal(bs, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse annotations file '%s': %w", a.options.Location, err)
+	}
+
+	var result []annotator.Annotation
+	for i, e := range entries {
+		if e.Start.IsZero() {
+			return nil, fmt.Errorf("annotation %d in '%s' has no start", i, a.options.Location)
+		}
+		if e.End.Before(e.Start) {
+			e.End = e.Start
+		}
+		if e.End.Before(start) || e.Start.After(end) {
+			continue
+		}
+		if !annotator.HasTags(e.Tags, tags) {
+			continue
+		}
+		result = append(result, annotator.Annotation{
+			Start: e.Start.UTC(),
+			End:   e.End.UTC(),
+			Text:  e.Text,
+			Tags:  e.Tags,
+		})
+	}
+
+	return result, nil
+}
+
+func NewAnnotator(opts ...annotator.Option) annotator.Annotator {
+	options := annotator.NewOptions(opts...)
+
+	return &fileAnnotator{
+		options: options,
+	}
+}
@@ -0,0 +1,91 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/w-h-a/caus/internal/client/annotator"
+)
+
+// limit is the most annotations one request returns.
+const limit = 1000
+
+type grafanaAnnotator struct {
+	options annotator.Options
+	client  *http.Client
+}
+
+type annotation struct {
+	Time    int64    `json:"time"`
+	TimeEnd int64    `json:"timeEnd"`
+	Text    string   `json:"text"`
+	Tags    []string `json:"tags"`
+}
+
+func (a *grafanaAnnotator) Annotations(ctx context.Context, tags []string, start time.Time, end time.Time) ([]annotator.Annotation, error) {
+	params := url.Values{}
+	params.Set("from", strconv.FormatInt(start.UnixMilli(), 10))
+	params.Set("to", strconv.FormatInt(end.UnixMilli(), 10))
+	params.Set("type", "annotation")
+	params.Set("limit", strconv.Itoa(limit))
+	for _, tag := range tags {
+		params.Add("tags", tag)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(a.options.Location, "/")+"/api/annotations?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.options.ApiKey) > 0 {
+		req.Header.Set("Authorization", "Bearer "+a.options.ApiKey)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("grafana annotations request failed: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	bs, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return nil, fmt.Errorf("grafana annotations request failed with status %d: %s", rsp.StatusCode, strings.TrimSpace(string(bs)))
+	}
+
+	var found []annotation
+	if err := json.Unmarshal(bs, &found); err != nil {
+		return nil, fmt.Errorf("failed to decode grafana annotations: %w", err)
+	}
+
+	result := make([]annotator.Annotation, len(found))
+	for i, an := range found {
+		result[i] = annotator.Annotation{
+			Start: time.UnixMilli(an.Time).UTC(),
+			End:   time.UnixMilli(max(an.TimeEnd, an.Time)).UTC(),
+			Text:  an.Text,
+			Tags:  an.Tags,
+		}
+	}
+
+	return result, nil
+}
+
+func NewAnnotator(opts ...annotator.Option) annotator.Annotator {
+	options := annotator.NewOptions(opts...)
+
+	return &grafanaAnnotator{
+		options: options,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
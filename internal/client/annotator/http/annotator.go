@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/w-h-a/caus/internal/client/annotator"
+)
+
+type httpAnnotator struct {
+	options annotator.Options
+	client  *http.Client
+}
+
+// event is one entry of the response. The start may be given as "time"
+// or "start".
+type event struct {
+	Time  timestamp `json:"time"`
+	Start timestamp `json:"start"`
+	End   timestamp `json:"end"`
+	Text  string    `json:"text"`
+	Tags  []string  `json:"tags"`
+}
+
+// timestamp is an RFC3339 string or unix seconds.
+type timestamp struct {
+	time.Time
+}
+
+func (t *timestamp) UnmarshalJSON(bs []byte) error {
+	if string(bs) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(bs, &s); err == nil {
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		t.Time = parsed.UTC()
+		return nil
+	}
+
+	var secs float64
+	if err := json.Unmarshal(bs, &secs); err != nil {
+		return fmt.Errorf("'%s' is neither an RFC3339 timestamp nor unix seconds", string(bs))
+	}
+	t.Time = time.Unix(0, int64(secs*float64(time.Second))).UTC()
+
+	return nil
+}
+
+// Annotations asks the endpoint at the location for the events of the
+// window with GET ?start=...&end=...&tags=..., and expects a JSON array of
+// {"time", "end", "text", "tags"} objects in return.
+func (a *httpAnnotator) Annotations(ctx context.Context, tags []string, start time.Time, end time.Time) ([]annotator.Annotation, error) {
+	u, err := url.Parse(a.options.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid events endpoint: %w", err)
+	}
+
+	params := u.Query()
+	params.Set("start", start.UTC().Format(time.RFC3339))
+	params.Set("end", end.UTC().Format(time.RFC3339))
+	for _, tag := range tags {
+		params.Add("tags", tag)
+	}
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.options.ApiKey) > 0 {
+		req.Header.Set("Authorization", "Bearer "+a.options.ApiKey)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	rsp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("events request failed: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	bs, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return nil, fmt.Errorf("events request failed with status %d: %s", rsp.StatusCode, strings.TrimSpace(string(bs)))
+	}
+
+	var events []event
+	if err := json.Unmarshal(bs, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	// the endpoint may ignore the parameters, so filter again
+	var result []annotator.Annotation
+	for i, e := range events {
+		from := e.Time.Time
+		if from.IsZero() {
+			from = e.Start.Time
+		}
+		if from.IsZero() {
+			return nil, fmt.Errorf("event %d has no time", i)
+		}
+		to := e.End.Time
+		if to.Before(from) {
+			to = from
+		}
+		if to.Before(start) || from.After(end) || !annotator.HasTags(e.Tags, tags) {
+			continue
+		}
+		result = append(result, annotator.Annotation{Start: from, End: to, Text: e.Text, Tags: e.Tags})
+	}
+
+	return result, nil
+}
+
+func NewAnnotator(opts ...annotator.Option) annotator.Annotator {
+	options := annotator.NewOptions(opts...)
+
+	return &httpAnnotator{
+		options: options,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/w-h-a/caus/internal/client/annotator"
+)
+
+type mockAnnotator struct {
+	options     annotator.Options
+	annotations []annotator.Annotation
+	err         error
+}
+
+// Annotations returns every annotation from WithAnnotations, regardless
+// of tags and window.
+func (a *mockAnnotator) Annotations(ctx context.Context, tags []string, start time.Time, end time.Time) ([]annotator.Annotation, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return a.annotations, nil
+}
+
+func NewAnnotator(opts ...annotator.Option) *mockAnnotator {
+	options := annotator.NewOptions(opts...)
+
+	ma := &mockAnnotator{
+		options: options,
+	}
+
+	if as, ok := getAnnotationsFromCtx(options.Context); ok {
+		ma.annotations = as
+	}
+
+	if err, ok := getErrorFromCtx(options.Context); ok {
+		ma.err = err
+	}
+
+	return ma
+}
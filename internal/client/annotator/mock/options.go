@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/w-h-a/caus/internal/client/annotator"
+)
+
+type annotationsKey struct{}
+type errorKey struct{}
+
+func WithAnnotations(as []annotator.Annotation) annotator.Option {
+	return func(o *annotator.Options) {
+		o.Context = context.WithValue(o.Context, annotationsKey{}, as)
+	}
+}
+
+func WithError(err error) annotator.Option {
+	return func(o *annotator.Options) {
+		o.Context = context.WithValue(o.Context, errorKey{}, err)
+	}
+}
+
+func getAnnotationsFromCtx(ctx context.Context) ([]annotator.Annotation, bool) {
+	as, ok := ctx.Value(annotationsKey{}).([]annotator.Annotation)
+	return as, ok
+}
+
+func getErrorFromCtx(ctx context.Context) (error, bool) {
+	err, ok := ctx.Value(errorKey{}).(error)
+	return err, ok
+}
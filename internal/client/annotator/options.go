@@ -0,0 +1,35 @@
+package annotator
+
+import "context"
+
+type Option func(*Options)
+
+type Options struct {
+	Location string
+	ApiKey   string
+	Context  context.Context
+}
+
+func WithLocation(loc string) Option {
+	return func(o *Options) {
+		o.Location = loc
+	}
+}
+
+func WithApiKey(key string) Option {
+	return func(o *Options) {
+		o.ApiKey = key
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Context: context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
@@ -0,0 +1,89 @@
+// Package columnar encodes datasets as the typed, chunked columns of the
+// v1alpha2 worker API and translates its results to and from v1alpha1, the
+// types the rest of caus works with.
+package columnar
+
+import (
+	causalv2 "github.com/w-h-a/caus/api/causal/v1alpha2"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+// DefaultChunkBytes keeps every chunk well under gRPC's 4MB default message
+// size.
+const DefaultChunkBytes = 1 << 20
+
+// ChunkRows is the number of rows of a dataset with the given number of
+// columns that fit in chunkBytes: 8 bytes per timestamp and value plus a
+// byte per missing flag.
+func ChunkRows(columns int, chunkBytes int) int {
+	if chunkBytes <= 0 {
+		chunkBytes = DefaultChunkBytes
+	}
+
+	return max(1, chunkBytes/(8+9*columns))
+}
+
+// Schema describes ds to the worker.
+func Schema(ds *dataset.Dataset) *causalv2.Schema {
+	schema := &causalv2.Schema{
+		Columns: ds.Names(),
+		Rows:    int64(ds.Len()),
+		Step:    ds.Step.String(),
+	}
+
+	for _, b := range ds.Breaks {
+		schema.Breaks = append(schema.Breaks, int64(b))
+	}
+
+	return schema
+}
+
+// Chunks calls send with consecutive runs of at most rows rows of ds, in
+// order, and stops at the first error.
+func Chunks(ds *dataset.Dataset, rows int, send func(*causalv2.DataChunk) error) error {
+	rows = max(1, rows)
+
+	for lo := 0; lo < ds.Len(); lo += rows {
+		hi := min(lo+rows, ds.Len())
+
+		chunk := &causalv2.DataChunk{
+			Columns: make([]*causalv2.Column, len(ds.Columns)),
+		}
+
+		if len(ds.Timestamps) == ds.Len() {
+			chunk.Timestamps = make([]int64, 0, hi-lo)
+			for _, t := range ds.Timestamps[lo:hi] {
+				chunk.Timestamps = append(chunk.Timestamps, t.UnixMilli())
+			}
+		}
+
+		for i, c := range ds.Columns {
+			chunk.Columns[i] = column(c.Values[lo:hi])
+		}
+
+		if err := send(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func column(values []float64) *causalv2.Column {
+	col := &causalv2.Column{
+		Values: make([]float64, len(values)),
+	}
+
+	for i, v := range values {
+		if !dataset.Missing(v) {
+			col.Values[i] = v
+			continue
+		}
+		if col.Missing == nil {
+			col.Missing = make([]bool, len(values))
+		}
+		col.Missing[i] = true
+	}
+
+	return col
+}
@@ -0,0 +1,120 @@
+package columnar
+
+import (
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	causalv2 "github.com/w-h-a/caus/api/causal/v1alpha2"
+)
+
+// ToV2Graph translates a v1alpha1 graph, e.g. the one to estimate.
+func ToV2Graph(g *causal.CausalGraph) *causalv2.CausalGraph {
+	if g == nil {
+		return nil
+	}
+
+	out := &causalv2.CausalGraph{}
+
+	for _, n := range g.Nodes {
+		out.Nodes = append(out.Nodes, &causalv2.Node{Id: n.Id, Label: n.Label, Transforms: n.Transforms})
+	}
+
+	for _, e := range g.Edges {
+		out.Edges = append(out.Edges, &causalv2.Edge{
+			Source:      e.Source,
+			Target:      e.Target,
+			Type:        e.Type,
+			Lag:         e.Lag,
+			Statistic:   e.Statistic,
+			PValue:      e.PValue,
+			PartialCorr: e.PartialCorr,
+		})
+	}
+
+	if w := g.Window; w != nil {
+		out.Window = &causalv2.Window{Start: w.Start, End: w.End, Step: w.Step}
+	}
+
+	return out
+}
+
+// FromV2Graph translates a discovered graph back to v1alpha1.
+func FromV2Graph(g *causalv2.CausalGraph) *causal.CausalGraph {
+	if g == nil {
+		return nil
+	}
+
+	out := &causal.CausalGraph{
+		Nodes:  fromV2Nodes(g.Nodes),
+		Window: fromV2Window(g.Window),
+	}
+
+	for _, e := range g.Edges {
+		out.Edges = append(out.Edges, &causal.Edge{
+			Source:      e.Source,
+			Target:      e.Target,
+			Type:        e.Type,
+			Lag:         e.Lag,
+			Statistic:   e.Statistic,
+			PValue:      e.PValue,
+			PartialCorr: e.PartialCorr,
+		})
+	}
+
+	return out
+}
+
+// ToV2Bootstrap translates the bootstrap settings of an estimate request.
+func ToV2Bootstrap(b *causal.Bootstrap) *causalv2.Bootstrap {
+	if b == nil {
+		return nil
+	}
+
+	return &causalv2.Bootstrap{Samples: b.Samples, BlockSize: b.BlockSize, Seed: b.Seed}
+}
+
+// FromV2EstimateResponse translates fitted models back to v1alpha1.
+func FromV2EstimateResponse(rsp *causalv2.EstimateResponse) *causal.EstimateResponse {
+	if rsp == nil {
+		return nil
+	}
+
+	out := &causal.EstimateResponse{
+		Models: make(map[string]*causal.ModelInfo, len(rsp.Models)),
+		Window: fromV2Window(rsp.Window),
+		Nodes:  fromV2Nodes(rsp.Nodes),
+	}
+
+	for name, m := range rsp.Models {
+		out.Models[name] = &causal.ModelInfo{
+			Features:         m.Features,
+			Coefficients:     m.Coefficients,
+			Intercept:        m.Intercept,
+			StandardErrors:   m.StandardErrors,
+			TStatistics:      m.TStatistics,
+			PValues:          m.PValues,
+			CiLower:          m.CiLower,
+			CiUpper:          m.CiUpper,
+			RSquared:         m.RSquared,
+			ResidualVariance: m.ResidualVariance,
+			NObs:             m.NObs,
+			Inference:        m.Inference,
+			ConfidenceLevel:  m.ConfidenceLevel,
+		}
+	}
+
+	return out
+}
+
+func fromV2Nodes(nodes []*causalv2.Node) []*causal.Node {
+	var out []*causal.Node
+	for _, n := range nodes {
+		out = append(out, &causal.Node{Id: n.Id, Label: n.Label, Transforms: n.Transforms})
+	}
+	return out
+}
+
+func fromV2Window(w *causalv2.Window) *causal.Window {
+	if w == nil {
+		return nil
+	}
+	return &causal.Window{Start: w.Start, End: w.End, Step: w.Step}
+}
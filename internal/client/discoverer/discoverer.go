@@ -4,8 +4,45 @@ import (
 	"context"
 
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
 )
 
+// Edge types. Only directed edges (source --> target) can be fitted; the
+// others are associations the discovery could not orient.
+const (
+	// EdgeDirected is source --> target.
+	EdgeDirected = "directed"
+	// EdgeUndirected is source o-o target: linked, direction unknown.
+	EdgeUndirected = "undirected"
+	// EdgeBidirected is source <-> target: both are driven by a hidden
+	// common cause.
+	EdgeBidirected = "bidirected"
+	// EdgeConflicting is source x-x target: the orientation rules disagree.
+	EdgeConflicting = "conflicting"
+)
+
+// EdgeArrow is the tigramite notation of an edge type, e.g. "o-o".
+func EdgeArrow(edgeType string) string {
+	switch edgeType {
+	case EdgeUndirected:
+		return "o-o"
+	case EdgeBidirected:
+		return "<->"
+	case EdgeConflicting:
+		return "x-x"
+	default:
+		return "-->"
+	}
+}
+
 type Discoverer interface {
 	Discover(ctx context.Context, req *causal.DiscoverRequest) (*causal.CausalGraph, error)
 }
+
+// DatasetDiscoverer is implemented by discoverers that take the dataset
+// itself instead of req.CsvData, e.g. one that streams it to the worker as
+// typed columns. Callers check for it with a type assertion.
+type DatasetDiscoverer interface {
+	Discoverer
+	DiscoverDataset(ctx context.Context, ds *dataset.Dataset, req *causal.DiscoverRequest) (*causal.CausalGraph, error)
+}
@@ -5,6 +5,7 @@ import (
 
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
 	"github.com/w-h-a/caus/internal/client/discoverer"
+	"google.golang.org/protobuf/proto"
 )
 
 type mockDiscoverer struct {
@@ -14,6 +15,11 @@ type mockDiscoverer struct {
 
 func (d *mockDiscoverer) Discover(ctx context.Context, req *causal.DiscoverRequest) (*causal.CausalGraph, error) {
 	d.lastRequest = req
+
+	if g, ok := getGraphFromCtx(d.options.Context); ok {
+		return proto.Clone(g).(*causal.CausalGraph), nil
+	}
+
 	return &causal.CausalGraph{
 		Nodes: []*causal.Node{{Id: 0, Label: "test"}},
 	}, nil
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+)
+
+type graphKey struct{}
+
+func WithGraph(g *causal.CausalGraph) discoverer.Option {
+	return func(o *discoverer.Options) {
+		o.Context = context.WithValue(o.Context, graphKey{}, g)
+	}
+}
+
+func getGraphFromCtx(ctx context.Context) (*causal.CausalGraph, bool) {
+	g, ok := ctx.Value(graphKey{}).(*causal.CausalGraph)
+	return g, ok
+}
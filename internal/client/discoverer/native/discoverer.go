@@ -0,0 +1,129 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+const (
+	defaultMaxLag = 3
+	// alphaLevel is the MCI significance level a link must reach to be kept
+	// in the graph, tigramite's alpha_level.
+	alphaLevel = 0.05
+)
+
+// defaultPcAlphas are the PC1 levels tried when pc_alpha is 0, as tigramite
+// does when pc_alpha is None.
+var defaultPcAlphas = []float64{0.05, 0.1, 0.2, 0.3, 0.4, 0.5}
+
+type nativeDiscoverer struct {
+	options discoverer.Options
+}
+
+func (d *nativeDiscoverer) Discover(ctx context.Context, req *causal.DiscoverRequest) (*causal.CausalGraph, error) {
+	// 1. Read data
+	ds, err := dataset.ReadCSV(strings.NewReader(req.CsvData))
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range req.Breaks {
+		ds.Breaks = append(ds.Breaks, int(b))
+	}
+
+	labels := ds.Names()
+
+	// 2. Initialize PCMCI
+	maxLag := int(req.MaxLag)
+	if maxLag <= 0 {
+		maxLag = defaultMaxLag
+	}
+
+	// pc_alpha is the PC1 selection level only; 0 picks the best of the
+	// defaults for each variable. Links are kept at the MCI level.
+	pcAlphas := defaultPcAlphas
+	if req.PcAlpha > 0 {
+		pcAlphas = []float64{float64(req.PcAlpha)}
+	}
+
+	if ds.Len() <= 2*maxLag+2 {
+		return nil, fmt.Errorf("not enough rows (%d) for max lag %d", ds.Len(), maxLag)
+	}
+
+	data := make([][]float64, len(ds.Columns))
+	for i, c := range ds.Columns {
+		data[i] = c.Values
+	}
+
+	p := &pcmci{
+		data:     data,
+		crosses:  ds.Crosses,
+		tauMax:   maxLag,
+		pcAlphas: pcAlphas,
+	}
+
+	// 3. Run PCMCI
+	log.Printf("NATIVE DISCOVERER: Running PCMCI with max_lag=%d, pc_alpha=%.4g and alpha_level=%.4g", maxLag, pcAlphas, alphaLevel)
+
+	results, err := p.run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pcmci failed: %w", err)
+	}
+
+	// 4. Build the response
+	nodes := make([]*causal.Node, len(labels))
+	for i, label := range labels {
+		nodes[i] = &causal.Node{Id: int32(i), Label: label}
+	}
+
+	var edges []*causal.Edge
+	for i := range labels { // Source
+		for j := range labels { // Target
+			for tau := 0; tau <= maxLag; tau++ { // Lag
+				if i == j && tau == 0 {
+					continue
+				}
+
+				// MCI cannot orient contemporaneous links, so each one is
+				// reported once as undirected
+				edgeType := discoverer.EdgeDirected
+				if tau == 0 {
+					if j < i {
+						continue
+					}
+					edgeType = discoverer.EdgeUndirected
+				}
+
+				res := results[i][j][tau]
+				if res.PValue <= alphaLevel {
+					edges = append(edges, &causal.Edge{
+						Source:      labels[i],
+						Target:      labels[j],
+						Type:        edgeType,
+						Lag:         int32(tau),
+						Statistic:   float32(res.Statistic),
+						PValue:      float32(res.PValue),
+						PartialCorr: float32(res.Value),
+					})
+				}
+			}
+		}
+	}
+
+	return &causal.CausalGraph{Nodes: nodes, Edges: edges}, nil
+}
+
+func NewDiscoverer(opts ...discoverer.Option) discoverer.Discoverer {
+	options := discoverer.NewOptions(opts...)
+
+	d := &nativeDiscoverer{
+		options: options,
+	}
+
+	return d
+}
@@ -0,0 +1,314 @@
+package native
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/w-h-a/caus/internal/stats"
+)
+
+// link is the variable at index v observed lag steps before the target.
+type link struct {
+	v   int
+	lag int
+}
+
+// pcmci runs the PCMCI algorithm (Runge et al., 2019) with a partial
+// correlation independence test:
+//
+//  1. PC1 selects a superset of the lagged parents of each variable by
+//     iteratively conditioning on the strongest remaining candidates.
+//  2. MCI tests every link X(t-tau) -> Y(t) conditioning on the parents of
+//     both Y and (shifted by tau) X.
+type pcmci struct {
+	data [][]float64 // data[variable][time]
+	// crosses reports whether time t, looking back lag steps, reaches
+	// across an excluded window
+	crosses func(t int, lag int) bool
+	tauMax  int
+	// pcAlphas are the PC1 levels to select parents at. With more than one,
+	// each variable keeps the parents of the level whose model of it has the
+	// lowest AIC, as tigramite does when pc_alpha is None.
+	pcAlphas []float64
+}
+
+// mciResult holds one test outcome per (source, target, lag).
+type mciResult [][][]*stats.ParCorrResult
+
+func (p *pcmci) run(ctx context.Context) (mciResult, error) {
+	n := len(p.data)
+
+	parents := make([][]link, n)
+	if err := p.parallel(ctx, func(ctx context.Context, j int) error {
+		ps, err := p.pc1(ctx, j)
+		if err != nil {
+			return err
+		}
+		parents[j] = ps
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	results := make(mciResult, n)
+	for i := range results {
+		results[i] = make([][]*stats.ParCorrResult, n)
+		for j := range results[i] {
+			results[i][j] = make([]*stats.ParCorrResult, p.tauMax+1)
+		}
+	}
+
+	if err := p.parallel(ctx, func(ctx context.Context, j int) error {
+		for i := 0; i < n; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			for tau := 0; tau <= p.tauMax; tau++ {
+				if i == j && tau == 0 {
+					continue
+				}
+
+				x := link{v: i, lag: tau}
+				z := p.mciConditions(x, parents[j], parents[i])
+
+				res, err := p.test(x, j, z)
+				if err != nil {
+					return err
+				}
+				results[i][j][tau] = res
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// contemporaneous links are tested in both directions; keep the weaker one
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a, b := results[i][j][0], results[j][i][0]
+			if a.PValue < b.PValue {
+				results[i][j][0] = b
+			} else {
+				results[j][i][0] = a
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// pc1 returns the lagged parents of variable j sorted by decreasing strength,
+// at the best of the PC1 levels.
+func (p *pcmci) pc1(ctx context.Context, j int) ([]link, error) {
+	if len(p.pcAlphas) == 1 {
+		return p.pc1At(ctx, j, p.pcAlphas[0])
+	}
+
+	var best []link
+	bestScore := math.Inf(1)
+	for k, alpha := range p.pcAlphas {
+		parents, err := p.pc1At(ctx, j, alpha)
+		if err != nil {
+			return nil, err
+		}
+
+		ys, zs := p.sample(j, parents)
+		score, err := stats.AIC(ys, zs)
+		if err != nil {
+			return nil, err
+		}
+
+		if k == 0 || score < bestScore {
+			best, bestScore = parents, score
+		}
+	}
+
+	return best, nil
+}
+
+// pc1At returns the lagged parents of variable j at the PC1 level alpha.
+func (p *pcmci) pc1At(ctx context.Context, j int, alpha float64) ([]link, error) {
+	n := len(p.data)
+
+	var candidates []link
+	strength := map[link]float64{}
+	for tau := 1; tau <= p.tauMax; tau++ {
+		for i := 0; i < n; i++ {
+			l := link{v: i, lag: tau}
+			candidates = append(candidates, l)
+			strength[l] = math.Inf(1)
+		}
+	}
+
+	for condsDim := 0; condsDim <= len(candidates)-1; condsDim++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var nonSignificant []link
+
+		for _, candidate := range candidates {
+			var z []link
+			for _, other := range candidates {
+				if len(z) == condsDim {
+					break
+				}
+				if other != candidate {
+					z = append(z, other)
+				}
+			}
+
+			res, err := p.test(candidate, j, z)
+			if err != nil {
+				return nil, err
+			}
+
+			strength[candidate] = math.Min(strength[candidate], math.Abs(res.Value))
+
+			if res.PValue > alpha {
+				nonSignificant = append(nonSignificant, candidate)
+			}
+		}
+
+		candidates = slices.DeleteFunc(candidates, func(l link) bool {
+			return slices.Contains(nonSignificant, l)
+		})
+
+		slices.SortStableFunc(candidates, func(a, b link) int {
+			switch {
+			case strength[a] > strength[b]:
+				return -1
+			case strength[a] < strength[b]:
+				return 1
+			default:
+				return 0
+			}
+		})
+	}
+
+	return candidates, nil
+}
+
+// mciConditions is the conditioning set of the MCI test of x -> Y: the
+// parents of Y other than x plus the parents of X shifted by x's lag.
+func (p *pcmci) mciConditions(x link, parentsY []link, parentsX []link) []link {
+	var z []link
+
+	for _, l := range parentsY {
+		if l != x {
+			z = append(z, l)
+		}
+	}
+
+	for _, l := range parentsX {
+		shifted := link{v: l.v, lag: l.lag + x.lag}
+		if shifted.lag > 2*p.tauMax || slices.Contains(z, shifted) {
+			continue
+		}
+		z = append(z, shifted)
+	}
+
+	return z
+}
+
+// test runs ParCorr on X(t-x.lag) and Y(t) given z over the sample of Y.
+func (p *pcmci) test(x link, y int, z []link) (*stats.ParCorrResult, error) {
+	ys, rows := p.sample(y, append([]link{x}, z...))
+
+	xs := make([]float64, len(rows))
+	zs := make([][]float64, len(rows))
+	for k, row := range rows {
+		xs[k], zs[k] = row[0], row[1:]
+	}
+
+	return stats.ParCorr(xs, ys, zs)
+}
+
+// sample returns Y(t) and the values of links at every time t that leaves
+// room for the largest possible lag, without reaching across an excluded
+// window, and has no missing values.
+func (p *pcmci) sample(y int, links []link) ([]float64, [][]float64) {
+	var ys []float64
+	var rows [][]float64
+
+	for t := 2 * p.tauMax; t < len(p.data[y]); t++ {
+		if p.crosses != nil && p.crosses(t, 2*p.tauMax) {
+			continue
+		}
+
+		yv := p.data[y][t]
+		if math.IsNaN(yv) {
+			continue
+		}
+
+		row := make([]float64, len(links))
+		ok := true
+		for k, l := range links {
+			row[k] = p.data[l.v][t-l.lag]
+			if math.IsNaN(row[k]) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		ys = append(ys, yv)
+		rows = append(rows, row)
+	}
+
+	return ys, rows
+}
+
+// parallel calls fn for every variable index, bounded by the number of CPUs,
+// and returns the first error.
+func (p *pcmci) parallel(ctx context.Context, fn func(ctx context.Context, j int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, runtime.NumCPU())
+	errs := make([]error, len(p.data))
+
+	var wg sync.WaitGroup
+	for j := range p.data {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[j] = ctx.Err()
+				return
+			}
+
+			if err := fn(ctx, j); err != nil {
+				errs[j] = err
+				cancel()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// prefer the error that caused the cancellation over its fallout
+	var first error
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+		if first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
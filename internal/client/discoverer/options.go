@@ -1,11 +1,20 @@
 package discoverer
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/w-h-a/caus/internal/client/worker"
+)
 
 type Option func(*Options)
 
 type Options struct {
 	Location string
+	Timeout  time.Duration
+	TLS      worker.TLS
+	Token    string
+	Retry    worker.Retry
 	Context  context.Context
 }
 
@@ -15,8 +24,37 @@ func WithLocation(loc string) Option {
 	}
 }
 
+// WithTimeout bounds each attempt of a call. A value <= 0 removes the bound.
+func WithTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = d
+	}
+}
+
+// WithTLS secures the connection to the worker.
+func WithTLS(t worker.TLS) Option {
+	return func(o *Options) {
+		o.TLS = t
+	}
+}
+
+// WithToken sends token as a bearer token with every call.
+func WithToken(token string) Option {
+	return func(o *Options) {
+		o.Token = token
+	}
+}
+
+// WithRetry retries calls while the worker is unavailable.
+func WithRetry(r worker.Retry) Option {
+	return func(o *Options) {
+		o.Retry = r
+	}
+}
+
 func NewOptions(opts ...Option) Options {
 	options := Options{
+		Timeout: 2 * time.Minute,
 		Context: context.Background(),
 	}
 
@@ -2,12 +2,10 @@ package v1alpha1
 
 import (
 	"context"
-	"time"
 
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
 	"github.com/w-h-a/caus/internal/client/discoverer"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"github.com/w-h-a/caus/internal/client/worker"
 )
 
 type v1alpha1Discoverer struct {
@@ -16,20 +14,27 @@ type v1alpha1Discoverer struct {
 }
 
 func (d *v1alpha1Discoverer) Discover(ctx context.Context, req *causal.DiscoverRequest) (*causal.CausalGraph, error) {
-	callCtx, cancel := context.WithTimeout(ctx, time.Minute*2)
-	defer cancel()
+	var graph *causal.CausalGraph
 
-	return d.client.Discover(callCtx, req)
+	err := worker.Call(ctx, d.options.Timeout, d.options.Retry, func(ctx context.Context) error {
+		var err error
+		graph, err = d.client.Discover(ctx, req)
+		return err
+	})
+
+	return graph, err
 }
 
-func NewDiscoverer(opts ...discoverer.Option) discoverer.Discoverer {
+func NewDiscoverer(opts ...discoverer.Option) (discoverer.Discoverer, error) {
 	options := discoverer.NewOptions(opts...)
 
-	// TODO: validate options
+	if err := options.Retry.Validate(); err != nil {
+		return nil, err
+	}
 
-	conn, err := grpc.NewClient(options.Location, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := worker.Dial(options.Location, options.TLS, options.Token)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	c := causal.NewCausalDiscoveryClient(conn)
@@ -39,5 +44,5 @@ func NewDiscoverer(opts ...discoverer.Option) discoverer.Discoverer {
 		client:  c,
 	}
 
-	return d
+	return d, nil
 }
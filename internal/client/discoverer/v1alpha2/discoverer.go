@@ -0,0 +1,94 @@
+package v1alpha2
+
+import (
+	"context"
+	"strings"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	causalv2 "github.com/w-h-a/caus/api/causal/v1alpha2"
+	"github.com/w-h-a/caus/internal/client/columnar"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+	"github.com/w-h-a/caus/internal/client/worker"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+type v1alpha2Discoverer struct {
+	options    discoverer.Options
+	client     causalv2.CausalDiscoveryClient
+	chunkBytes int
+}
+
+func (d *v1alpha2Discoverer) Discover(ctx context.Context, req *causal.DiscoverRequest) (*causal.CausalGraph, error) {
+	ds, err := dataset.ReadCSV(strings.NewReader(req.CsvData))
+	if err != nil {
+		return nil, err
+	}
+
+	return d.DiscoverDataset(ctx, ds, req)
+}
+
+func (d *v1alpha2Discoverer) DiscoverDataset(ctx context.Context, ds *dataset.Dataset, req *causal.DiscoverRequest) (*causal.CausalGraph, error) {
+	params := &causalv2.DiscoverParams{
+		Schema:  columnar.Schema(ds),
+		MaxLag:  req.MaxLag,
+		PcAlpha: req.PcAlpha,
+	}
+
+	var graph *causalv2.CausalGraph
+
+	err := worker.Call(ctx, d.options.Timeout, d.options.Retry, func(ctx context.Context) error {
+		stream, err := d.client.Discover(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = stream.Send(&causalv2.DiscoverRequest{Payload: &causalv2.DiscoverRequest_Params{Params: params}})
+		if err == nil {
+			rows := columnar.ChunkRows(len(ds.Columns), d.chunkBytes)
+			err = columnar.Chunks(ds, rows, func(chunk *causalv2.DataChunk) error {
+				return stream.Send(&causalv2.DiscoverRequest{Payload: &causalv2.DiscoverRequest_Chunk{Chunk: chunk}})
+			})
+		}
+		if err != nil {
+			// the server's status explains an aborted stream better than io.EOF
+			if _, rspErr := stream.CloseAndRecv(); rspErr != nil {
+				return rspErr
+			}
+			return err
+		}
+
+		graph, err = stream.CloseAndRecv()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return columnar.FromV2Graph(graph), nil
+}
+
+func NewDiscoverer(opts ...discoverer.Option) (discoverer.Discoverer, error) {
+	options := discoverer.NewOptions(opts...)
+
+	if err := options.Retry.Validate(); err != nil {
+		return nil, err
+	}
+
+	conn, err := worker.Dial(options.Location, options.TLS, options.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	c := causalv2.NewCausalDiscoveryClient(conn)
+
+	d := &v1alpha2Discoverer{
+		options: options,
+		client:  c,
+	}
+
+	if n, ok := getChunkBytesFromCtx(options.Context); ok {
+		d.chunkBytes = n
+	}
+
+	return d, nil
+}
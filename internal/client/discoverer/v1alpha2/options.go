@@ -0,0 +1,22 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"github.com/w-h-a/caus/internal/client/discoverer"
+)
+
+type chunkBytesKey struct{}
+
+// WithChunkBytes bounds the size of each streamed chunk of rows. It defaults
+// to columnar.DefaultChunkBytes.
+func WithChunkBytes(n int) discoverer.Option {
+	return func(o *discoverer.Options) {
+		o.Context = context.WithValue(o.Context, chunkBytesKey{}, n)
+	}
+}
+
+func getChunkBytesFromCtx(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(chunkBytesKey{}).(int)
+	return n, ok
+}
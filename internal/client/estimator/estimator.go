@@ -4,8 +4,17 @@ import (
 	"context"
 
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
 )
 
 type Estimator interface {
 	Estimate(ctx context.Context, req *causal.EstimateRequest) (*causal.EstimateResponse, error)
 }
+
+// DatasetEstimator is implemented by estimators that take the dataset
+// itself instead of req.CsvData, e.g. one that streams it to the worker as
+// typed columns. Callers check for it with a type assertion.
+type DatasetEstimator interface {
+	Estimator
+	EstimateDataset(ctx context.Context, ds *dataset.Dataset, req *causal.EstimateRequest) (*causal.EstimateResponse, error)
+}
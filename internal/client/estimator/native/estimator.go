@@ -0,0 +1,171 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/estimator"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/stats"
+)
+
+const defaultConfidenceLevel = 0.95
+
+type parent struct {
+	name string
+	lag  int
+}
+
+type nativeEstimator struct {
+	options estimator.Options
+}
+
+func (s *nativeEstimator) Estimate(ctx context.Context, req *causal.EstimateRequest) (*causal.EstimateResponse, error) {
+	// 1. Load data
+	ds, err := dataset.ReadCSV(strings.NewReader(req.CsvData))
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range req.Breaks {
+		ds.Breaks = append(ds.Breaks, int(b))
+	}
+
+	// 2. Parse graph into parents lookup
+	parents := map[string][]parent{}
+	for _, edge := range req.GetGraph().GetEdges() {
+		if ds.Index(edge.Target) < 0 {
+			continue
+		}
+		if ds.Index(edge.Source) < 0 {
+			return nil, fmt.Errorf("edge %s -> %s references unknown column '%s'", edge.Source, edge.Target, edge.Source)
+		}
+		if edge.Lag < 0 {
+			continue
+		}
+		parents[edge.Target] = append(parents[edge.Target], parent{name: edge.Source, lag: int(edge.Lag)})
+	}
+
+	// 3. Fit SCM
+	level := float64(req.ConfidenceLevel)
+	if level <= 0 || level >= 1 {
+		level = defaultConfidenceLevel
+	}
+
+	models := map[string]*causal.ModelInfo{}
+
+	for _, col := range ds.Columns {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		nodeParents := parents[col.Name]
+		if len(nodeParents) == 0 {
+			continue
+		}
+
+		x, y, features := design(ds, col, nodeParents)
+
+		fit, err := stats.OLS(x, y)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fit model for '%s': %w", col.Name, err)
+		}
+
+		log.Printf("NATIVE ESTIMATOR: Model for %s: Coeffs=%v Intercept=%v Features=%v", col.Name, fit.Coefficients, fit.Intercept, features)
+
+		models[col.Name] = modelInfo(x, y, features, fit, level, req.GetBootstrap())
+	}
+
+	// 4. Format results
+	return &causal.EstimateResponse{Models: models}, nil
+}
+
+func modelInfo(x [][]float64, y []float64, features []string, fit *stats.Fit, level float64, bootstrap *causal.Bootstrap) *causal.ModelInfo {
+	k := len(features)
+
+	info := &causal.ModelInfo{
+		Features:         features,
+		Coefficients:     make([]float32, k),
+		Intercept:        float32(fit.Intercept),
+		StandardErrors:   make([]float32, k),
+		TStatistics:      make([]float32, k),
+		PValues:          make([]float32, k),
+		CiLower:          make([]float32, k),
+		CiUpper:          make([]float32, k),
+		RSquared:         float32(fit.RSquared),
+		ResidualVariance: float32(fit.ResidualVariance),
+		NObs:             int32(len(y)),
+		Inference:        "analytic",
+		ConfidenceLevel:  float32(level),
+	}
+
+	for i := range features {
+		lo, hi := fit.ConfidenceInterval(i, level)
+		info.Coefficients[i] = float32(fit.Coefficients[i])
+		info.StandardErrors[i] = float32(fit.StdErrors[i])
+		info.TStatistics[i] = float32(fit.TStatistics[i])
+		info.PValues[i] = float32(fit.PValues[i])
+		info.CiLower[i] = float32(lo)
+		info.CiUpper[i] = float32(hi)
+	}
+
+	if bootstrap.GetSamples() > 0 {
+		b := stats.BlockBootstrapOLS(x, y, fit, int(bootstrap.Samples), int(bootstrap.BlockSize), level, bootstrap.Seed)
+		info.Inference = "bootstrap"
+		for i := range features {
+			info.StandardErrors[i] = float32(b.StdErrors[i])
+			info.TStatistics[i] = float32(fit.Coefficients[i] / b.StdErrors[i])
+			info.PValues[i] = float32(b.PValues[i])
+			info.CiLower[i] = float32(b.CILower[i])
+			info.CiUpper[i] = float32(b.CIUpper[i])
+		}
+	}
+
+	return info
+}
+
+// design builds the lagged design matrix for col, dropping the leading rows
+// for which some lag reaches before the start of the data, those for which
+// it reaches across an excluded window and the rows with a missing value.
+func design(ds *dataset.Dataset, col dataset.Column, parents []parent) ([][]float64, []float64, []string) {
+	maxLag := 0
+	features := make([]string, len(parents))
+	for i, p := range parents {
+		features[i] = fmt.Sprintf("%s_lag%d", p.name, p.lag)
+		maxLag = max(maxLag, p.lag)
+	}
+
+	var x [][]float64
+	var y []float64
+
+	for t := maxLag; t < ds.Len(); t++ {
+		if dataset.Missing(col.Values[t]) || ds.Crosses(t, maxLag) {
+			continue
+		}
+		row := make([]float64, len(parents))
+		complete := true
+		for i, p := range parents {
+			row[i] = ds.Columns[ds.Index(p.name)].Values[t-p.lag]
+			complete = complete && !dataset.Missing(row[i])
+		}
+		if !complete {
+			continue
+		}
+		x = append(x, row)
+		y = append(y, col.Values[t])
+	}
+
+	return x, y, features
+}
+
+func NewEstimator(opts ...estimator.Option) estimator.Estimator {
+	options := estimator.NewOptions(opts...)
+
+	s := &nativeEstimator{
+		options: options,
+	}
+
+	return s
+}
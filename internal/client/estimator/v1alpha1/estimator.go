@@ -2,12 +2,10 @@ package v1alpha1
 
 import (
 	"context"
-	"time"
 
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
 	"github.com/w-h-a/caus/internal/client/estimator"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"github.com/w-h-a/caus/internal/client/worker"
 )
 
 type v1alpha1Estimator struct {
@@ -16,20 +14,27 @@ type v1alpha1Estimator struct {
 }
 
 func (s *v1alpha1Estimator) Estimate(ctx context.Context, req *causal.EstimateRequest) (*causal.EstimateResponse, error) {
-	callCtx, cancel := context.WithTimeout(ctx, time.Minute*2)
-	defer cancel()
+	var rsp *causal.EstimateResponse
 
-	return s.client.Estimate(callCtx, req)
+	err := worker.Call(ctx, s.options.Timeout, s.options.Retry, func(ctx context.Context) error {
+		var err error
+		rsp, err = s.client.Estimate(ctx, req)
+		return err
+	})
+
+	return rsp, err
 }
 
-func NewEstimator(opts ...estimator.Option) estimator.Estimator {
+func NewEstimator(opts ...estimator.Option) (estimator.Estimator, error) {
 	options := estimator.NewOptions(opts...)
 
-	// TODO: validate options
+	if err := options.Retry.Validate(); err != nil {
+		return nil, err
+	}
 
-	conn, err := grpc.NewClient(options.Location, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := worker.Dial(options.Location, options.TLS, options.Token)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	c := causal.NewCausalEstimationClient(conn)
@@ -39,5 +44,5 @@ func NewEstimator(opts ...estimator.Option) estimator.Estimator {
 		client:  c,
 	}
 
-	return s
+	return s, nil
 }
@@ -0,0 +1,95 @@
+package v1alpha2
+
+import (
+	"context"
+	"strings"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	causalv2 "github.com/w-h-a/caus/api/causal/v1alpha2"
+	"github.com/w-h-a/caus/internal/client/columnar"
+	"github.com/w-h-a/caus/internal/client/estimator"
+	"github.com/w-h-a/caus/internal/client/worker"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+type v1alpha2Estimator struct {
+	options    estimator.Options
+	client     causalv2.CausalEstimationClient
+	chunkBytes int
+}
+
+func (s *v1alpha2Estimator) Estimate(ctx context.Context, req *causal.EstimateRequest) (*causal.EstimateResponse, error) {
+	ds, err := dataset.ReadCSV(strings.NewReader(req.CsvData))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.EstimateDataset(ctx, ds, req)
+}
+
+func (s *v1alpha2Estimator) EstimateDataset(ctx context.Context, ds *dataset.Dataset, req *causal.EstimateRequest) (*causal.EstimateResponse, error) {
+	params := &causalv2.EstimateParams{
+		Schema:          columnar.Schema(ds),
+		Graph:           columnar.ToV2Graph(req.Graph),
+		ConfidenceLevel: req.ConfidenceLevel,
+		Bootstrap:       columnar.ToV2Bootstrap(req.Bootstrap),
+	}
+
+	var rsp *causalv2.EstimateResponse
+
+	err := worker.Call(ctx, s.options.Timeout, s.options.Retry, func(ctx context.Context) error {
+		stream, err := s.client.Estimate(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = stream.Send(&causalv2.EstimateRequest{Payload: &causalv2.EstimateRequest_Params{Params: params}})
+		if err == nil {
+			rows := columnar.ChunkRows(len(ds.Columns), s.chunkBytes)
+			err = columnar.Chunks(ds, rows, func(chunk *causalv2.DataChunk) error {
+				return stream.Send(&causalv2.EstimateRequest{Payload: &causalv2.EstimateRequest_Chunk{Chunk: chunk}})
+			})
+		}
+		if err != nil {
+			// the server's status explains an aborted stream better than io.EOF
+			if _, rspErr := stream.CloseAndRecv(); rspErr != nil {
+				return rspErr
+			}
+			return err
+		}
+
+		rsp, err = stream.CloseAndRecv()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return columnar.FromV2EstimateResponse(rsp), nil
+}
+
+func NewEstimator(opts ...estimator.Option) (estimator.Estimator, error) {
+	options := estimator.NewOptions(opts...)
+
+	if err := options.Retry.Validate(); err != nil {
+		return nil, err
+	}
+
+	conn, err := worker.Dial(options.Location, options.TLS, options.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	c := causalv2.NewCausalEstimationClient(conn)
+
+	s := &v1alpha2Estimator{
+		options: options,
+		client:  c,
+	}
+
+	if n, ok := getChunkBytesFromCtx(options.Context); ok {
+		s.chunkBytes = n
+	}
+
+	return s, nil
+}
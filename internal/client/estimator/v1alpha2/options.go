@@ -0,0 +1,22 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"github.com/w-h-a/caus/internal/client/estimator"
+)
+
+type chunkBytesKey struct{}
+
+// WithChunkBytes bounds the size of each streamed chunk of rows. It defaults
+// to columnar.DefaultChunkBytes.
+func WithChunkBytes(n int) estimator.Option {
+	return func(o *estimator.Options) {
+		o.Context = context.WithValue(o.Context, chunkBytesKey{}, n)
+	}
+}
+
+func getChunkBytesFromCtx(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(chunkBytesKey{}).(int)
+	return n, ok
+}
@@ -17,10 +17,7 @@ type clickhouseFetcher struct {
 }
 
 func (f *clickhouseFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[time.Time]float64, error) {
-	interval := fmt.Sprintf("%d", int(step.Minutes()))
-	if interval == "0" {
-		interval = "1" // lowest interval is 1m
-	}
+	interval := fmt.Sprintf("%d", max(int(step.Seconds()), 1))
 
 	results, err := f.aggregateSpans(
 		ctx,
@@ -47,7 +44,7 @@ func (f *clickhouseFetcher) Fetch(ctx context.Context, v variable.VariableDefini
 				return nil, fmt.Errorf("failed to parse time string '%s': %w", r.Time, err)
 			}
 		}
-		data[t.UTC().Truncate(step)] = r.Value
+		data[t.UTC()] = r.Value
 	}
 
 	return data, nil
@@ -83,7 +80,7 @@ func (f *clickhouseFetcher) aggregateSpans(
 		aggregateQuery = "count(*) as value"
 	}
 
-	query := fmt.Sprintf(`SELECT toStartOfInterval(Timestamp, INTERVAL %s minute) as time, %s FROM default.otel_traces WHERE Timestamp>=? AND Timestamp<=?`, interval, aggregateQuery)
+	query := fmt.Sprintf(`SELECT toStartOfInterval(Timestamp, INTERVAL %s second) as time, %s FROM default.otel_traces WHERE Timestamp>=? AND Timestamp<=?`, interval, aggregateQuery)
 	args := []any{start, end}
 	var err error
 
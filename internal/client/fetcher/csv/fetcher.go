@@ -5,11 +5,13 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"time"
 
 	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
 	"github.com/w-h-a/caus/internal/client/fetcher"
+	"github.com/w-h-a/caus/internal/dataset"
 )
 
 type csvFetcher struct {
@@ -48,7 +50,12 @@ func (f *csvFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, s
 		return nil, fmt.Errorf("variable '%s' not found in csv columns: %v", v.Name, header)
 	}
 
-	// 3. Parse Data & Synthesize Timestamps
+	// 3. Parse Data at the file's own timestamps, e.g. of an export
+	if tsIdx := slices.Index(header, dataset.TimestampColumn); tsIdx >= 0 {
+		return readTimestamped(records[1:], tsIdx, colIdx, start, end.Add(step))
+	}
+
+	// 4. Or Synthesize Timestamps
 	result := map[time.Time]float64{}
 	totalRows := len(records) - 1
 
@@ -80,6 +87,30 @@ func (f *csvFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, s
 	return result, nil
 }
 
+// readTimestamped keeps the values of rows from start up to, but not
+// including, until.
+func readTimestamped(rows [][]string, tsIdx int, colIdx int, start time.Time, until time.Time) (map[time.Time]float64, error) {
+	result := map[time.Time]float64{}
+
+	for _, row := range rows {
+		ts, err := dataset.ParseTimestamp(row[tsIdx])
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := strconv.ParseFloat(row[colIdx], 64)
+		if err != nil {
+			continue
+		}
+
+		if !ts.Before(start) && ts.Before(until) {
+			result[ts] = val
+		}
+	}
+
+	return result, nil
+}
+
 func NewFetcher(opts ...fetcher.Option) fetcher.Fetcher {
 	options := fetcher.NewOptions(opts...)
 
@@ -3,6 +3,7 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
@@ -17,6 +18,22 @@ type datadogFetcher struct {
 }
 
 func (f *datadogFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[time.Time]float64, error) {
+	series, err := f.FetchAll(ctx, v, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(series) == 0 {
+		return map[time.Time]float64{}, nil
+	}
+	if len(series) > 1 {
+		return nil, fmt.Errorf("query returned %d series, expected 1 (set split_by to get one variable per series)", len(series))
+	}
+
+	return series[0].Points, nil
+}
+
+func (f *datadogFetcher) FetchAll(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) ([]fetcher.Series, error) {
 	interval := int(step.Seconds())
 	if interval < 1 {
 		interval = 60
@@ -35,22 +52,30 @@ func (f *datadogFetcher) Fetch(ctx context.Context, v variable.VariableDefinitio
 		return nil, fmt.Errorf("datadog query failed: %w", err)
 	}
 
-	if len(rsp.Series) == 0 {
-		return map[time.Time]float64{}, nil
-	}
-	if len(rsp.Series) > 1 {
-		return nil, fmt.Errorf("query returned %d series, expected 1", len(rsp.Series))
-	}
+	result := make([]fetcher.Series, len(rsp.Series))
 
-	result := map[time.Time]float64{}
+	for i, s := range rsp.Series {
+		series := fetcher.Series{
+			Labels: map[string]string{},
+			Points: map[time.Time]float64{},
+		}
+
+		// tags come as "key:value"
+		for _, tag := range s.TagSet {
+			key, value, _ := strings.Cut(tag, ":")
+			series.Labels[key] = value
+		}
 
-	for _, point := range rsp.Series[0].Pointlist {
-		if len(point) < 2 || point[0] == nil || point[1] == nil {
-			continue
+		for _, point := range s.Pointlist {
+			if len(point) < 2 || point[0] == nil || point[1] == nil {
+				continue
+			}
+			tsSeconds := int64(*point[0]) / 1000
+			t := time.Unix(tsSeconds, 0)
+			series.Points[t.UTC()] = *point[1]
 		}
-		tsSeconds := int64(*point[0]) / 1000
-		t := time.Unix(tsSeconds, 0)
-		result[t.UTC().Truncate(step)] = *point[1]
+
+		result[i] = series
 	}
 
 	return result, nil
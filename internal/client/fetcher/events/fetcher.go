@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/annotator"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+)
+
+// eventsFetcher counts the events an annotator lists in every step.
+type eventsFetcher struct {
+	options   fetcher.Options
+	annotator annotator.Annotator
+}
+
+func (f *eventsFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[time.Time]float64, error) {
+	var tags []string
+	if v.EventQuery != nil {
+		tags = v.EventQuery.Tags
+	}
+
+	// the last step's bucket runs until end + step
+	events, err := f.annotator.Annotations(ctx, tags, start, end.Add(step))
+	if err != nil {
+		return nil, fmt.Errorf("events query failed: %w", err)
+	}
+
+	return Count(events, start, end, step), nil
+}
+
+// Count is the number of events that touch each step's bucket from start
+// to end. An event that spans several steps counts in each of them.
+func Count(events []annotator.Annotation, start time.Time, end time.Time, step time.Duration) map[time.Time]float64 {
+	counts := map[time.Time]float64{}
+
+	for t := start.UTC().Truncate(step); !t.After(end); t = t.Add(step) {
+		counts[t] = 0
+	}
+
+	for _, e := range events {
+		first := e.Start.UTC().Truncate(step)
+		for t := first; !t.After(e.End); t = t.Add(step) {
+			if _, ok := counts[t]; ok {
+				counts[t]++
+			}
+		}
+	}
+
+	return counts
+}
+
+func NewFetcher(opts ...fetcher.Option) fetcher.Fetcher {
+	options := fetcher.NewOptions(opts...)
+
+	a, ok := getAnnotatorFromCtx(options.Context)
+	if !ok {
+		panic("events fetcher requires an annotator")
+	}
+
+	return &eventsFetcher{
+		options:   options,
+		annotator: a,
+	}
+}
@@ -0,0 +1,23 @@
+package events
+
+import (
+	"context"
+
+	"github.com/w-h-a/caus/internal/client/annotator"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+)
+
+type annotatorKey struct{}
+
+// WithAnnotator sets the source of the events, e.g. a file or an HTTP
+// endpoint.
+func WithAnnotator(a annotator.Annotator) fetcher.Option {
+	return func(o *fetcher.Options) {
+		o.Context = context.WithValue(o.Context, annotatorKey{}, a)
+	}
+}
+
+func getAnnotatorFromCtx(ctx context.Context) (annotator.Annotator, bool) {
+	a, ok := ctx.Value(annotatorKey{}).(annotator.Annotator)
+	return a, ok
+}
@@ -10,3 +10,16 @@ import (
 type Fetcher interface {
 	Fetch(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[time.Time]float64, error)
 }
+
+// MultiFetcher is implemented by fetchers whose queries can return several
+// series, e.g. one per pod, for variables that are split by label.
+type MultiFetcher interface {
+	Fetcher
+	FetchAll(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) ([]Series, error)
+}
+
+// Series is one series of a query's result, identified by its labels.
+type Series struct {
+	Labels map[string]string
+	Points map[time.Time]float64
+}
@@ -1 +1,223 @@
 package honeycomb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+)
+
+const (
+	pollInterval = 250 * time.Millisecond
+	maxPolls     = 120
+)
+
+type honeycombFetcher struct {
+	options fetcher.Options
+	client  *http.Client
+}
+
+type querySpec struct {
+	Calculations      []calculation `json:"calculations"`
+	Filters           []filter      `json:"filters,omitempty"`
+	FilterCombination string        `json:"filter_combination,omitempty"`
+	Granularity       int           `json:"granularity"`
+	StartTime         int64         `json:"start_time"`
+	EndTime           int64         `json:"end_time"`
+}
+
+type calculation struct {
+	Op     string `json:"op"`
+	Column string `json:"column,omitempty"`
+}
+
+type filter struct {
+	Column string `json:"column"`
+	Op     string `json:"op"`
+	Value  any    `json:"value,omitempty"`
+}
+
+type queryResult struct {
+	ID       string `json:"id"`
+	Complete bool   `json:"complete"`
+	Data     struct {
+		Series []struct {
+			Time string             `json:"time"`
+			Data map[string]float64 `json:"data"`
+		} `json:"series"`
+	} `json:"data"`
+}
+
+func (f *honeycombFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[time.Time]float64, error) {
+	granularity := int(step.Seconds())
+	if granularity < 1 {
+		granularity = 1
+	}
+
+	calc, key := f.buildCalculation(v.TraceQuery.Dimension, v.TraceQuery.AggregationOption)
+
+	spec := querySpec{
+		Calculations:      []calculation{calc},
+		Filters:           f.buildFilters(v.TraceQuery.SpanName, v.TraceQuery.SpanKind, v.TraceQuery.AttributeQueries...),
+		FilterCombination: "AND",
+		Granularity:       granularity,
+		StartTime:         start.Unix(),
+		EndTime:           end.Unix(),
+	}
+
+	dataset := v.TraceQuery.ServiceName
+
+	// 1. Create the query
+	var query struct {
+		ID string `json:"id"`
+	}
+	if err := f.do(ctx, http.MethodPost, "/1/queries/"+dataset, spec, &query); err != nil {
+		return nil, fmt.Errorf("honeycomb fetcher failed to create query: %w", err)
+	}
+
+	// 2. Run it
+	var result queryResult
+	runReq := map[string]any{
+		"query_id":       query.ID,
+		"disable_series": false,
+	}
+	if err := f.do(ctx, http.MethodPost, "/1/query_results/"+dataset, runReq, &result); err != nil {
+		return nil, fmt.Errorf("honeycomb fetcher failed to run query: %w", err)
+	}
+
+	// 3. Poll until complete
+	for polls := 0; !result.Complete; polls++ {
+		if polls >= maxPolls {
+			return nil, fmt.Errorf("honeycomb query result %s did not complete after %d polls", result.ID, maxPolls)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+
+		if err := f.do(ctx, http.MethodGet, "/1/query_results/"+dataset+"/"+result.ID, nil, &result); err != nil {
+			return nil, fmt.Errorf("honeycomb fetcher failed to poll query result: %w", err)
+		}
+	}
+
+	// 4. Collect the series at its native timestamps
+	data := map[time.Time]float64{}
+	for _, point := range result.Data.Series {
+		val, ok := point.Data[key]
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, point.Time)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse time string '%s': %w", point.Time, err)
+		}
+		data[t.UTC()] = val
+	}
+
+	return data, nil
+}
+
+func (f *honeycombFetcher) buildCalculation(dimension string, aggregationOption string) (calculation, string) {
+	if dimension == "calls" {
+		return calculation{Op: "COUNT"}, "COUNT"
+	}
+
+	op := "AVG"
+	switch aggregationOption {
+	case "p50":
+		op = "P50"
+	case "p95":
+		op = "P95"
+	case "p99":
+		op = "P99"
+	}
+
+	return calculation{Op: op, Column: "duration_ms"}, fmt.Sprintf("%s(duration_ms)", op)
+}
+
+func (f *honeycombFetcher) buildFilters(spanName string, spanKind string, attributeQueries ...variable.AttributeQuery) []filter {
+	var filters []filter
+
+	if len(spanName) != 0 {
+		filters = append(filters, filter{Column: "name", Op: "=", Value: spanName})
+	}
+
+	if len(spanKind) != 0 {
+		filters = append(filters, filter{Column: "span.kind", Op: "=", Value: strings.ToLower(spanKind)})
+	}
+
+	for _, attributeQ := range attributeQueries {
+		if attributeQ.Key == "error" && attributeQ.Value == "true" {
+			filters = append(filters, filter{Column: "error", Op: "=", Value: true})
+			continue
+		}
+
+		switch attributeQ.Operator {
+		case "equals":
+			filters = append(filters, filter{Column: attributeQ.Key, Op: "=", Value: attributeQ.Value})
+		case "contains":
+			filters = append(filters, filter{Column: attributeQ.Key, Op: "contains", Value: attributeQ.Value})
+		case "isnotnull":
+			filters = append(filters, filter{Column: attributeQ.Key, Op: "exists"})
+		}
+	}
+
+	return filters
+}
+
+func (f *honeycombFetcher) do(ctx context.Context, method string, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(f.options.Location, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Honeycomb-Team", f.options.ApiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	bs, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d: %s", rsp.StatusCode, strings.TrimSpace(string(bs)))
+	}
+
+	return json.Unmarshal(bs, out)
+}
+
+func NewFetcher(opts ...fetcher.Option) fetcher.Fetcher {
+	options := fetcher.NewOptions(opts...)
+
+	// TODO: validate options
+
+	hf := &honeycombFetcher{
+		options: options,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+
+	return hf
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
@@ -11,11 +12,65 @@ import (
 type mockFetcher struct {
 	options     fetcher.Options
 	data        map[string]map[time.Time]float64
+	series      map[string][]fetcher.Series
+	entered     chan<- string
+	release     map[string]chan struct{}
+	errs        map[string]error
+	mtx         sync.Mutex
 	calledStart time.Time
+	inFlight    int
+	maxInFlight int
+	canceled    []string
+}
+
+// FetchAll returns the series from WithSeries, or else the one series that
+// Fetch would return.
+func (f *mockFetcher) FetchAll(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) ([]fetcher.Series, error) {
+	if series, ok := f.series[v.Name]; ok {
+		return series, nil
+	}
+
+	points, err := f.Fetch(ctx, v, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	return []fetcher.Series{{Labels: map[string]string{}, Points: points}}, nil
 }
 
 func (f *mockFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[time.Time]float64, error) {
+	f.mtx.Lock()
 	f.calledStart = start
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mtx.Unlock()
+
+	defer func() {
+		f.mtx.Lock()
+		f.inFlight--
+		f.mtx.Unlock()
+	}()
+
+	if f.entered != nil {
+		f.entered <- v.Name
+	}
+
+	if release, ok := f.release[v.Name]; ok {
+		select {
+		case <-ctx.Done():
+			f.mtx.Lock()
+			f.canceled = append(f.canceled, v.Name)
+			f.mtx.Unlock()
+			return nil, ctx.Err()
+		case <-release:
+		}
+	}
+
+	if err, ok := f.errs[v.Name]; ok {
+		return nil, err
+	}
 
 	if series, ok := f.data[v.Name]; ok {
 		return series, nil
@@ -25,9 +80,29 @@ func (f *mockFetcher) Fetch(ctx context.Context, v variable.VariableDefinition,
 }
 
 func (f *mockFetcher) CalledStart() time.Time {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
 	return f.calledStart
 }
 
+func (f *mockFetcher) InFlight() int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.inFlight
+}
+
+func (f *mockFetcher) MaxInFlight() int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.maxInFlight
+}
+
+func (f *mockFetcher) Canceled() []string {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.canceled
+}
+
 func NewFetcher(opts ...fetcher.Option) *mockFetcher {
 	options := fetcher.NewOptions(opts...)
 
@@ -42,5 +117,21 @@ func NewFetcher(opts ...fetcher.Option) *mockFetcher {
 		data:    data,
 	}
 
+	if s, ok := getSeriesFromCtx(options.Context); ok {
+		mf.series = s
+	}
+
+	if e, ok := getEnteredFromCtx(options.Context); ok {
+		mf.entered = e
+	}
+
+	if r, ok := getReleaseFromCtx(options.Context); ok {
+		mf.release = r
+	}
+
+	if e, ok := getErrorsFromCtx(options.Context); ok {
+		mf.errs = e
+	}
+
 	return mf
 }
@@ -8,6 +8,10 @@ import (
 )
 
 type dataKey struct{}
+type seriesKey struct{}
+type enteredKey struct{}
+type releaseKey struct{}
+type errorsKey struct{}
 
 func WithData(d map[string]map[time.Time]float64) fetcher.Option {
 	return func(o *fetcher.Options) {
@@ -15,7 +19,55 @@ func WithData(d map[string]map[time.Time]float64) fetcher.Option {
 	}
 }
 
+// WithSeries sets the series FetchAll returns for each variable.
+func WithSeries(s map[string][]fetcher.Series) fetcher.Option {
+	return func(o *fetcher.Options) {
+		o.Context = context.WithValue(o.Context, seriesKey{}, s)
+	}
+}
+
+// WithEntered sends the name of every variable on ch as its fetch starts.
+func WithEntered(ch chan<- string) fetcher.Option {
+	return func(o *fetcher.Options) {
+		o.Context = context.WithValue(o.Context, enteredKey{}, ch)
+	}
+}
+
+// WithRelease holds the fetch of each variable in release until its channel
+// is closed or the fetch is canceled.
+func WithRelease(release map[string]chan struct{}) fetcher.Option {
+	return func(o *fetcher.Options) {
+		o.Context = context.WithValue(o.Context, releaseKey{}, release)
+	}
+}
+
+func WithErrors(errs map[string]error) fetcher.Option {
+	return func(o *fetcher.Options) {
+		o.Context = context.WithValue(o.Context, errorsKey{}, errs)
+	}
+}
+
 func getDataFromCtx(ctx context.Context) (map[string]map[time.Time]float64, bool) {
 	d, ok := ctx.Value(dataKey{}).(map[string]map[time.Time]float64)
 	return d, ok
 }
+
+func getSeriesFromCtx(ctx context.Context) (map[string][]fetcher.Series, bool) {
+	s, ok := ctx.Value(seriesKey{}).(map[string][]fetcher.Series)
+	return s, ok
+}
+
+func getEnteredFromCtx(ctx context.Context) (chan<- string, bool) {
+	ch, ok := ctx.Value(enteredKey{}).(chan<- string)
+	return ch, ok
+}
+
+func getReleaseFromCtx(ctx context.Context) (map[string]chan struct{}, bool) {
+	r, ok := ctx.Value(releaseKey{}).(map[string]chan struct{})
+	return r, ok
+}
+
+func getErrorsFromCtx(ctx context.Context) (map[string]error, bool) {
+	e, ok := ctx.Value(errorsKey{}).(map[string]error)
+	return e, ok
+}
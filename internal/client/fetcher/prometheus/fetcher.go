@@ -18,13 +18,39 @@ type prometheusFetcher struct {
 }
 
 func (f *prometheusFetcher) Fetch(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[time.Time]float64, error) {
+	series, err := f.FetchAll(ctx, v, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(series) == 0 {
+		return map[time.Time]float64{}, nil
+	}
+	if len(series) > 1 {
+		return nil, fmt.Errorf("query returned %d series, expected 1 (set split_by to get one variable per series)", len(series))
+	}
+
+	return series[0].Points, nil
+}
+
+func (f *prometheusFetcher) FetchAll(ctx context.Context, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) ([]fetcher.Series, error) {
+	query := v.MetricsQuery
+	shift := time.Duration(0)
+
+	if v.Source.Type == "events" {
+		// every change of the series is an event, and the changes in the
+		// bucket [t, t+step) are what Prometheus evaluates at t+step
+		query = fmt.Sprintf("sum(changes(%s[%s]))", v.EventQuery.Query, model.Duration(step))
+		shift = step
+	}
+
 	r := v1.Range{
-		Start: start,
-		End:   end,
+		Start: start.Add(shift),
+		End:   end.Add(shift),
 		Step:  step,
 	}
 
-	val, _, err := f.api.QueryRange(ctx, v.MetricsQuery, r)
+	val, _, err := f.api.QueryRange(ctx, query, r)
 	if err != nil {
 		return nil, fmt.Errorf("prometheus query failed: %w", err)
 	}
@@ -34,19 +60,27 @@ func (f *prometheusFetcher) Fetch(ctx context.Context, v variable.VariableDefini
 		return nil, fmt.Errorf("expected prometheus Matrix response, got %T", val)
 	}
 
-	if len(matrix) == 0 {
-		return map[time.Time]float64{}, nil
-	}
-	if len(matrix) > 1 {
-		return nil, fmt.Errorf("query returned %d series, expected 1", len(matrix))
-	}
+	result := make([]fetcher.Series, len(matrix))
+
+	for i, stream := range matrix {
+		series := fetcher.Series{
+			Labels: map[string]string{},
+			Points: map[time.Time]float64{},
+		}
+
+		for name, value := range stream.Metric {
+			if name == model.MetricNameLabel {
+				continue
+			}
+			series.Labels[string(name)] = string(value)
+		}
 
-	result := map[time.Time]float64{}
-	stream := matrix[0]
+		for _, pair := range stream.Values {
+			t := pair.Timestamp.Time()
+			series.Points[t.UTC().Add(-shift)] = float64(pair.Value)
+		}
 
-	for _, pair := range stream.Values {
-		t := pair.Timestamp.Time()
-		result[t.UTC().Truncate(step)] = float64(pair.Value)
+		result[i] = series
 	}
 
 	return result, nil
@@ -0,0 +1,199 @@
+package bolt
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/jobstore"
+	bbolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	jobsBucket    = []byte("jobs")
+	resultsBucket = []byte("results")
+	logsBucket    = []byte("logs")
+)
+
+// boltStore keeps every job in a single file. Results live apart from the
+// jobs so that listing doesn't decode every graph ever found.
+type boltStore struct {
+	options jobstore.Options
+	db      *bbolt.DB
+}
+
+func (s *boltStore) Put(ctx context.Context, job *caus.Job) error {
+	meta := proto.Clone(job).(*caus.Job)
+	meta.Result = nil
+
+	bs, err := proto.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode job %s: %w", job.Id, err)
+	}
+
+	var result []byte
+	if job.Result != nil {
+		if result, err = proto.Marshal(&caus.Job{Result: job.Result}); err != nil {
+			return fmt.Errorf("failed to encode result of job %s: %w", job.Id, err)
+		}
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(jobsBucket).Put([]byte(job.Id), bs); err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+		return tx.Bucket(resultsBucket).Put([]byte(job.Id), result)
+	})
+}
+
+func (s *boltStore) Get(ctx context.Context, id string) (*caus.Job, error) {
+	job := &caus.Job{}
+	result := &caus.Job{}
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bs := tx.Bucket(jobsBucket).Get([]byte(id))
+		if bs == nil {
+			return jobstore.ErrNotFound
+		}
+		if err := proto.Unmarshal(bs, job); err != nil {
+			return fmt.Errorf("failed to decode job %s: %w", id, err)
+		}
+		if bs := tx.Bucket(resultsBucket).Get([]byte(id)); bs != nil {
+			if err := proto.Unmarshal(bs, result); err != nil {
+				return fmt.Errorf("failed to decode result of job %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	job.Result = result.Result
+
+	return job, nil
+}
+
+func (s *boltStore) List(ctx context.Context) ([]*caus.Job, error) {
+	var jobs []*caus.Job
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(jobsBucket).ForEach(func(k, v []byte) error {
+			job := &caus.Job{}
+			if err := proto.Unmarshal(v, job); err != nil {
+				return fmt.Errorf("failed to decode job %s: %w", k, err)
+			}
+			jobs = append(jobs, job)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(jobs, func(a, b *caus.Job) int {
+		return cmp.Compare(b.CreatedAt, a.CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+func (s *boltStore) AppendLog(ctx context.Context, id string, t time.Time, message string) (*caus.LogEntry, error) {
+	entry := &caus.LogEntry{Time: t.UnixMilli(), Message: message}
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		logs, err := tx.Bucket(logsBucket).CreateBucketIfNotExists([]byte(id))
+		if err != nil {
+			return err
+		}
+
+		if entry.Seq, err = logs.NextSequence(); err != nil {
+			return err
+		}
+
+		bs, err := proto.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		return logs.Put(binary.BigEndian.AppendUint64(nil, entry.Seq), bs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to append to the log of job %s: %w", id, err)
+	}
+
+	return entry, nil
+}
+
+func (s *boltStore) Logs(ctx context.Context, id string, after uint64) ([]*caus.LogEntry, error) {
+	var entries []*caus.LogEntry
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		logs := tx.Bucket(logsBucket).Bucket([]byte(id))
+		if logs == nil {
+			return nil
+		}
+
+		c := logs.Cursor()
+		for k, v := c.Seek(binary.BigEndian.AppendUint64(nil, after+1)); k != nil; k, v = c.Next() {
+			entry := &caus.LogEntry{}
+			if err := proto.Unmarshal(v, entry); err != nil {
+				return fmt.Errorf("failed to decode log entry %d of job %s: %w", binary.BigEndian.Uint64(k), id, err)
+			}
+			entries = append(entries, entry)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+// NewStore opens the store at the path given by jobstore.WithLocation,
+// creating it if needed. Only one process can hold it open.
+func NewStore(opts ...jobstore.Option) (jobstore.Store, error) {
+	options := jobstore.NewOptions(opts...)
+
+	if len(options.Location) == 0 {
+		return nil, errors.New("job store path is required")
+	}
+
+	db, err := bbolt.Open(options.Location, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job store '%s': %w", options.Location, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{jobsBucket, resultsBucket, logsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize job store '%s': %w", options.Location, err)
+	}
+
+	return &boltStore{
+		options: options,
+		db:      db,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package jobstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+)
+
+var ErrNotFound = errors.New("job not found")
+
+// Store keeps jobs, their results and their logs.
+type Store interface {
+	// Put creates or replaces the job with job.Id, along with its result if
+	// it has one.
+	Put(ctx context.Context, job *caus.Job) error
+	// Get returns the job with id and its result, or ErrNotFound.
+	Get(ctx context.Context, id string) (*caus.Job, error)
+	// List returns every job, newest first, without results.
+	List(ctx context.Context) ([]*caus.Job, error)
+	// AppendLog adds message to the log of the job with id.
+	AppendLog(ctx context.Context, id string, t time.Time, message string) (*caus.LogEntry, error)
+	// Logs returns the log entries of the job with id after seq after.
+	Logs(ctx context.Context, id string, after uint64) ([]*caus.LogEntry, error)
+	Close() error
+}
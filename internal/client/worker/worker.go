@@ -0,0 +1,210 @@
+// Package worker connects the discoverer and estimator clients to the Python
+// gRPC worker: transport security, bearer tokens, per-call deadlines and
+// retries are the same whichever API version the client speaks.
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// TLS configures transport security. Setting CAFile, CertFile or KeyFile
+// implies Enabled; CertFile and KeyFile together enable mutual TLS.
+type TLS struct {
+	Enabled bool
+	// CAFile verifies the worker instead of the system roots.
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+func (t TLS) enabled() bool {
+	return t.Enabled || len(t.CAFile) > 0 || len(t.CertFile) > 0 || len(t.KeyFile) > 0
+}
+
+// Retry retries calls that fail because the worker is unavailable, e.g.
+// while it restarts, waiting a jittered, doubling backoff in between.
+type Retry struct {
+	// Retries is the number of attempts after the first one.
+	Retries        int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (r Retry) Validate() error {
+	if r.Retries < 0 {
+		return errors.New("retries must not be negative")
+	}
+	if r.InitialBackoff < 0 || r.MaxBackoff < 0 {
+		return errors.New("backoff must not be negative")
+	}
+	if r.MaxBackoff > 0 && r.MaxBackoff < r.InitialBackoff {
+		return errors.New("max backoff must not be below the initial backoff")
+	}
+	return nil
+}
+
+// Dial connects to the worker at location. The connection is established
+// lazily, on the first call.
+func Dial(location string, t TLS, token string) (*grpc.ClientConn, error) {
+	if len(location) == 0 {
+		return nil, errors.New("worker address is required")
+	}
+
+	creds := insecure.NewCredentials()
+	if t.enabled() {
+		cfg, err := tlsConfig(t)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+	}
+
+	if len(token) > 0 {
+		insecureOK := loopback(location)
+		if !t.enabled() && !insecureOK {
+			return nil, fmt.Errorf("refusing to send the worker token to '%s' in cleartext: enable worker TLS or reach the worker over loopback", location)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(bearer{token: token, insecureOK: insecureOK}))
+	}
+
+	conn, err := grpc.NewClient(location, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker client for '%s': %w", location, err)
+	}
+
+	return conn, nil
+}
+
+func tlsConfig(t TLS) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(t.CAFile) > 0 {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read worker CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("worker CA '%s' has no PEM certificates", t.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (len(t.CertFile) > 0) != (len(t.KeyFile) > 0) {
+		return nil, errors.New("worker client cert and key must be set together")
+	}
+
+	if len(t.CertFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load worker client cert: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// bearer sends the token with every call. It insists on TLS unless the
+// worker is on this host, e.g. behind a TLS-terminating proxy reached over
+// loopback.
+type bearer struct {
+	token      string
+	insecureOK bool
+}
+
+func (b bearer) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearer) RequireTransportSecurity() bool {
+	return !b.insecureOK
+}
+
+// loopback reports whether the gRPC target location is on this host: a unix
+// socket, localhost or a loopback IP, with or without a resolver scheme such
+// as "dns:///".
+func loopback(location string) bool {
+	if strings.HasPrefix(location, "unix:") || strings.HasPrefix(location, "unix-abstract:") {
+		return true
+	}
+
+	if _, rest, ok := strings.Cut(location, "://"); ok {
+		// drop the authority, e.g. the DNS server of "dns://8.8.8.8/host:port"
+		_, location, _ = strings.Cut(rest, "/")
+	}
+
+	host, _, err := net.SplitHostPort(location)
+	if err != nil {
+		host = location
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Call runs fn with a deadline of timeout per attempt (none if <= 0) and
+// retries it as r says while the worker is unavailable.
+func Call(ctx context.Context, timeout time.Duration, r Retry, fn func(ctx context.Context) error) error {
+	backoff := r.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := attemptCall(ctx, timeout, fn)
+		if err == nil || attempt >= r.Retries || status.Code(err) != codes.Unavailable {
+			return err
+		}
+
+		// full jitter keeps many clients from retrying in lockstep
+		wait := time.Duration(0)
+		if backoff > 0 {
+			wait = time.Duration(rand.Int64N(int64(backoff)) + 1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if r.MaxBackoff > 0 && backoff > r.MaxBackoff {
+			backoff = r.MaxBackoff
+		}
+	}
+}
+
+func attemptCall(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return fn(callCtx)
+}
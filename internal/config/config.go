@@ -14,6 +14,11 @@ func LoadConfig(path string) (*variable.DiscoveryConfig, error) {
 		return nil, err
 	}
 
+	return ParseConfig(data)
+}
+
+// ParseConfig reads a vars.yml document, e.g. one sent to 'caus serve'.
+func ParseConfig(data []byte) (*variable.DiscoveryConfig, error) {
 	var cfg variable.DiscoveryConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, err
@@ -23,5 +28,40 @@ func LoadConfig(path string) (*variable.DiscoveryConfig, error) {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
 
+	if _, err := EvaluationOrder(cfg.Variables); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+
 	return &cfg, nil
 }
+
+// SourcesConfig lists the sources that 'caus serve' fetches from, with
+// their credentials.
+type SourcesConfig struct {
+	Sources []variable.Source `yaml:"sources"`
+}
+
+// LoadSources reads a sources file. Environment variables in it, e.g.
+// ${DD_API_KEY}, are expanded so that credentials can stay out of the file.
+func LoadSources(path string) ([]variable.Source, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg SourcesConfig
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &cfg); err != nil {
+		return nil, err
+	}
+
+	for i, s := range cfg.Sources {
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("source[%d] invalid: %w", i, err)
+		}
+		if s.Type == "derived" {
+			return nil, fmt.Errorf("source[%d] invalid: derived variables have no source", i)
+		}
+	}
+
+	return cfg.Sources, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/expr"
+)
+
+// EvaluationOrder lists the indexes of vars so that every derived variable
+// comes after the variables its expression references. It fails on
+// references to unknown variables and on cycles.
+func EvaluationOrder(vars []variable.VariableDefinition) ([]int, error) {
+	index := make(map[string]int, len(vars))
+	for i, v := range vars {
+		index[v.Name] = i
+	}
+
+	refs := make([][]int, len(vars))
+	for i, v := range vars {
+		if !v.Derived() {
+			continue
+		}
+		e, err := expr.Parse(v.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("variable '%s' has an invalid expression: %w", v.Name, err)
+		}
+		for _, name := range e.Refs() {
+			j, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("variable '%s' references unknown variable '%s'", v.Name, name)
+			}
+			if vars[j].Split() {
+				return nil, fmt.Errorf("variable '%s' references '%s', which is split into one variable per series", v.Name, name)
+			}
+			refs[i] = append(refs[i], j)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make([]int, len(vars))
+	order := make([]int, 0, len(vars))
+	var path []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			start := slices.Index(path, vars[i].Name)
+			cycle := append(slices.Clone(path[start:]), vars[i].Name)
+			return fmt.Errorf("derived variables form a cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[i] = visiting
+		path = append(path, vars[i].Name)
+		for _, j := range refs[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+		order = append(order, i)
+
+		return nil
+	}
+
+	for i := range vars {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+)
+
+// ParseTime accepts a duration ago ("2h"), an RFC3339 timestamp or unix
+// seconds.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+
+	return time.Time{}, fmt.Errorf("'%s' is not a duration ago, an RFC3339 timestamp or unix seconds", value)
+}
+
+// ParseExclusions turns values of the form 'start/end' into exclusions with
+// the given reason. Either side takes the forms of ParseTime.
+func ParseExclusions(specs []string, reason string) ([]variable.Exclusion, error) {
+	now := time.Now().UTC()
+
+	var exclusions []variable.Exclusion
+	for _, spec := range specs {
+		from, to, ok := strings.Cut(spec, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid exclusion '%s' (expected start/end)", spec)
+		}
+
+		start, err := ParseTime(from, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclusion start: %w", err)
+		}
+
+		end, err := ParseTime(to, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclusion end: %w", err)
+		}
+
+		e := variable.Exclusion{Start: start, End: end, Reason: reason}
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid exclusion '%s': %w", spec, err)
+		}
+
+		exclusions = append(exclusions, e)
+	}
+
+	return exclusions, nil
+}
@@ -0,0 +1,132 @@
+package dataset
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadCSV parses a header row followed by numeric rows. Empty cells and
+// cells that don't parse as numbers are treated as missing.
+func ReadCSV(r io.Reader) (*Dataset, error) {
+	records, err := readRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseColumns(records[0], records[1:]), nil
+}
+
+// ReadTimestampedCSV parses csv written by WriteCSV: a timestamp column of
+// RFC3339 times or unix seconds followed by numeric columns.
+func ReadTimestampedCSV(r io.Reader) (*Dataset, error) {
+	records, err := readRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header := records[0]
+	if strings.TrimSpace(header[0]) != TimestampColumn {
+		return nil, fmt.Errorf("csv data has no leading '%s' column", TimestampColumn)
+	}
+
+	rows := records[1:]
+	timestamps := make([]time.Time, len(rows))
+	for i, record := range rows {
+		ts, err := ParseTimestamp(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		timestamps[i] = ts
+		rows[i] = record[1:]
+	}
+
+	ds := parseColumns(header[1:], rows)
+	ds.Timestamps = timestamps
+
+	return ds, nil
+}
+
+func readRecords(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv data: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("csv data is empty")
+	}
+
+	return records, nil
+}
+
+func parseColumns(header []string, rows [][]string) *Dataset {
+	ds := &Dataset{
+		Columns: make([]Column, len(header)),
+	}
+	for i, name := range header {
+		ds.Columns[i] = Column{Name: strings.TrimSpace(name), Values: make([]float64, 0, len(rows))}
+	}
+
+	for _, record := range rows {
+		for i := range ds.Columns {
+			val, err := strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+			if err != nil {
+				val = math.NaN()
+			}
+			ds.Columns[i].Values = append(ds.Columns[i].Values, val)
+		}
+	}
+
+	return ds
+}
+
+// ParseTimestamp reads an RFC3339 timestamp or unix seconds.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if ts, err := time.Parse(time.RFC3339, s); err == nil {
+		return ts.UTC(), nil
+	}
+
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp '%s' (expected RFC3339 or unix seconds)", s)
+}
+
+// WriteCSV writes a timestamp column followed by one column per variable.
+// Missing values are left empty.
+func WriteCSV(w io.Writer, d *Dataset) error {
+	writer := csv.NewWriter(w)
+
+	header := append([]string{TimestampColumn}, d.Names()...)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for t, ts := range d.Timestamps {
+		row := make([]string, 0, len(header))
+		row = append(row, ts.UTC().Format(time.RFC3339))
+		for _, c := range d.Columns {
+			if Missing(c.Values[t]) {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(c.Values[t], 'g', -1, 64))
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,136 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Dataset is a set of equally long, step-aligned columns. A NaN value marks
+// a missing observation.
+type Dataset struct {
+	Timestamps []time.Time
+	Step       time.Duration
+	Columns    []Column
+	// Breaks are the rows where an excluded window starts or ends, each
+	// starting a new run. No lagged row may pair a value from before a
+	// break with one from at or after it.
+	Breaks []int
+}
+
+type Column struct {
+	Name       string
+	Values     []float64
+	Provenance Provenance
+}
+
+// Provenance records where a column's data came from.
+type Provenance struct {
+	Type  string `json:"type,omitempty"`
+	Impl  string `json:"impl,omitempty"`
+	Loc   string `json:"loc,omitempty"`
+	Query string `json:"query,omitempty"`
+	Fill  string `json:"fill,omitempty"`
+	// Resample is how the fetched points were brought onto the step grid.
+	Resample string `json:"resample,omitempty"`
+	// Series identifies the series of a split query, e.g. "pod=web-1".
+	Series string `json:"series,omitempty"`
+	// Variable names the split variable the series belongs to.
+	Variable string `json:"variable,omitempty"`
+	// Transforms applied after filling, in order.
+	Transforms []string `json:"transforms,omitempty"`
+}
+
+func (d *Dataset) Len() int {
+	if len(d.Columns) == 0 {
+		return len(d.Timestamps)
+	}
+	return len(d.Columns[0].Values)
+}
+
+func (d *Dataset) Names() []string {
+	names := make([]string, len(d.Columns))
+	for i, c := range d.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func (d *Dataset) Index(name string) int {
+	for i, c := range d.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Crosses reports whether row t, looking back lag steps, reaches across a
+// break.
+func (d *Dataset) Crosses(t int, lag int) bool {
+	for _, b := range d.Breaks {
+		if t-lag < b && b <= t {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs splits the rows at the breaks into consecutive [start, end) ranges.
+func (d *Dataset) Runs() [][2]int {
+	var runs [][2]int
+
+	start := 0
+	for _, b := range d.Breaks {
+		if b <= start || b >= d.Len() {
+			continue
+		}
+		runs = append(runs, [2]int{start, b})
+		start = b
+	}
+
+	return append(runs, [2]int{start, d.Len()})
+}
+
+// SeriesOf lists the columns holding the series of the split variable name.
+func (d *Dataset) SeriesOf(name string) []string {
+	var names []string
+	for _, c := range d.Columns {
+		if len(c.Provenance.Series) > 0 && c.Provenance.Variable == name {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// Select returns a dataset with only the named columns, in that order.
+func (d *Dataset) Select(names []string) (*Dataset, error) {
+	selected := &Dataset{
+		Timestamps: d.Timestamps,
+		Step:       d.Step,
+		Columns:    make([]Column, 0, len(names)),
+		Breaks:     d.Breaks,
+	}
+
+	var missing []string
+	for _, name := range names {
+		idx := d.Index(name)
+		if idx < 0 {
+			missing = append(missing, name)
+			continue
+		}
+		selected.Columns = append(selected.Columns, d.Columns[idx])
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("dataset has no column for: %s", strings.Join(missing, ", "))
+	}
+
+	return selected, nil
+}
+
+// Missing reports whether v marks a missing observation.
+func Missing(v float64) bool {
+	return math.IsNaN(v)
+}
@@ -0,0 +1,100 @@
+package dataset
+
+import "math"
+
+// FillForward carries the last observation forward over runs of at most
+// maxGap missing values (zero means no limit). Leading missing values and
+// longer runs are left missing.
+func FillForward(values []float64, maxGap int) []float64 {
+	filled := make([]float64, len(values))
+	copy(filled, values)
+
+	for _, g := range Gaps(values) {
+		if g.Start == 0 || (maxGap > 0 && g.Len() > maxGap) {
+			continue
+		}
+		for t := g.Start; t < g.End; t++ {
+			filled[t] = values[g.Start-1]
+		}
+	}
+
+	return filled
+}
+
+// FillLinear interpolates across interior runs of at most maxGap missing
+// values (zero means no limit). Leading and trailing missing values and
+// longer runs are left missing.
+func FillLinear(values []float64, maxGap int) []float64 {
+	filled := make([]float64, len(values))
+	copy(filled, values)
+
+	for _, g := range Gaps(values) {
+		if g.Start == 0 || g.End == len(values) || (maxGap > 0 && g.Len() > maxGap) {
+			continue
+		}
+		lo, hi := values[g.Start-1], values[g.End]
+		span := float64(g.Len() + 1)
+		for t := g.Start; t < g.End; t++ {
+			filled[t] = lo + (hi-lo)*float64(t-g.Start+1)/span
+		}
+	}
+
+	return filled
+}
+
+// FillConstant replaces every missing value with c.
+func FillConstant(values []float64, c float64) []float64 {
+	filled := make([]float64, len(values))
+	for t, v := range values {
+		if Missing(v) {
+			v = c
+		}
+		filled[t] = v
+	}
+	return filled
+}
+
+// Mean is the mean of the observed values, or NaN if there are none.
+func Mean(values []float64) float64 {
+	sum, n := 0.0, 0
+	for _, v := range values {
+		if Missing(v) {
+			continue
+		}
+		sum += v
+		n++
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum / float64(n)
+}
+
+// Gap is a run of missing values in [Start, End).
+type Gap struct {
+	Start int
+	End   int
+}
+
+func (g Gap) Len() int {
+	return g.End - g.Start
+}
+
+// Gaps lists the runs of missing values in order.
+func Gaps(values []float64) []Gap {
+	var gaps []Gap
+
+	for t := 0; t < len(values); t++ {
+		if !Missing(values[t]) {
+			continue
+		}
+		g := Gap{Start: t}
+		for t < len(values) && Missing(values[t]) {
+			t++
+		}
+		g.End = t
+		gaps = append(gaps, g)
+	}
+
+	return gaps
+}
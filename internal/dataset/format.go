@@ -0,0 +1,62 @@
+package dataset
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// TimestampColumn is the name of the time column in exported datasets.
+const TimestampColumn = "timestamp"
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+	FormatJSONL   Format = "jsonl"
+)
+
+var SupportedFormats = []Format{FormatCSV, FormatParquet, FormatJSONL}
+
+// FormatFromPath infers the format from a file extension, defaulting to csv.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".parquet", ".pq":
+		return FormatParquet
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	default:
+		return FormatCSV
+	}
+}
+
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(s))
+	if !slices.Contains(SupportedFormats, f) {
+		return "", fmt.Errorf("unsupported format '%s' (supported: %v)", s, SupportedFormats)
+	}
+	return f, nil
+}
+
+// Write encodes d in the given format. Parquet embeds the metadata; csv and
+// jsonl carry only the data so callers should write it alongside with
+// WriteMetadata.
+func Write(w io.Writer, d *Dataset, format Format) error {
+	if d.Index(TimestampColumn) >= 0 {
+		return fmt.Errorf("variable name '%s' is reserved for the time column", TimestampColumn)
+	}
+
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, d)
+	case FormatParquet:
+		return WriteParquet(w, d)
+	case FormatJSONL:
+		return WriteJSONL(w, d)
+	default:
+		return fmt.Errorf("unsupported format '%s' (supported: %v)", format, SupportedFormats)
+	}
+}
@@ -0,0 +1,130 @@
+package dataset
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// WriteJSONL writes one object per timestamp with the columns in dataset
+// order. Missing values are null since json has no NaN.
+func WriteJSONL(w io.Writer, d *Dataset) error {
+	bw := bufio.NewWriter(w)
+
+	keys := make([][]byte, len(d.Columns))
+	for i, c := range d.Columns {
+		key, err := json.Marshal(c.Name)
+		if err != nil {
+			return fmt.Errorf("failed to encode column name '%s': %w", c.Name, err)
+		}
+		keys[i] = key
+	}
+
+	for t, ts := range d.Timestamps {
+		line := []byte(`{"` + TimestampColumn + `":"` + ts.UTC().Format(time.RFC3339) + `"`)
+		for i, c := range d.Columns {
+			line = append(line, ',')
+			line = append(line, keys[i]...)
+			line = append(line, ':')
+			if Missing(c.Values[t]) || math.IsInf(c.Values[t], 0) {
+				line = append(line, "null"...)
+			} else {
+				line = strconv.AppendFloat(line, c.Values[t], 'g', -1, 64)
+			}
+		}
+		line = append(line, '}', '\n')
+
+		if _, err := bw.Write(line); err != nil {
+			return fmt.Errorf("failed to write jsonl row: %w", err)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadJSONL parses objects written by WriteJSONL. The key order of the first
+// object fixes the column order; a key missing from a later object is a
+// missing value.
+func ReadJSONL(r io.Reader) (*Dataset, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	ds := &Dataset{}
+
+	for row := 0; ; row++ {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jsonl data: %w", err)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+			return nil, fmt.Errorf("row %d: expected an object", row+1)
+		}
+
+		for i := range ds.Columns {
+			ds.Columns[i].Values = append(ds.Columns[i].Values, math.NaN())
+		}
+
+		seenTimestamp := false
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", row+1, err)
+			}
+			key := tok.(string)
+
+			var raw any
+			if err := dec.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("row %d: %w", row+1, err)
+			}
+
+			if key == TimestampColumn {
+				str, ok := raw.(string)
+				if !ok {
+					str = fmt.Sprint(raw)
+				}
+				ts, err := ParseTimestamp(str)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %w", row+1, err)
+				}
+				ds.Timestamps = append(ds.Timestamps, ts)
+				seenTimestamp = true
+				continue
+			}
+
+			idx := ds.Index(key)
+			if idx < 0 {
+				if row > 0 {
+					return nil, fmt.Errorf("row %d: unknown column '%s'", row+1, key)
+				}
+				ds.Columns = append(ds.Columns, Column{Name: key, Values: []float64{math.NaN()}})
+				idx = len(ds.Columns) - 1
+			}
+
+			if num, ok := raw.(json.Number); ok {
+				val, err := num.Float64()
+				if err != nil {
+					return nil, fmt.Errorf("row %d: column '%s': %w", row+1, key, err)
+				}
+				ds.Columns[idx].Values[row] = val
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			return nil, fmt.Errorf("row %d: %w", row+1, err)
+		}
+
+		if !seenTimestamp {
+			return nil, fmt.Errorf("row %d: no '%s'", row+1, TimestampColumn)
+		}
+	}
+
+	return ds, nil
+}
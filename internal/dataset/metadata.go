@@ -0,0 +1,62 @@
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Metadata describes an exported dataset: the window it covers, where each
+// column came from and where excluded windows break it into runs.
+type Metadata struct {
+	Start   time.Time        `json:"start"`
+	End     time.Time        `json:"end"`
+	Step    string           `json:"step"`
+	Columns []ColumnMetadata `json:"columns"`
+	// Breaks are the timestamps of the rows where an excluded window starts
+	// or ends.
+	Breaks []time.Time `json:"breaks,omitempty"`
+}
+
+type ColumnMetadata struct {
+	Name string `json:"name"`
+	Provenance
+}
+
+func (d *Dataset) Metadata() Metadata {
+	m := Metadata{
+		Step:    d.Step.String(),
+		Columns: make([]ColumnMetadata, len(d.Columns)),
+	}
+
+	if len(d.Timestamps) > 0 {
+		m.Start = d.Timestamps[0]
+		m.End = d.Timestamps[len(d.Timestamps)-1]
+	}
+
+	for i, c := range d.Columns {
+		m.Columns[i] = ColumnMetadata{Name: c.Name, Provenance: c.Provenance}
+	}
+
+	for _, b := range d.Breaks {
+		if b < len(d.Timestamps) {
+			m.Breaks = append(m.Breaks, d.Timestamps[b])
+		}
+	}
+
+	return m
+}
+
+func WriteMetadata(w io.Writer, d *Dataset) error {
+	bs, err := json.MarshalIndent(d.Metadata(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	if _, err := w.Write(append(bs, '\n')); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package dataset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// MetadataKey is the parquet key-value metadata entry holding the json
+// encoded Metadata.
+const MetadataKey = "caus.metadata"
+
+// WriteParquet writes a millisecond timestamp column and one optional double
+// column per variable, with missing values as nulls and the Metadata
+// embedded in the file footer.
+func WriteParquet(w io.Writer, d *Dataset) error {
+	group := parquet.Group{
+		TimestampColumn: parquet.Timestamp(parquet.Millisecond),
+	}
+	for _, c := range d.Columns {
+		group[c.Name] = parquet.Optional(parquet.Leaf(parquet.DoubleType))
+	}
+	schema := parquet.NewSchema("caus", group)
+
+	meta, err := json.Marshal(d.Metadata())
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	writer := parquet.NewWriter(w, schema, parquet.KeyValueMetadata(MetadataKey, string(meta)))
+
+	// parquet orders the columns of a group by name
+	leaf := map[string]int{}
+	for i, path := range schema.Columns() {
+		leaf[path[0]] = i
+	}
+
+	rows := make([]parquet.Row, len(d.Timestamps))
+	for t, ts := range d.Timestamps {
+		row := make(parquet.Row, len(leaf))
+		row[leaf[TimestampColumn]] = parquet.Int64Value(ts.UnixMilli()).Level(0, 0, leaf[TimestampColumn])
+		for _, c := range d.Columns {
+			idx := leaf[c.Name]
+			if Missing(c.Values[t]) {
+				row[idx] = parquet.NullValue().Level(0, 0, idx)
+				continue
+			}
+			row[idx] = parquet.DoubleValue(c.Values[t]).Level(0, 1, idx)
+		}
+		rows[t] = row
+	}
+
+	if _, err := writer.WriteRows(rows); err != nil {
+		return fmt.Errorf("failed to write parquet rows: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close parquet writer: %w", err)
+	}
+
+	return nil
+}
+
+// readParquetFile reads a file written by WriteParquet, or any flat parquet
+// file with a timestamp column and numeric columns. Columns follow the
+// embedded metadata's order when there is one.
+func readParquetFile(path string) (*Dataset, *Metadata, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+
+	pf, err := parquet.OpenFile(f, info.Size())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read parquet data: %w", err)
+	}
+
+	var meta *Metadata
+	if raw, ok := pf.Lookup(MetadataKey); ok {
+		meta = &Metadata{}
+		if err := json.Unmarshal([]byte(raw), meta); err != nil {
+			return nil, nil, fmt.Errorf("invalid dataset metadata: %w", err)
+		}
+	}
+
+	schema := pf.Schema()
+
+	tsLeaf, ok := schema.Lookup(TimestampColumn)
+	if !ok {
+		return nil, nil, fmt.Errorf("parquet data has no '%s' column", TimestampColumn)
+	}
+	unit := time.Millisecond
+	if lt := tsLeaf.Node.Type().LogicalType(); lt != nil {
+		ts, _ := lt.Value.(*format.TimestampType)
+		if ts == nil {
+			return nil, nil, fmt.Errorf("parquet column '%s' is not a timestamp", TimestampColumn)
+		}
+		switch ts.Unit.Value.(type) {
+		case *format.MicroSeconds:
+			unit = time.Microsecond
+		case *format.NanoSeconds:
+			unit = time.Nanosecond
+		}
+	}
+
+	var names []string
+	if meta != nil {
+		for _, c := range meta.Columns {
+			names = append(names, c.Name)
+		}
+	} else {
+		for _, path := range schema.Columns() {
+			if path[0] != TimestampColumn {
+				names = append(names, path[0])
+			}
+		}
+	}
+
+	ds := &Dataset{Columns: make([]Column, len(names))}
+	leaves := make([]int, len(names))
+	for i, name := range names {
+		leaf, ok := schema.Lookup(name)
+		if !ok {
+			return nil, nil, fmt.Errorf("parquet data has no column '%s'", name)
+		}
+		leaves[i] = leaf.ColumnIndex
+		ds.Columns[i] = Column{Name: name}
+	}
+
+	reader := parquet.NewReader(pf)
+	defer reader.Close()
+
+	rows := make([]parquet.Row, 256)
+	for {
+		n, err := reader.ReadRows(rows)
+		for _, row := range rows[:n] {
+			ts := row[tsLeaf.ColumnIndex]
+			if ts.IsNull() {
+				return nil, nil, fmt.Errorf("parquet data has a null timestamp")
+			}
+			ds.Timestamps = append(ds.Timestamps, time.Unix(0, ts.Int64()*int64(unit)).UTC())
+
+			for i, leaf := range leaves {
+				ds.Columns[i].Values = append(ds.Columns[i].Values, parquetFloat(row[leaf]))
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read parquet rows: %w", err)
+		}
+	}
+
+	return ds, meta, nil
+}
+
+func parquetFloat(v parquet.Value) float64 {
+	switch v.Kind() {
+	case parquet.Double:
+		return v.Double()
+	case parquet.Float:
+		return float64(v.Float())
+	case parquet.Int32:
+		return float64(v.Int32())
+	case parquet.Int64:
+		return float64(v.Int64())
+	default:
+		return math.NaN()
+	}
+}
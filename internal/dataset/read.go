@@ -0,0 +1,123 @@
+package dataset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+)
+
+// ReadFile loads a dataset in any of the formats Write produces, picking the
+// format from the extension. The provenance and step come from the embedded
+// or sidecar metadata when there is one, otherwise the step is inferred from
+// the timestamps.
+func ReadFile(path string) (*Dataset, error) {
+	var (
+		ds   *Dataset
+		meta *Metadata
+		err  error
+	)
+
+	switch FormatFromPath(path) {
+	case FormatParquet:
+		ds, meta, err = readParquetFile(path)
+	case FormatJSONL:
+		ds, err = readFileWith(path, ReadJSONL)
+	default:
+		ds, err = readFileWith(path, ReadTimestampedCSV)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if meta == nil {
+		meta, err = readSidecar(path + ".meta.json")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ds.check(); err != nil {
+		return nil, fmt.Errorf("invalid dataset %s: %w", path, err)
+	}
+
+	ds.apply(meta)
+
+	return ds, nil
+}
+
+func readFileWith(path string, read func(r io.Reader) (*Dataset, error)) (*Dataset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer f.Close()
+
+	return read(f)
+}
+
+func readSidecar(path string) (*Metadata, error) {
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset metadata: %w", err)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(bs, &meta); err != nil {
+		return nil, fmt.Errorf("invalid dataset metadata %s: %w", path, err)
+	}
+
+	return &meta, nil
+}
+
+// check makes sure the rows are evenly spaced and every column is complete.
+func (d *Dataset) check() error {
+	if len(d.Timestamps) == 0 {
+		return fmt.Errorf("no rows")
+	}
+
+	for _, c := range d.Columns {
+		if len(c.Values) != len(d.Timestamps) {
+			return fmt.Errorf("column '%s' has %d values for %d timestamps", c.Name, len(c.Values), len(d.Timestamps))
+		}
+	}
+
+	for t := 2; t < len(d.Timestamps); t++ {
+		if d.Timestamps[t].Sub(d.Timestamps[t-1]) != d.Timestamps[1].Sub(d.Timestamps[0]) {
+			return fmt.Errorf("timestamps are not evenly spaced at %s", d.Timestamps[t].Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// apply copies the step, provenance and breaks from meta, falling back to
+// the spacing of the timestamps for the step.
+func (d *Dataset) apply(meta *Metadata) {
+	if meta != nil {
+		if step, err := time.ParseDuration(meta.Step); err == nil {
+			d.Step = step
+		}
+		for _, cm := range meta.Columns {
+			if i := d.Index(cm.Name); i >= 0 {
+				d.Columns[i].Provenance = cm.Provenance
+			}
+		}
+		for _, b := range meta.Breaks {
+			if t := slices.IndexFunc(d.Timestamps, b.Equal); t >= 0 {
+				d.Breaks = append(d.Breaks, t)
+			}
+		}
+	}
+
+	if d.Step == 0 && len(d.Timestamps) > 1 {
+		d.Step = d.Timestamps[1].Sub(d.Timestamps[0])
+	}
+}
@@ -0,0 +1,159 @@
+package dataset
+
+import (
+	"math"
+	"time"
+
+	"github.com/w-h-a/caus/internal/stats"
+)
+
+// The transforms below return a new slice and never modify their input. A
+// missing input, or one the transform is undefined for, gives a missing
+// output.
+
+// Diff is the change from lag steps earlier. The first lag values are
+// missing.
+func Diff(values []float64, lag int) []float64 {
+	out := missing(len(values))
+	for t := lag; t < len(values); t++ {
+		out[t] = values[t] - values[t-lag]
+	}
+	return out
+}
+
+// Derivative is the per-second change of a gauge sampled every step.
+func Derivative(values []float64, step time.Duration) []float64 {
+	out := Diff(values, 1)
+	for t := range out {
+		out[t] /= step.Seconds()
+	}
+	return out
+}
+
+// Rate is the per-second increase of a monotonic counter sampled every
+// step. A decrease is taken as a counter reset, so the increase is the new
+// value itself.
+func Rate(values []float64, step time.Duration) []float64 {
+	out := missing(len(values))
+	for t := 1; t < len(values); t++ {
+		inc := values[t] - values[t-1]
+		if inc < 0 {
+			inc = values[t]
+		}
+		out[t] = inc / step.Seconds()
+	}
+	return out
+}
+
+// Log is the natural logarithm, missing for values <= 0.
+func Log(values []float64) []float64 {
+	out := missing(len(values))
+	for t, v := range values {
+		if v > 0 {
+			out[t] = math.Log(v)
+		}
+	}
+	return out
+}
+
+// Log1p is log(1 + v), missing for values <= -1.
+func Log1p(values []float64) []float64 {
+	out := missing(len(values))
+	for t, v := range values {
+		if v > -1 {
+			out[t] = math.Log1p(v)
+		}
+	}
+	return out
+}
+
+// ZScore standardizes values to zero mean and unit variance. A series with
+// no variance becomes all zeros.
+func ZScore(values []float64) []float64 {
+	mean := Mean(values)
+
+	ss, n := 0.0, 0
+	for _, v := range values {
+		if Missing(v) {
+			continue
+		}
+		ss += (v - mean) * (v - mean)
+		n++
+	}
+
+	std := 0.0
+	if n > 1 {
+		std = math.Sqrt(ss / float64(n-1))
+	}
+
+	out := make([]float64, len(values))
+	for t, v := range values {
+		switch {
+		case Missing(v):
+			out[t] = math.NaN()
+		case std == 0:
+			out[t] = 0
+		default:
+			out[t] = (v - mean) / std
+		}
+	}
+	return out
+}
+
+// RollingMean is the mean of the observed values in the trailing window of
+// the given number of steps. The first window-1 values are missing.
+func RollingMean(values []float64, window int) []float64 {
+	return rolling(values, window, func(xs []float64) float64 {
+		return Mean(xs)
+	})
+}
+
+// RollingMedian is the median of the observed values in the trailing window
+// of the given number of steps. The first window-1 values are missing.
+func RollingMedian(values []float64, window int) []float64 {
+	return rolling(values, window, func(xs []float64) float64 {
+		return stats.Median(observed(xs))
+	})
+}
+
+// Winsorize clips values to their lower and upper quantiles.
+func Winsorize(values []float64, lower float64, upper float64) []float64 {
+	obs := observed(values)
+	lo, hi := stats.Quantile(obs, lower), stats.Quantile(obs, upper)
+
+	out := make([]float64, len(values))
+	for t, v := range values {
+		if Missing(v) {
+			out[t] = v
+			continue
+		}
+		out[t] = math.Min(math.Max(v, lo), hi)
+	}
+	return out
+}
+
+func rolling(values []float64, window int, agg func([]float64) float64) []float64 {
+	out := missing(len(values))
+	for t := window - 1; t < len(values); t++ {
+		out[t] = agg(values[t-window+1 : t+1])
+	}
+	return out
+}
+
+func observed(values []float64) []float64 {
+	var obs []float64
+	for _, v := range values {
+		if !Missing(v) {
+			obs = append(obs, v)
+		}
+	}
+	return obs
+}
+
+func missing(n int) []float64 {
+	out := make([]float64, n)
+	for t := range out {
+		out[t] = math.NaN()
+	}
+	return out
+}
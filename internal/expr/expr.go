@@ -0,0 +1,121 @@
+// Package expr parses and evaluates the arithmetic expressions of derived
+// variables, e.g. "errors / calls" or "max(cpu_used / cpu_limit, 0)".
+package expr
+
+import (
+	"math"
+	"slices"
+)
+
+// Expr is a parsed expression.
+type Expr interface {
+	// Eval evaluates the expression with the values lookup returns for each
+	// referenced variable. Division by zero gives NaN.
+	Eval(lookup func(name string) float64) float64
+	// Refs lists the referenced variables in order of first appearance.
+	Refs() []string
+}
+
+type number float64
+
+func (n number) Eval(func(string) float64) float64 {
+	return float64(n)
+}
+
+func (n number) Refs() []string {
+	return nil
+}
+
+type ref string
+
+func (r ref) Eval(lookup func(string) float64) float64 {
+	return lookup(string(r))
+}
+
+func (r ref) Refs() []string {
+	return []string{string(r)}
+}
+
+type negate struct {
+	x Expr
+}
+
+func (n negate) Eval(lookup func(string) float64) float64 {
+	return -n.x.Eval(lookup)
+}
+
+func (n negate) Refs() []string {
+	return n.x.Refs()
+}
+
+type binary struct {
+	op   byte
+	x, y Expr
+}
+
+func (b binary) Eval(lookup func(string) float64) float64 {
+	x, y := b.x.Eval(lookup), b.y.Eval(lookup)
+	switch b.op {
+	case '+':
+		return x + y
+	case '-':
+		return x - y
+	case '*':
+		return x * y
+	case '/':
+		if y == 0 {
+			return math.NaN()
+		}
+		return x / y
+	}
+	return math.NaN()
+}
+
+func (b binary) Refs() []string {
+	return merge(b.x.Refs(), b.y.Refs())
+}
+
+type call struct {
+	fn   string
+	args []Expr
+}
+
+// functions maps each supported function to the number of arguments it
+// takes (a zero maxArgs means no limit) and its implementation.
+var functions = map[string]struct {
+	minArgs int
+	maxArgs int
+	apply   func([]float64) float64
+}{
+	"abs": {1, 1, func(xs []float64) float64 { return math.Abs(xs[0]) }},
+	"min": {1, 0, func(xs []float64) float64 { return slices.Min(xs) }},
+	"max": {1, 0, func(xs []float64) float64 { return slices.Max(xs) }},
+}
+
+func (c call) Eval(lookup func(string) float64) float64 {
+	xs := make([]float64, len(c.args))
+	for i, a := range c.args {
+		xs[i] = a.Eval(lookup)
+		if math.IsNaN(xs[i]) {
+			return math.NaN()
+		}
+	}
+	return functions[c.fn].apply(xs)
+}
+
+func (c call) Refs() []string {
+	var refs []string
+	for _, a := range c.args {
+		refs = merge(refs, a.Refs())
+	}
+	return refs
+}
+
+func merge(a []string, b []string) []string {
+	for _, r := range b {
+		if !slices.Contains(a, r) {
+			a = append(a, r)
+		}
+	}
+	return a
+}
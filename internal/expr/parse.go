@@ -0,0 +1,222 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// Parse parses an expression of numbers, variable names, the operators
+// + - * / with the usual precedence, parentheses and the functions abs, min
+// and max. Variable names consist of letters, digits, '_' and '.', and don't
+// start with a digit.
+func Parse(s string) (Expr, error) {
+	p := &parser{src: s}
+	p.next()
+
+	e, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected()
+	}
+
+	return e, nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+// next scans the next token into p.tok.
+func (p *parser) next() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := rune(p.src[p.pos])
+	switch {
+	case unicode.IsDigit(c) || c == '.':
+		p.skip(func(c byte) bool { return c >= '0' && c <= '9' || c == '.' })
+		// exponent, e.g. 1e-3
+		if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+			p.pos++
+			if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+				p.pos++
+			}
+			p.skip(func(c byte) bool { return c >= '0' && c <= '9' })
+		}
+		p.tok = token{kind: tokNumber, text: p.src[start:p.pos], pos: start}
+	case unicode.IsLetter(c) || c == '_':
+		p.skip(func(c byte) bool { return isIdent(rune(c)) })
+		p.tok = token{kind: tokIdent, text: p.src[start:p.pos], pos: start}
+	default:
+		p.pos++
+		p.tok = token{kind: tokOp, text: string(c), pos: start}
+	}
+}
+
+func (p *parser) skip(match func(byte) bool) {
+	for p.pos < len(p.src) && match(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func isIdent(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.'
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return fmt.Errorf("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected '%s' at position %d", p.tok.text, p.tok.pos+1)
+}
+
+func (p *parser) is(op string) bool {
+	return p.tok.kind == tokOp && p.tok.text == op
+}
+
+// sum := product (('+' | '-') product)*
+func (p *parser) sum() (Expr, error) {
+	x, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.is("+") || p.is("-") {
+		op := p.tok.text[0]
+		p.next()
+		y, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+
+	return x, nil
+}
+
+// product := unary (('*' | '/') unary)*
+func (p *parser) product() (Expr, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.is("*") || p.is("/") {
+		op := p.tok.text[0]
+		p.next()
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: op, x: x, y: y}
+	}
+
+	return x, nil
+}
+
+// unary := '-' unary | primary
+func (p *parser) unary() (Expr, error) {
+	if p.is("-") {
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return negate{x: x}, nil
+	}
+
+	return p.primary()
+}
+
+// primary := number | name | name '(' sum (',' sum)* ')' | '(' sum ')'
+func (p *parser) primary() (Expr, error) {
+	switch {
+	case p.tok.kind == tokNumber:
+		v, err := strconv.ParseFloat(p.tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", p.tok.text, p.tok.pos+1)
+		}
+		p.next()
+		return number(v), nil
+	case p.tok.kind == tokIdent:
+		name, pos := p.tok.text, p.tok.pos
+		p.next()
+		if !p.is("(") {
+			return ref(name), nil
+		}
+		return p.call(name, pos)
+	case p.is("("):
+		p.next()
+		x, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if !p.is(")") {
+			return nil, p.unexpected()
+		}
+		p.next()
+		return x, nil
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+func (p *parser) call(name string, pos int) (Expr, error) {
+	fn, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function '%s' at position %d (supported: abs, min, max)", name, pos+1)
+	}
+
+	// consume '('
+	p.next()
+
+	var args []Expr
+	for !p.is(")") {
+		if len(args) > 0 {
+			if !p.is(",") {
+				return nil, p.unexpected()
+			}
+			p.next()
+		}
+		a, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+	}
+	p.next()
+
+	if len(args) < fn.minArgs || (fn.maxArgs > 0 && len(args) > fn.maxArgs) {
+		return nil, fmt.Errorf("wrong number of arguments to %s at position %d", name, pos+1)
+	}
+
+	return call{fn: name, args: args}, nil
+}
@@ -0,0 +1,58 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/w-h-a/caus/internal/client/discoverer"
+)
+
+// writeDOT draws v for Graphviz, left to right. Unoriented edges are dashed,
+// and conflicting ones red as well, so that they stand out from the edges
+// that can be estimated.
+func writeDOT(w io.Writer, v view) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph caus {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	if len(v.title) > 0 {
+		fmt.Fprintf(bw, "  label=%s;\n", dotQuote(v.title))
+		fmt.Fprintln(bw, "  labelloc=t;")
+	}
+	fmt.Fprintln(bw, "  node [shape=box, style=rounded];")
+	fmt.Fprintln(bw, "  edge [fontsize=10];")
+
+	for _, n := range v.nodes {
+		fmt.Fprintf(bw, "  %s [label=%s];\n", n.id, dotQuote(strings.Join(n.lines(), "\n")))
+	}
+
+	for _, e := range v.edges {
+		attrs := []string{
+			"label=" + dotQuote(strings.Join(e.lines(), "\n")),
+			fmt.Sprintf("penwidth=%.1f", e.width()),
+		}
+		switch e.Type {
+		case discoverer.EdgeUndirected:
+			attrs = append(attrs, "dir=both", "arrowhead=odot", "arrowtail=odot", "style=dashed")
+		case discoverer.EdgeBidirected:
+			attrs = append(attrs, "dir=both", "style=dashed")
+		case discoverer.EdgeConflicting:
+			attrs = append(attrs, "dir=none", "style=dashed", "color=red")
+		}
+		fmt.Fprintf(bw, "  %s -> %s [%s];\n", e.source, e.target, strings.Join(attrs, ", "))
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// dotQuote quotes s as a DOT string, keeping line breaks as centered lines.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
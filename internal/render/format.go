@@ -0,0 +1,61 @@
+// Package render draws causal graphs in formats other tools can display:
+// Graphviz DOT, Mermaid and GraphML.
+package render
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+)
+
+type Format string
+
+const (
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mermaid"
+	FormatGraphML Format = "graphml"
+)
+
+var SupportedFormats = []Format{FormatDOT, FormatMermaid, FormatGraphML}
+
+// FormatFromPath infers the format from a file extension, defaulting to dot.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mmd", ".mermaid":
+		return FormatMermaid
+	case ".graphml":
+		return FormatGraphML
+	default:
+		return FormatDOT
+	}
+}
+
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(s))
+	if !slices.Contains(SupportedFormats, f) {
+		return "", fmt.Errorf("unsupported graph format '%s' (supported: %v)", s, SupportedFormats)
+	}
+	return f, nil
+}
+
+// Write draws graph in the given format. Edges are labelled with their lag
+// and strength, and with the fitted coefficient when estimate (which may be
+// nil) has a model for their target.
+func Write(w io.Writer, graph *causal.CausalGraph, estimate *causal.EstimateResponse, format Format) error {
+	v := newView(graph, estimate)
+
+	switch format {
+	case FormatDOT:
+		return writeDOT(w, v)
+	case FormatMermaid:
+		return writeMermaid(w, v)
+	case FormatGraphML:
+		return writeGraphML(w, v)
+	default:
+		return fmt.Errorf("unsupported graph format '%s' (supported: %v)", format, SupportedFormats)
+	}
+}
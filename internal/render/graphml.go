@@ -0,0 +1,153 @@
+package render
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/w-h-a/caus/internal/client/discoverer"
+)
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Data        []graphMLData `xml:"data"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID       string        `xml:"id,attr"`
+	Source   string        `xml:"source,attr"`
+	Target   string        `xml:"target,attr"`
+	Directed *bool         `xml:"directed,attr,omitempty"`
+	Data     []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// graphMLKeys declares every attribute writeGraphML may set. Tools like yEd
+// and Gephi show them as node and edge properties.
+var graphMLKeys = []graphMLKey{
+	{ID: "title", For: "graph", Name: "title", Type: "string"},
+	{ID: "label", For: "node", Name: "label", Type: "string"},
+	{ID: "transforms", For: "node", Name: "transforms", Type: "string"},
+	{ID: "r_squared", For: "node", Name: "r_squared", Type: "double"},
+	{ID: "edge_label", For: "edge", Name: "label", Type: "string"},
+	{ID: "type", For: "edge", Name: "type", Type: "string"},
+	{ID: "lag", For: "edge", Name: "lag", Type: "int"},
+	{ID: "lag_time", For: "edge", Name: "lag_time", Type: "string"},
+	{ID: "strength", For: "edge", Name: "strength", Type: "double"},
+	{ID: "statistic", For: "edge", Name: "statistic", Type: "double"},
+	{ID: "p_value", For: "edge", Name: "p_value", Type: "double"},
+	{ID: "coefficient", For: "edge", Name: "coefficient", Type: "double"},
+	{ID: "ci_lower", For: "edge", Name: "ci_lower", Type: "double"},
+	{ID: "ci_upper", For: "edge", Name: "ci_upper", Type: "double"},
+}
+
+// writeGraphML draws v as GraphML, with every annotation as its own typed
+// attribute as well as in the labels. Unoriented edges are undirected, with
+// their tigramite type kept in "type".
+func writeGraphML(w io.Writer, v view) error {
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  graphMLKeys,
+		Graph: graphMLGraph{ID: "caus", EdgeDefault: "directed"},
+	}
+
+	if len(v.title) > 0 {
+		doc.Graph.Data = append(doc.Graph.Data, graphMLData{Key: "title", Value: v.title})
+	}
+
+	for _, n := range v.nodes {
+		gn := graphMLNode{ID: n.id, Data: []graphMLData{{Key: "label", Value: n.label}}}
+		if len(n.transforms) > 0 {
+			gn.Data = append(gn.Data, graphMLData{Key: "transforms", Value: n.transforms})
+		}
+		if n.model != nil {
+			gn.Data = append(gn.Data, graphMLData{Key: "r_squared", Value: graphMLFloat(n.model.RSquared)})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gn)
+	}
+
+	for i, e := range v.edges {
+		typ := e.Type
+		if len(typ) == 0 {
+			typ = discoverer.EdgeDirected
+		}
+
+		ge := graphMLEdge{
+			ID:     fmt.Sprintf("e%d", i),
+			Source: e.source,
+			Target: e.target,
+			Data: []graphMLData{
+				{Key: "edge_label", Value: strings.Join(e.lines(), "\n")},
+				{Key: "type", Value: typ},
+				{Key: "lag", Value: strconv.Itoa(int(e.Lag))},
+			},
+		}
+		if !directed(e.Edge) {
+			undirected := false
+			ge.Directed = &undirected
+		}
+		if e.lagTime > 0 {
+			ge.Data = append(ge.Data, graphMLData{Key: "lag_time", Value: e.lagTime.String()})
+		}
+		ge.Data = append(ge.Data,
+			graphMLData{Key: "strength", Value: graphMLFloat(e.PartialCorr)},
+			graphMLData{Key: "statistic", Value: graphMLFloat(e.Statistic)},
+			graphMLData{Key: "p_value", Value: graphMLFloat(e.PValue)},
+		)
+		if e.model != nil {
+			ge.Data = append(ge.Data, graphMLData{Key: "coefficient", Value: graphMLFloat(e.model.Coefficients[e.coef])})
+			if lower, upper, ok := e.interval(); ok {
+				ge.Data = append(ge.Data,
+					graphMLData{Key: "ci_lower", Value: graphMLFloat(lower)},
+					graphMLData{Key: "ci_upper", Value: graphMLFloat(upper)},
+				)
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, ge)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func graphMLFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
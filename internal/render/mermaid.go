@@ -0,0 +1,59 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/w-h-a/caus/internal/client/discoverer"
+)
+
+// writeMermaid draws v as a Mermaid flowchart, left to right, with the same
+// edge styles as writeDOT, so that it can be pasted into a Markdown doc.
+func writeMermaid(w io.Writer, v view) error {
+	bw := bufio.NewWriter(w)
+
+	if len(v.title) > 0 {
+		fmt.Fprintln(bw, "---")
+		fmt.Fprintf(bw, "title: %s\n", mermaidQuote(v.title))
+		fmt.Fprintln(bw, "---")
+	}
+	fmt.Fprintln(bw, "flowchart LR")
+
+	for _, n := range v.nodes {
+		fmt.Fprintf(bw, "  %s[%s]\n", n.id, mermaidQuote(strings.Join(n.lines(), "<br/>")))
+	}
+
+	// links are styled by their index, in the order they were declared
+	var styles []string
+	for i, e := range v.edges {
+		arrow := "-->"
+		style := fmt.Sprintf("stroke-width:%.1fpx", e.width())
+		switch e.Type {
+		case discoverer.EdgeUndirected:
+			arrow = "o--o"
+			style += ",stroke-dasharray:4"
+		case discoverer.EdgeBidirected:
+			arrow = "<-->"
+			style += ",stroke-dasharray:4"
+		case discoverer.EdgeConflicting:
+			arrow = "x--x"
+			style += ",stroke-dasharray:4,stroke:red"
+		}
+		fmt.Fprintf(bw, "  %s %s|%s| %s\n", e.source, arrow, mermaidQuote(strings.Join(e.lines(), "<br/>")), e.target)
+		styles = append(styles, fmt.Sprintf("  linkStyle %d %s", i, style))
+	}
+
+	for _, s := range styles {
+		fmt.Fprintln(bw, s)
+	}
+
+	return bw.Flush()
+}
+
+// mermaidQuote quotes s as Mermaid text, which has entity codes instead of
+// escapes.
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,166 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+)
+
+// view is a graph with everything the renderers annotate it with, so that
+// every format tells the same story.
+type view struct {
+	title string
+	nodes []node
+	edges []edge
+}
+
+type node struct {
+	// id is safe to use as an identifier in every format, e.g. "n0".
+	id    string
+	label string
+	// transforms spells out the node's transforms, empty without any.
+	transforms string
+	// model is the node's fitted model, nil without an estimate.
+	model *causal.ModelInfo
+}
+
+type edge struct {
+	*causal.Edge
+	source, target string
+	// lagTime is the lag in time, zero when the step is unknown.
+	lagTime time.Duration
+	// coef indexes the edge's coefficient in model, which is nil when the
+	// edge was not fitted.
+	model *causal.ModelInfo
+	coef  int
+}
+
+func newView(graph *causal.CausalGraph, estimate *causal.EstimateResponse) view {
+	v := view{}
+
+	window := graph.GetWindow()
+	if window == nil {
+		window = estimate.GetWindow()
+	}
+	if window != nil {
+		v.title = fmt.Sprintf("Window: %s -> %s (Step: %s)", window.Start, window.End, window.Step)
+	}
+
+	step, _ := time.ParseDuration(window.GetStep())
+
+	ids := map[string]string{}
+	add := func(n *causal.Node) string {
+		if id, ok := ids[n.Label]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(v.nodes))
+		ids[n.Label] = id
+		nd := node{
+			id:    id,
+			label: n.Label,
+			model: estimate.GetModels()[n.Label],
+		}
+		if len(n.Transforms) > 0 {
+			nd.transforms = DescribeTransforms(n)
+		}
+		v.nodes = append(v.nodes, nd)
+		return id
+	}
+
+	for _, n := range graph.Nodes {
+		add(n)
+	}
+
+	for _, e := range graph.Edges {
+		ed := edge{
+			Edge:   e,
+			source: add(&causal.Node{Label: e.Source}),
+			target: add(&causal.Node{Label: e.Target}),
+			coef:   -1,
+		}
+		if step > 0 {
+			ed.lagTime = time.Duration(e.Lag) * step
+		}
+		if model := estimate.GetModels()[e.Target]; model != nil && directed(e) {
+			feature := fmt.Sprintf("%s_lag%d", e.Source, e.Lag)
+			for i, f := range model.Features {
+				if f == feature && i < len(model.Coefficients) {
+					ed.model, ed.coef = model, i
+					break
+				}
+			}
+		}
+		v.edges = append(v.edges, ed)
+	}
+
+	return v
+}
+
+// DescribeTransforms spells out the transforms of n as nested calls, e.g.
+// "log1p(rate(requests))".
+func DescribeTransforms(n *causal.Node) string {
+	out := n.Label
+	for _, t := range n.Transforms {
+		name, args, ok := strings.Cut(t, "(")
+		if ok {
+			out = fmt.Sprintf("%s(%s, %s", name, out, args)
+			continue
+		}
+		out = fmt.Sprintf("%s(%s)", t, out)
+	}
+	return out
+}
+
+func directed(e *causal.Edge) bool {
+	return e.Type == discoverer.EdgeDirected || len(e.Type) == 0
+}
+
+// lines are what a node is labelled with.
+func (n node) lines() []string {
+	lines := []string{n.label}
+	if len(n.transforms) > 0 {
+		lines = append(lines, "= "+n.transforms)
+	}
+	if n.model != nil {
+		lines = append(lines, fmt.Sprintf("R² %.3f", n.model.RSquared))
+	}
+	return lines
+}
+
+// lines are what an edge is labelled with: its lag, its strength and, once
+// fitted, its coefficient with its confidence interval.
+func (e edge) lines() []string {
+	lag := fmt.Sprintf("lag %d", e.Lag)
+	if e.lagTime > 0 {
+		lag = fmt.Sprintf("lag %d = %s", e.Lag, e.lagTime)
+	}
+
+	lines := []string{lag, fmt.Sprintf("strength %.3f", e.PartialCorr)}
+
+	if e.model != nil {
+		coef := fmt.Sprintf("coef %.4f", e.model.Coefficients[e.coef])
+		if lower, upper, ok := e.interval(); ok {
+			coef += fmt.Sprintf(" [%.4f, %.4f]", lower, upper)
+		}
+		lines = append(lines, coef)
+	}
+
+	return lines
+}
+
+func (e edge) interval() (float32, float32, bool) {
+	if e.model == nil || e.coef >= len(e.model.CiLower) || e.coef >= len(e.model.CiUpper) {
+		return 0, 0, false
+	}
+	return e.model.CiLower[e.coef], e.model.CiUpper[e.coef], true
+}
+
+// width is the line width of an edge, from 1 for no strength up to 5 for a
+// partial correlation of ±1.
+func (e edge) width() float64 {
+	return math.Round(10*(1+4*math.Min(math.Abs(float64(e.PartialCorr)), 1))) / 10
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxBodyBytes bounds a JSON request; a graph and a vars.yml are small.
+const maxBodyBytes = 8 << 20
+
+// Handler is the JSON/HTTP gateway: POST /v1alpha1/discover and
+// /v1alpha1/estimate with the request as JSON, answered with the response as
+// JSON, plus the job routes:
+//
+//	POST /v1alpha1/jobs/discover     submit a DiscoverRequest as a job
+//	POST /v1alpha1/jobs/estimate     submit an EstimateRequest as a job
+//	GET  /v1alpha1/jobs              list jobs (?state=running&limit=10)
+//	GET  /v1alpha1/jobs/{id}         a job, with its result once it succeeded
+//	POST /v1alpha1/jobs/{id}/cancel  cancel a job
+//	GET  /v1alpha1/jobs/{id}/logs    a job's log as JSON lines (?after=12&follow=true)
+//
+// GET /healthz answers "ok".
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok\n")
+	})
+
+	mux.Handle("POST /v1alpha1/discover", s.gateway(
+		body(func() proto.Message { return &caus.DiscoverRequest{} }),
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.Discover(ctx, req.(*caus.DiscoverRequest))
+		},
+	))
+
+	mux.Handle("POST /v1alpha1/estimate", s.gateway(
+		body(func() proto.Message { return &caus.EstimateRequest{} }),
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.Estimate(ctx, req.(*caus.EstimateRequest))
+		},
+	))
+
+	mux.Handle("POST /v1alpha1/jobs/discover", s.gateway(
+		body(func() proto.Message { return &caus.DiscoverRequest{} }),
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.SubmitDiscover(ctx, req.(*caus.DiscoverRequest))
+		},
+	))
+
+	mux.Handle("POST /v1alpha1/jobs/estimate", s.gateway(
+		body(func() proto.Message { return &caus.EstimateRequest{} }),
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.SubmitEstimate(ctx, req.(*caus.EstimateRequest))
+		},
+	))
+
+	mux.Handle("GET /v1alpha1/jobs", s.gateway(
+		func(r *http.Request) (proto.Message, error) {
+			limit, err := queryInt(r, "limit")
+			if err != nil {
+				return nil, err
+			}
+			return &caus.ListJobsRequest{State: r.URL.Query().Get("state"), Limit: int32(limit)}, nil
+		},
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.ListJobs(ctx, req.(*caus.ListJobsRequest))
+		},
+	))
+
+	mux.Handle("GET /v1alpha1/jobs/{id}", s.gateway(
+		func(r *http.Request) (proto.Message, error) {
+			return &caus.GetJobRequest{Id: r.PathValue("id")}, nil
+		},
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.GetJob(ctx, req.(*caus.GetJobRequest))
+		},
+	))
+
+	mux.Handle("POST /v1alpha1/jobs/{id}/cancel", s.gateway(
+		func(r *http.Request) (proto.Message, error) {
+			return &caus.CancelJobRequest{Id: r.PathValue("id")}, nil
+		},
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.CancelJob(ctx, req.(*caus.CancelJobRequest))
+		},
+	))
+
+	mux.HandleFunc("GET /v1alpha1/jobs/{id}/logs", s.jobLogs)
+
+	return mux
+}
+
+// body decodes the JSON body of a request into a message of newRequest.
+func body(newRequest func() proto.Message) func(r *http.Request) (proto.Message, error) {
+	return func(r *http.Request) (proto.Message, error) {
+		bs, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to read request: %v", err)
+		}
+
+		req := newRequest()
+		if err := protojson.Unmarshal(bs, req); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		}
+
+		return req, nil
+	}
+}
+
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s '%s'", name, value)
+	}
+
+	return n, nil
+}
+
+func (s *Server) gateway(decode func(r *http.Request) (proto.Message, error), call func(ctx context.Context, req proto.Message) (proto.Message, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authenticated(r.Header.Values("Authorization")) {
+			writeError(w, status.Error(codes.Unauthenticated, "missing or invalid bearer token"))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+		req, err := decode(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		rsp, err := call(r.Context(), req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		bs, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(rsp)
+		if err != nil {
+			writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bs)
+	})
+}
+
+// jobLogs streams a job's log entries as JSON lines, flushing each one, so
+// that ?follow=true can be tailed with curl.
+func (s *Server) jobLogs(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticated(r.Header.Values("Authorization")) {
+		writeError(w, status.Error(codes.Unauthenticated, "missing or invalid bearer token"))
+		return
+	}
+
+	if s.options.Jobs == nil {
+		writeError(w, errNoJobs)
+		return
+	}
+
+	after, err := queryInt(r, "after")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	follow := r.URL.Query().Get("follow") == "true"
+	flusher, _ := w.(http.Flusher)
+	started := false
+
+	err = s.options.Jobs.Logs(r.Context(), r.PathValue("id"), uint64(max(after, 0)), follow, func(entry *caus.LogEntry) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			started = true
+		}
+
+		bs, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(append(bs, '\n')); err != nil {
+			return err
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+		}
+	case !started:
+		writeError(w, jobError(err))
+	}
+}
+
+// writeError answers with the HTTP status closest to the error's gRPC code
+// and a body of {"code": ..., "message": ...}.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(st.Code()))
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"code":    st.Code().String(),
+		"message": st.Message(),
+	})
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	"github.com/w-h-a/caus/internal/service/jobs"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Server) SubmitDiscover(ctx context.Context, req *caus.DiscoverRequest) (*caus.Job, error) {
+	if s.options.Jobs == nil {
+		return nil, errNoJobs
+	}
+
+	task := s.options.Jobs.NewTask(jobs.KindDiscover)
+
+	r, err := s.resolve(req.GetRun(), taskOptions(task)...)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.options.Jobs.Submit(ctx, task, func(ctx context.Context) (proto.Message, error) {
+		return r.discover(ctx, req)
+	})
+	if err != nil {
+		return nil, jobError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Server) SubmitEstimate(ctx context.Context, req *caus.EstimateRequest) (*caus.Job, error) {
+	if s.options.Jobs == nil {
+		return nil, errNoJobs
+	}
+
+	if req.GetGraph() == nil {
+		return nil, status.Error(codes.InvalidArgument, "graph is required")
+	}
+
+	task := s.options.Jobs.NewTask(jobs.KindEstimate)
+
+	r, err := s.resolve(req.GetRun(), taskOptions(task)...)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.options.Jobs.Submit(ctx, task, func(ctx context.Context) (proto.Message, error) {
+		return r.estimate(ctx, req)
+	})
+	if err != nil {
+		return nil, jobError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Server) GetJob(ctx context.Context, req *caus.GetJobRequest) (*caus.Job, error) {
+	if s.options.Jobs == nil {
+		return nil, errNoJobs
+	}
+
+	job, err := s.options.Jobs.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, jobError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Server) ListJobs(ctx context.Context, req *caus.ListJobsRequest) (*caus.ListJobsResponse, error) {
+	if s.options.Jobs == nil {
+		return nil, errNoJobs
+	}
+
+	list, err := s.options.Jobs.List(ctx, req.GetState(), int(req.GetLimit()))
+	if err != nil {
+		return nil, jobError(err)
+	}
+
+	return &caus.ListJobsResponse{Jobs: list}, nil
+}
+
+func (s *Server) CancelJob(ctx context.Context, req *caus.CancelJobRequest) (*caus.Job, error) {
+	if s.options.Jobs == nil {
+		return nil, errNoJobs
+	}
+
+	job, err := s.options.Jobs.Cancel(ctx, req.GetId())
+	if err != nil {
+		return nil, jobError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Server) JobLogs(req *caus.JobLogsRequest, stream grpc.ServerStreamingServer[caus.LogEntry]) error {
+	if s.options.Jobs == nil {
+		return errNoJobs
+	}
+
+	err := s.options.Jobs.Logs(stream.Context(), req.GetId(), req.GetAfter(), req.GetFollow(), stream.Send)
+	if err != nil {
+		return jobError(err)
+	}
+
+	return nil
+}
+
+var errNoJobs = status.Error(codes.Unimplemented, "jobs are disabled on this server")
+
+// taskOptions send the log and progress of a job's run to its task.
+func taskOptions(task *jobs.Task) []orchestrator.Option {
+	return []orchestrator.Option{
+		orchestrator.WithLogger(task.Logger()),
+		orchestrator.WithProgress(task.Progress),
+	}
+}
+
+func jobError(err error) error {
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, jobs.ErrFinished):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, jobs.ErrShutdown):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+}
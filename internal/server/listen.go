@@ -0,0 +1,56 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Loopback reports whether addr ("host:port") only listens on the loopback
+// interface. An empty host, as in ":8080", listens on every interface, and so
+// does a hostname other than localhost as far as anyone can tell.
+func Loopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// CheckExposure refuses to serve on an address beyond the loopback interface
+// without a token, since every request runs with the sources' credentials.
+func CheckExposure(addr string, token string) error {
+	if len(token) > 0 || Loopback(addr) {
+		return nil
+	}
+	return fmt.Errorf("refusing to serve on '%s' without a token: anyone who can reach it could query the sources with their credentials", addr)
+}
+
+// TLSConfig loads the certificate the listeners present, or returns nil
+// without certFile and keyFile.
+func TLSConfig(certFile string, keyFile string) (*tls.Config, error) {
+	if len(certFile) == 0 && len(keyFile) == 0 {
+		return nil, nil
+	}
+
+	if len(certFile) == 0 || len(keyFile) == 0 {
+		return nil, errors.New("server cert and key must be set together")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server cert: %w", err)
+	}
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
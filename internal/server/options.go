@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/service/jobs"
+)
+
+type Option func(*Options)
+
+type Options struct {
+	Sources []variable.Source
+	Token   string
+	Jobs    *jobs.Service
+	Context context.Context
+}
+
+// WithSources serves the variables and exclusions of these sources, whose
+// credentials requests never carry. A request naming any other source is
+// refused.
+func WithSources(sources ...variable.Source) Option {
+	return func(o *Options) {
+		o.Sources = append(o.Sources, sources...)
+	}
+}
+
+// WithToken requires every request to carry token as a bearer token.
+func WithToken(token string) Option {
+	return func(o *Options) {
+		o.Token = token
+	}
+}
+
+// WithJobs runs the Submit* rpcs as jobs of svc. Without it, the job rpcs
+// are unimplemented.
+func WithJobs(svc *jobs.Service) Option {
+	return func(o *Options) {
+		o.Jobs = svc
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Context: context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
@@ -0,0 +1,271 @@
+// Package server exposes the orchestrator as the caus.v1alpha1 gRPC service
+// and a JSON/HTTP gateway in front of it.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultStart = "2h"
+	defaultEnd   = "5m"
+	defaultStep  = time.Minute
+)
+
+// Factory builds the orchestrator of one request from its config, with the
+// fetchers and annotators of the config's sources and opts on top of the
+// server's own options.
+type Factory func(cfg *variable.DiscoveryConfig, opts ...orchestrator.Option) (*orchestrator.Service, error)
+
+type Server struct {
+	caus.UnimplementedCausServer
+	options Options
+	factory Factory
+}
+
+// run is a resolved caus.v1alpha1.Run.
+type run struct {
+	cfg   *variable.DiscoveryConfig
+	svc   *orchestrator.Service
+	start time.Time
+	end   time.Time
+	step  time.Duration
+}
+
+func (s *Server) Discover(ctx context.Context, req *caus.DiscoverRequest) (*causal.CausalGraph, error) {
+	r, err := s.resolve(req.GetRun())
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := r.discover(ctx, req)
+	if err != nil {
+		return nil, runError(ctx, err)
+	}
+
+	return graph, nil
+}
+
+func (s *Server) Estimate(ctx context.Context, req *caus.EstimateRequest) (*causal.EstimateResponse, error) {
+	if req.GetGraph() == nil {
+		return nil, status.Error(codes.InvalidArgument, "graph is required")
+	}
+
+	r, err := s.resolve(req.GetRun())
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := r.estimate(ctx, req)
+	if err != nil {
+		return nil, runError(ctx, err)
+	}
+
+	return rsp, nil
+}
+
+func (r *run) discover(ctx context.Context, req *caus.DiscoverRequest) (*causal.CausalGraph, error) {
+	return r.svc.Discover(ctx, r.cfg.Variables, r.start, r.end, r.step, orchestrator.DiscoveryArgs{
+		MaxLag:      req.GetMaxLag(),
+		PcAlpha:     req.GetPcAlpha(),
+		MinStrength: req.GetMinStrength(),
+	})
+}
+
+func (r *run) estimate(ctx context.Context, req *caus.EstimateRequest) (*causal.EstimateResponse, error) {
+	args := orchestrator.EstimateArgs{
+		Graph:           req.GetGraph(),
+		AllowUnoriented: req.GetAllowUnoriented(),
+		ConfidenceLevel: req.GetConfidenceLevel(),
+	}
+
+	if b := req.GetBootstrap(); b != nil {
+		args.Bootstrap = orchestrator.BootstrapArgs{
+			Samples:   b.Samples,
+			BlockSize: b.BlockSize,
+			Seed:      b.Seed,
+		}
+	}
+
+	return r.svc.Estimate(ctx, r.cfg.Variables, r.start, r.end, r.step, args)
+}
+
+// resolve parses and authorizes a run and builds its orchestrator, with
+// extra on top of the run's own options.
+func (s *Server) resolve(in *caus.Run, extra ...orchestrator.Option) (*run, error) {
+	if in == nil || len(in.Vars) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "run.vars is required")
+	}
+
+	cfg, err := config.ParseConfig([]byte(in.Vars))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid vars: %v", err)
+	}
+
+	if err := s.authorize(cfg); err != nil {
+		return nil, err
+	}
+
+	r := &run{cfg: cfg, step: defaultStep}
+
+	if len(in.Step) > 0 {
+		if r.step, err = time.ParseDuration(in.Step); err != nil || r.step <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid step '%s': must be a positive duration", in.Step)
+		}
+	}
+
+	now := time.Now().UTC().Truncate(r.step)
+
+	if r.start, err = config.ParseTime(or(in.Start, defaultStart), now); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start: %v", err)
+	}
+	if r.end, err = config.ParseTime(or(in.End, defaultEnd), now); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid end: %v", err)
+	}
+	if !r.start.Before(r.end) {
+		return nil, status.Errorf(codes.InvalidArgument, "window start %s is not before its end %s", r.start.Format(time.RFC3339), r.end.Format(time.RFC3339))
+	}
+
+	exclusions, err := config.ParseExclusions(in.Exclude, "request")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	opts := []orchestrator.Option{
+		orchestrator.WithMaxMissingRatio(cfg.MaxMissingRatio),
+		orchestrator.WithMakeStationary(in.MakeStationary),
+		orchestrator.WithExclusions(append(slices.Clone(cfg.Exclusions), exclusions...)...),
+	}
+
+	if cfg.Quality != nil {
+		opts = append(opts, orchestrator.WithQualityRules(*cfg.Quality))
+	}
+
+	if r.svc, err = s.factory(cfg, append(opts, extra...)...); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return r, nil
+}
+
+// authorize checks that every source of cfg is one the server serves and
+// fills in its credentials.
+func (s *Server) authorize(cfg *variable.DiscoveryConfig) error {
+	var sources []*variable.Source
+	for i := range cfg.Variables {
+		if !cfg.Variables[i].Derived() {
+			sources = append(sources, cfg.Variables[i].Source)
+		}
+	}
+	for i := range cfg.Exclusions {
+		if cfg.Exclusions[i].Source != nil {
+			sources = append(sources, cfg.Exclusions[i].Source)
+		}
+	}
+
+	for _, src := range sources {
+		if len(src.ApiKey) > 0 || len(src.AppKey) > 0 {
+			return status.Errorf(codes.InvalidArgument, "source %s/%s at '%s' carries credentials, which only the server sets", src.Type, src.Impl, src.Loc)
+		}
+
+		i := s.served(src)
+		if i < 0 {
+			return status.Errorf(codes.PermissionDenied, "source %s/%s at '%s' is not served", src.Type, src.Impl, src.Loc)
+		}
+
+		src.ApiKey = s.options.Sources[i].ApiKey
+		src.AppKey = s.options.Sources[i].AppKey
+	}
+
+	return nil
+}
+
+func (s *Server) served(src *variable.Source) int {
+	for i, allowed := range s.options.Sources {
+		if allowed.Type == src.Type && allowed.Impl == src.Impl && strings.TrimSuffix(allowed.Loc, "/") == strings.TrimSuffix(src.Loc, "/") {
+			return i
+		}
+	}
+	return -1
+}
+
+// UnaryInterceptor refuses calls without the server's token, if it has one.
+func (s *Server) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if !s.authenticated(md.Get("authorization")) {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming calls.
+func (s *Server) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		if !s.authenticated(md.Get("authorization")) {
+			return status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (s *Server) authenticated(values []string) bool {
+	if len(s.options.Token) == 0 {
+		return true
+	}
+
+	want := []byte("Bearer " + s.options.Token)
+	for _, v := range values {
+		if subtle.ConstantTimeCompare([]byte(v), want) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runError gives a failed run a status: the caller's own cancellation or
+// deadline, or Unknown for everything from fetching to the worker.
+func runError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
+
+func or(value string, fallback string) string {
+	if len(value) == 0 {
+		return fallback
+	}
+	return value
+}
+
+func New(factory Factory, opts ...Option) *Server {
+	options := NewOptions(opts...)
+
+	return &Server{
+		options: options,
+		factory: factory,
+	}
+}
@@ -0,0 +1,431 @@
+// Package jobs runs discovery and estimation in the background and keeps
+// their state, logs and results in a job store, so that they outlive the
+// request that started them and survive restarts.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/jobstore"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	KindDiscover = "discover"
+	KindEstimate = "estimate"
+
+	StateQueued    = "queued"
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+	StateCancelled = "cancelled"
+)
+
+var (
+	ErrNotFound = jobstore.ErrNotFound
+	ErrFinished = errors.New("job already finished")
+	ErrShutdown = errors.New("the server is shutting down")
+
+	errCancelled = errors.New("cancelled")
+)
+
+// Func runs a job and returns its result: a *causal.CausalGraph or a
+// *causal.EstimateResponse.
+type Func func(ctx context.Context) (proto.Message, error)
+
+type Service struct {
+	store   jobstore.Store
+	options Options
+	slots   chan struct{}
+	ctx     context.Context
+	stop    context.CancelCauseFunc
+	wg      sync.WaitGroup
+	mtx     sync.Mutex
+	active  map[string]*activeJob
+	wakeMtx sync.Mutex
+	changed chan struct{}
+}
+
+// activeJob is a queued or running job, which the service owns until it
+// finishes.
+type activeJob struct {
+	job    *caus.Job
+	cancel context.CancelCauseFunc
+}
+
+// Task is a job before it is submitted, so that its logger and progress can
+// be handed to the orchestrator that will run it.
+type Task struct {
+	id     string
+	kind   string
+	svc    *Service
+	logger *log.Logger
+}
+
+func (t *Task) ID() string {
+	return t.id
+}
+
+// Logger appends every line it is given to the job's log.
+func (t *Task) Logger() *log.Logger {
+	return t.logger
+}
+
+// Progress records p as the job's progress.
+func (t *Task) Progress(p orchestrator.Progress) {
+	t.svc.update(t.id, func(job *caus.Job) {
+		job.Progress = &caus.JobProgress{Stage: p.Stage, Done: int32(p.Done), Total: int32(p.Total)}
+	})
+}
+
+type logWriter struct {
+	task *Task
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w.task.svc.log(w.task.id, strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// NewTask prepares a job of kind ("discover" or "estimate").
+func (s *Service) NewTask(kind string) *Task {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	t := &Task{
+		id:   hex.EncodeToString(b),
+		kind: kind,
+		svc:  s,
+	}
+	t.logger = log.New(logWriter{task: t}, "", 0)
+
+	return t
+}
+
+// Submit queues t to run fn and returns the queued job.
+func (s *Service) Submit(ctx context.Context, t *Task, fn Func) (*caus.Job, error) {
+	if s.ctx.Err() != nil {
+		return nil, ErrShutdown
+	}
+
+	job := &caus.Job{
+		Id:        t.id,
+		Kind:      t.kind,
+		State:     StateQueued,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+
+	if err := s.store.Put(ctx, job); err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancelCause(s.ctx)
+
+	s.mtx.Lock()
+	s.active[t.id] = &activeJob{job: proto.Clone(job).(*caus.Job), cancel: cancel}
+	s.mtx.Unlock()
+
+	t.logger.Printf("Queued %s job", t.kind)
+
+	s.wg.Add(1)
+	go s.run(runCtx, t, fn)
+
+	return job, nil
+}
+
+func (s *Service) run(ctx context.Context, t *Task, fn Func) {
+	defer s.wg.Done()
+
+	if s.slots != nil {
+		select {
+		case s.slots <- struct{}{}:
+			defer func() { <-s.slots }()
+		case <-ctx.Done():
+			s.finish(ctx, t, nil, context.Cause(ctx))
+			return
+		}
+	}
+
+	s.update(t.id, func(job *caus.Job) {
+		job.State = StateRunning
+		job.StartedAt = time.Now().UnixMilli()
+	})
+	t.logger.Printf("Started")
+
+	if s.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, s.options.Timeout, fmt.Errorf("timed out after %s", s.options.Timeout))
+		defer cancel()
+	}
+
+	result, err := fn(ctx)
+
+	s.finish(ctx, t, result, err)
+}
+
+// finish records how the job ended. Its last log line is written before its
+// state, so that whoever sees the job finished has seen its whole log.
+func (s *Service) finish(ctx context.Context, t *Task, result proto.Message, err error) {
+	cause := context.Cause(ctx)
+	ended := &caus.Job{FinishedAt: time.Now().UnixMilli()}
+
+	switch {
+	case err == nil:
+		ended.State = StateSucceeded
+		switch r := result.(type) {
+		case *causal.CausalGraph:
+			ended.Result = &caus.Job_Graph{Graph: r}
+		case *causal.EstimateResponse:
+			ended.Result = &caus.Job_Estimate{Estimate: r}
+		default:
+			ended.State = StateFailed
+			ended.Error = fmt.Sprintf("unsupported result %T", result)
+		}
+	case errors.Is(cause, errCancelled):
+		ended.State = StateCancelled
+		ended.Error = cause.Error()
+	case cause != nil:
+		ended.State = StateFailed
+		ended.Error = fmt.Sprintf("interrupted: %v", cause)
+	default:
+		ended.State = StateFailed
+		ended.Error = err.Error()
+	}
+
+	if len(ended.Error) > 0 {
+		t.logger.Printf("Finished as %s: %s", ended.State, ended.Error)
+	} else {
+		t.logger.Printf("Finished as %s", ended.State)
+	}
+
+	s.mtx.Lock()
+	job := s.active[t.id].job
+	job.State, job.Error, job.FinishedAt, job.Result = ended.State, ended.Error, ended.FinishedAt, ended.Result
+	if err := s.store.Put(context.Background(), job); err != nil {
+		s.options.Logger.Printf("JOBS: failed to record the end of job %s: %v", t.id, err)
+	}
+	delete(s.active, t.id)
+	s.mtx.Unlock()
+
+	s.notify()
+}
+
+// update applies fn to an active job and persists it.
+func (s *Service) update(id string, fn func(job *caus.Job)) {
+	s.mtx.Lock()
+	a, ok := s.active[id]
+	if ok {
+		fn(a.job)
+		if err := s.store.Put(context.Background(), a.job); err != nil {
+			s.options.Logger.Printf("JOBS: failed to update job %s: %v", id, err)
+		}
+	}
+	s.mtx.Unlock()
+
+	if ok {
+		s.notify()
+	}
+}
+
+func (s *Service) log(id string, message string) {
+	if _, err := s.store.AppendLog(context.Background(), id, time.Now(), message); err != nil {
+		s.options.Logger.Printf("JOBS: %v", err)
+	}
+
+	s.options.Logger.Printf("JOB %s: %s", id, message)
+
+	s.notify()
+}
+
+// notify wakes everyone waiting for a job to change.
+func (s *Service) notify() {
+	s.wakeMtx.Lock()
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.wakeMtx.Unlock()
+}
+
+func (s *Service) wait() <-chan struct{} {
+	s.wakeMtx.Lock()
+	defer s.wakeMtx.Unlock()
+	return s.changed
+}
+
+func (s *Service) running(id string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, ok := s.active[id]
+	return ok
+}
+
+// Get returns the job with id, with its result once it succeeded.
+func (s *Service) Get(ctx context.Context, id string) (*caus.Job, error) {
+	job, err := s.store.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: '%s'", ErrNotFound, id)
+	}
+	return job, err
+}
+
+// List returns the jobs in state (every job if empty), newest first and
+// without results. A limit > 0 returns that many at most.
+func (s *Service) List(ctx context.Context, state string, limit int) ([]*caus.Job, error) {
+	all, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*caus.Job
+	for _, job := range all {
+		if len(state) > 0 && job.State != state {
+			continue
+		}
+		if limit > 0 && len(jobs) == limit {
+			break
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Cancel stops a queued or running job. The job is cancelled once its run
+// returns, which the returned job may not show yet.
+func (s *Service) Cancel(ctx context.Context, id string) (*caus.Job, error) {
+	s.mtx.Lock()
+	a, ok := s.active[id]
+	var job *caus.Job
+	if ok {
+		a.cancel(errCancelled)
+		job = proto.Clone(a.job).(*caus.Job)
+	}
+	s.mtx.Unlock()
+
+	if ok {
+		s.log(id, "Cancel requested")
+		return job, nil
+	}
+
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w (%s)", ErrFinished, job.State)
+}
+
+// Logs hands send the log entries of the job with id after seq after. With
+// follow, it keeps waiting for new entries until the job finishes or ctx is
+// done.
+func (s *Service) Logs(ctx context.Context, id string, after uint64, follow bool, send func(*caus.LogEntry) error) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+
+	for {
+		wake := s.wait()
+		running := s.running(id)
+
+		entries, err := s.store.Logs(ctx, id, after)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := send(entry); err != nil {
+				return err
+			}
+			after = entry.Seq
+		}
+
+		if !follow || !running {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// Close cancels every active job and waits for their runs to return, or for
+// ctx to be done. The store stays open.
+func (s *Service) Close(ctx context.Context) error {
+	s.stop(ErrShutdown)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recover fails the jobs that were queued or running when the last process
+// stopped, since nothing will ever finish them.
+func (s *Service) recover(ctx context.Context) error {
+	jobs, err := s.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.State != StateQueued && job.State != StateRunning {
+			continue
+		}
+
+		job.State = StateFailed
+		job.Error = "interrupted: the server restarted"
+		job.FinishedAt = time.Now().UnixMilli()
+
+		s.log(job.Id, "Finished as failed: "+job.Error)
+
+		if err := s.store.Put(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func New(store jobstore.Store, opts ...Option) (*Service, error) {
+	options := NewOptions(opts...)
+
+	s := &Service{
+		store:   store,
+		options: options,
+		active:  map[string]*activeJob{},
+		changed: make(chan struct{}),
+	}
+
+	s.ctx, s.stop = context.WithCancelCause(options.Context)
+
+	if options.MaxRunning > 0 {
+		s.slots = make(chan struct{}, options.MaxRunning)
+	}
+
+	if err := s.recover(s.ctx); err != nil {
+		return nil, fmt.Errorf("failed to recover jobs: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Option func(*Options)
+
+type Options struct {
+	MaxRunning int
+	Timeout    time.Duration
+	Logger     *log.Logger
+	Context    context.Context
+}
+
+// WithMaxRunning bounds the number of jobs that run at once; the others wait
+// in the queue. A value <= 0 removes the bound.
+func WithMaxRunning(n int) Option {
+	return func(o *Options) {
+		o.MaxRunning = n
+	}
+}
+
+// WithTimeout bounds how long a job may run once it started. A value <= 0
+// removes the bound.
+func WithTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = d
+	}
+}
+
+// WithLogger mirrors the log of every job, prefixed with its ID, to l.
+func WithLogger(l *log.Logger) Option {
+	return func(o *Options) {
+		o.Logger = l
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		MaxRunning: 2,
+		Logger:     log.Default(),
+		Context:    context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
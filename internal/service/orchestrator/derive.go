@@ -0,0 +1,40 @@
+package orchestrator
+
+import (
+	"math"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/expr"
+)
+
+// derive evaluates v's expression at every step over the current values of
+// the columns it references. A step where an operand is missing, or the
+// expression is undefined, is missing.
+func derive(ds *dataset.Dataset, v variable.VariableDefinition) []float64 {
+	out := make([]float64, ds.Len())
+
+	// EvaluationOrder has already parsed the expression
+	e, err := expr.Parse(v.Expression)
+	if err != nil {
+		for t := range out {
+			out[t] = math.NaN()
+		}
+		return out
+	}
+
+	columns := map[string][]float64{}
+	for _, name := range e.Refs() {
+		columns[name] = ds.Columns[ds.Index(name)].Values
+	}
+
+	for t := range out {
+		val := e.Eval(func(name string) float64 { return columns[name][t] })
+		if math.IsInf(val, 0) {
+			val = math.NaN()
+		}
+		out[t] = val
+	}
+
+	return out
+}
@@ -3,4 +3,8 @@ package orchestrator
 type DiscoveryArgs struct {
 	MaxLag  int32
 	PcAlpha float32
+	// MinStrength drops discovered edges whose absolute partial correlation
+	// is below it. Zero keeps every edge. Discovery fails when it's set and
+	// the discoverer reported no strengths at all.
+	MinStrength float32
 }
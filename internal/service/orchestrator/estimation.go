@@ -1,7 +1,57 @@
 package orchestrator
 
-import causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+import (
+	"fmt"
+	"strings"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+	"google.golang.org/protobuf/proto"
+)
 
 type EstimateArgs struct {
 	Graph *causal.CausalGraph
+	// ConfidenceLevel of the reported intervals. Zero means 0.95.
+	ConfidenceLevel float32
+	// Bootstrap replaces the analytic standard errors with a moving block
+	// bootstrap when Samples > 0.
+	Bootstrap BootstrapArgs
+	// AllowUnoriented fits the graph without its undirected, bidirected and
+	// conflicting edges instead of refusing it.
+	AllowUnoriented bool
+}
+
+type BootstrapArgs struct {
+	Samples   int32
+	BlockSize int32
+	Seed      uint64
+}
+
+// orientedGraph returns graph with only its directed edges. An edge without
+// a type counts as directed so that hand-written graphs keep working.
+func (s *Service) orientedGraph(graph *causal.CausalGraph, allowUnoriented bool) (*causal.CausalGraph, error) {
+	var unoriented []string
+	oriented := proto.Clone(graph).(*causal.CausalGraph)
+	oriented.Edges = nil
+
+	for _, e := range graph.GetEdges() {
+		switch e.Type {
+		case discoverer.EdgeDirected, "":
+			oriented.Edges = append(oriented.Edges, e)
+		default:
+			unoriented = append(unoriented, fmt.Sprintf("%s %s %s (lag %d)", e.Source, discoverer.EdgeArrow(e.Type), e.Target, e.Lag))
+		}
+	}
+
+	if len(unoriented) == 0 {
+		return graph, nil
+	}
+
+	if !allowUnoriented {
+		return nil, fmt.Errorf("graph has %d edges without a direction, orient or remove them first:\n  %s", len(unoriented), strings.Join(unoriented, "\n  "))
+	}
+
+	s.options.Logger.Printf("WARNING: ignoring %d edges without a direction: %s", len(unoriented), strings.Join(unoriented, ", "))
+
+	return oriented, nil
 }
@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"strings"
+	"time"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+)
+
+func indicator(v variable.VariableDefinition) string {
+	if v.EventQuery == nil || len(v.EventQuery.Indicator) == 0 {
+		return variable.IndicatorBinary
+	}
+	return v.EventQuery.Indicator
+}
+
+// indicate turns the event counts of binary events variables into 1 for a
+// step with any event and 0 otherwise.
+func indicate(vars []variable.VariableDefinition, results map[string]map[time.Time]float64) {
+	for _, v := range vars {
+		if v.Source.Type != "events" || indicator(v) != variable.IndicatorBinary {
+			continue
+		}
+
+		binary := make(map[time.Time]float64, len(results[v.Name]))
+		for t, count := range results[v.Name] {
+			if count > 0 {
+				binary[t] = 1
+			} else {
+				binary[t] = 0
+			}
+		}
+		results[v.Name] = binary
+	}
+}
+
+// describeEventQuery renders e.g. "binary(tags=deploy,checkout)".
+func describeEventQuery(v variable.VariableDefinition) string {
+	var what string
+	switch {
+	case len(v.EventQuery.Query) > 0:
+		what = "changes=" + v.EventQuery.Query
+	case len(v.EventQuery.Tags) > 0:
+		what = "tags=" + strings.Join(v.EventQuery.Tags, ",")
+	}
+	if len(what) == 0 {
+		return indicator(v)
+	}
+	return indicator(v) + "(" + what + ")"
+}
@@ -0,0 +1,101 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+// Exclusion is a window whose steps were masked in a fetch.
+type Exclusion struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+	// Steps is how many steps of the dataset the window masked.
+	Steps int `json:"steps"`
+}
+
+// exclusions resolves the configured exclusions into the windows that
+// overlap start to end, padded and with annotations looked up.
+func (s *Service) exclusions(ctx context.Context, start time.Time, end time.Time) ([]Exclusion, error) {
+	var windows []Exclusion
+
+	for _, e := range s.options.Exclusions {
+		if e.Source == nil {
+			windows = append(windows, Exclusion{
+				Start:  e.Start.UTC().Add(-e.Padding),
+				End:    e.End.UTC().Add(e.Padding),
+				Reason: e.Reason,
+			})
+			continue
+		}
+
+		a, ok := sourceClient(s.options.Annotators, e.Source)
+		if !ok {
+			return nil, fmt.Errorf("no annotator for annotations implementation '%s' at '%s'", e.Source.Impl, e.Source.Loc)
+		}
+
+		annotations, err := a.Annotations(ctx, e.Tags, start.Add(-e.Padding), end.Add(e.Padding))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch exclusions from '%s': %w", e.Source.Impl, err)
+		}
+
+		for _, an := range annotations {
+			reason := e.Reason
+			if len(reason) == 0 {
+				reason, _, _ = strings.Cut(an.Text, "\n")
+			}
+			windows = append(windows, Exclusion{
+				Start:  an.Start.UTC().Add(-e.Padding),
+				End:    an.End.UTC().Add(e.Padding),
+				Reason: reason,
+			})
+		}
+	}
+
+	var overlapping []Exclusion
+	for _, w := range windows {
+		if w.End.Before(start) || w.Start.After(end) {
+			continue
+		}
+		overlapping = append(overlapping, w)
+	}
+
+	return overlapping, nil
+}
+
+// mask sets every value of the steps that the windows touch to missing and
+// breaks ds where each run of them starts and ends, so that no lagged row
+// of the analysis pairs a value from before an excluded window with one from
+// after it, and no transform fills the masked steps back in. A step is
+// touched when its bucket overlaps a window. It returns which steps were
+// masked.
+func mask(ds *dataset.Dataset, windows []Exclusion) []bool {
+	excluded := make([]bool, ds.Len())
+
+	for i := range windows {
+		w := &windows[i]
+		for t, ts := range ds.Timestamps {
+			if !ts.Add(ds.Step).After(w.Start) || ts.After(w.End) {
+				continue
+			}
+			w.Steps++
+			excluded[t] = true
+			for c := range ds.Columns {
+				ds.Columns[c].Values[t] = math.NaN()
+			}
+		}
+	}
+
+	for t := 1; t < len(excluded); t++ {
+		if excluded[t-1] != excluded[t] {
+			ds.Breaks = append(ds.Breaks, t)
+		}
+	}
+
+	return excluded
+}
@@ -0,0 +1,115 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+// fillPolicy is v's configured policy, or the default: missing trace call
+// counts are zero calls, everything else carries the last value forward.
+func fillPolicy(v variable.VariableDefinition) variable.FillPolicy {
+	if v.Fill != nil {
+		return *v.Fill
+	}
+	if v.Source.Type == "traces" && v.TraceQuery != nil && v.TraceQuery.Dimension == "calls" {
+		return variable.FillPolicy{Strategy: variable.FillZero}
+	}
+	if v.Source.Type == "events" {
+		return variable.FillPolicy{Strategy: variable.FillZero}
+	}
+	return variable.FillPolicy{Strategy: variable.FillForward}
+}
+
+func describeFill(p variable.FillPolicy) string {
+	if p.MaxGap > 0 {
+		return fmt.Sprintf("%s(max_gap=%d)", p.Strategy, p.MaxGap)
+	}
+	return p.Strategy
+}
+
+// fillValues fills the gaps of one column by policy p. Drop policies leave
+// the gaps for dropRows.
+func fillValues(values []float64, p variable.FillPolicy) []float64 {
+	switch p.Strategy {
+	case variable.FillForward:
+		return dataset.FillForward(values, p.MaxGap)
+	case variable.FillLinear:
+		return dataset.FillLinear(values, p.MaxGap)
+	case variable.FillZero:
+		return dataset.FillConstant(values, 0)
+	case variable.FillMean:
+		return dataset.FillConstant(values, dataset.Mean(values))
+	default:
+		return values
+	}
+}
+
+// dropRows blanks every row where a variable with a drop policy was missing
+// before filling, so that the time grid stays even.
+func dropRows(ds *dataset.Dataset, vars []variable.VariableDefinition, raw [][]float64) {
+	for i, v := range vars {
+		if fillPolicy(v).Strategy != variable.FillDrop {
+			continue
+		}
+		for t, val := range raw[i] {
+			if !dataset.Missing(val) {
+				continue
+			}
+			for c := range ds.Columns {
+				ds.Columns[c].Values[t] = math.NaN()
+			}
+		}
+	}
+}
+
+// sparsity describes how much of one column was missing before filling.
+type sparsity struct {
+	name       string
+	missing    int
+	total      int
+	longestGap int
+}
+
+func (s sparsity) ratio() float64 {
+	if s.total == 0 {
+		return 0
+	}
+	return float64(s.missing) / float64(s.total)
+}
+
+// checkSparsity fails if any of the unfilled columns is missing more than
+// maxRatio of its steps, listing every such column.
+func checkSparsity(names []string, raw [][]float64, maxRatio float64) error {
+	if maxRatio <= 0 {
+		return nil
+	}
+
+	var sparse []sparsity
+	for i, values := range raw {
+		s := sparsity{name: names[i], total: len(values)}
+		for _, g := range dataset.Gaps(values) {
+			s.missing += g.Len()
+			s.longestGap = max(s.longestGap, g.Len())
+		}
+		if s.ratio() > maxRatio {
+			sparse = append(sparse, s)
+		}
+	}
+
+	if len(sparse) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d variables are missing more than %.1f%% of steps:", len(sparse), 100*maxRatio)
+	for _, s := range sparse {
+		fmt.Fprintf(&b, "\n  - %s: %.1f%% missing (%d of %d steps, longest gap %d steps)", s.name, 100*s.ratio(), s.missing, s.total, s.longestGap)
+	}
+
+	return errors.New(b.String())
+}
@@ -0,0 +1,120 @@
+package orchestrator
+
+import (
+	"context"
+	"log"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/annotator"
+)
+
+type Option func(*Options)
+
+type Options struct {
+	Concurrency     int
+	ImplConcurrency map[string]int
+	MaxMissingRatio float64
+	QualityRules    variable.QualityRules
+	QualityReport   func(*QualityReport)
+	MakeStationary  bool
+	Exclusions      []variable.Exclusion
+	Annotators      map[string]annotator.Annotator
+	Logger          *log.Logger
+	Progress        func(Progress)
+	Context         context.Context
+}
+
+// WithConcurrency bounds the number of fetches in flight across all sources.
+// A value <= 0 removes the bound.
+func WithConcurrency(n int) Option {
+	return func(o *Options) {
+		o.Concurrency = n
+	}
+}
+
+// WithImplConcurrency bounds the number of fetches in flight against a single
+// source implementation (e.g., "prometheus"), on top of the global bound.
+func WithImplConcurrency(impl string, n int) Option {
+	return func(o *Options) {
+		o.ImplConcurrency[impl] = n
+	}
+}
+
+// WithMaxMissingRatio fails a fetch when any variable is missing more than
+// this fraction of its steps before filling. A value <= 0 removes the check.
+func WithMaxMissingRatio(r float64) Option {
+	return func(o *Options) {
+		o.MaxMissingRatio = r
+	}
+}
+
+// WithQualityRules fails a fetch whose data breaks any of rules.
+func WithQualityRules(rules variable.QualityRules) Option {
+	return func(o *Options) {
+		o.QualityRules = rules
+	}
+}
+
+// WithQualityReport hands the data quality report of every fetch to fn
+// before the rules are checked, e.g. to print it.
+func WithQualityReport(fn func(*QualityReport)) Option {
+	return func(o *Options) {
+		o.QualityReport = fn
+	}
+}
+
+// WithMakeStationary detrends or differences every column that the
+// stationarity tests find non-stationary.
+func WithMakeStationary(b bool) Option {
+	return func(o *Options) {
+		o.MakeStationary = b
+	}
+}
+
+// WithExclusions masks the steps of every exclusion window in a fetch.
+func WithExclusions(exclusions ...variable.Exclusion) Option {
+	return func(o *Options) {
+		o.Exclusions = append(o.Exclusions, exclusions...)
+	}
+}
+
+// WithAnnotator looks up the exclusion windows of annotations sources with
+// a. key is SourceKey(impl, loc) for a single source, or an impl (e.g.,
+// "grafana") for every source of it.
+func WithAnnotator(key string, a annotator.Annotator) Option {
+	return func(o *Options) {
+		o.Annotators[key] = a
+	}
+}
+
+// WithLogger writes the log of a run to l instead of the standard logger,
+// e.g. to keep the runs of a server apart.
+func WithLogger(l *log.Logger) Option {
+	return func(o *Options) {
+		o.Logger = l
+	}
+}
+
+// WithProgress hands fn the progress of a run after every fetch and around
+// discovery and estimation.
+func WithProgress(fn func(Progress)) Option {
+	return func(o *Options) {
+		o.Progress = fn
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Concurrency:     8,
+		ImplConcurrency: map[string]int{},
+		Annotators:      map[string]annotator.Annotator{},
+		Logger:          log.Default(),
+		Context:         context.Background(),
+	}
+
+	for _, fn := range opts {
+		fn(&options)
+	}
+
+	return options
+}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
-	"log"
+	"math"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
@@ -14,12 +18,27 @@ import (
 	"github.com/w-h-a/caus/internal/client/discoverer"
 	"github.com/w-h-a/caus/internal/client/estimator"
 	"github.com/w-h-a/caus/internal/client/fetcher"
+	"github.com/w-h-a/caus/internal/config"
+	"github.com/w-h-a/caus/internal/dataset"
 )
 
 type Service struct {
 	fetchers   map[string]map[string]fetcher.Fetcher
 	discoverer discoverer.Discoverer
 	estimator  estimator.Estimator
+	options    Options
+}
+
+// Fetch runs the scatter and stitch steps only and returns the aligned
+// dataset.
+func (s *Service) Fetch(
+	ctx context.Context,
+	vars []variable.VariableDefinition,
+	start time.Time,
+	end time.Time,
+	step time.Duration,
+) (*dataset.Dataset, error) {
+	return s.fetch(ctx, vars, start, end, step)
 }
 
 func (s *Service) Discover(
@@ -31,17 +50,26 @@ func (s *Service) Discover(
 	discoveryArgs DiscoveryArgs,
 ) (*causal.CausalGraph, error) {
 	// 1. fetch and stitch
-	csvData, err := s.fetch(ctx, vars, start, end, step)
+	ds, err := s.fetch(ctx, vars, start, end, step)
 	if err != nil {
 		return nil, err
 	}
 
 	// 2. discover direct causes
-	graph, err := s.discover(ctx, csvData, discoveryArgs)
+	return s.DiscoverDataset(ctx, ds, discoveryArgs)
+}
+
+// DiscoverDataset runs discovery on an already aligned dataset, e.g. one
+// exported by Fetch, without touching any fetcher.
+func (s *Service) DiscoverDataset(ctx context.Context, ds *dataset.Dataset, discoveryArgs DiscoveryArgs) (*causal.CausalGraph, error) {
+	graph, err := s.discover(ctx, ds, discoveryArgs)
 	if err != nil {
 		return nil, err
 	}
 
+	graph.Window = window(ds)
+	annotate(graph, ds)
+
 	return graph, nil
 }
 
@@ -53,139 +81,495 @@ func (s *Service) Estimate(
 	step time.Duration,
 	estimateArgs EstimateArgs,
 ) (*causal.EstimateResponse, error) {
+	// refuse a bad graph before querying anything
+	graph, err := s.orientedGraph(estimateArgs.Graph, estimateArgs.AllowUnoriented)
+	if err != nil {
+		return nil, err
+	}
+	estimateArgs.Graph = graph
+
 	// 1. fetch and stitch
-	csvData, err := s.fetch(ctx, vars, start, end, step)
+	ds, err := s.fetch(ctx, vars, start, end, step)
 	if err != nil {
 		return nil, err
 	}
 
 	// 2. do counterfactual prediction
-	result, err := s.estimate(ctx, csvData, estimateArgs)
+	return s.estimateOriented(ctx, ds, estimateArgs)
+}
+
+// EstimateDataset fits the graph to an already aligned dataset, e.g. one
+// exported by Fetch, without touching any fetcher.
+func (s *Service) EstimateDataset(ctx context.Context, ds *dataset.Dataset, estimateArgs EstimateArgs) (*causal.EstimateResponse, error) {
+	graph, err := s.orientedGraph(estimateArgs.Graph, estimateArgs.AllowUnoriented)
 	if err != nil {
 		return nil, err
 	}
+	estimateArgs.Graph = graph
+
+	return s.estimateOriented(ctx, ds, estimateArgs)
+}
+
+// estimateOriented fits estimateArgs.Graph, which orientedGraph has already
+// checked, to ds.
+func (s *Service) estimateOriented(ctx context.Context, ds *dataset.Dataset, estimateArgs EstimateArgs) (*causal.EstimateResponse, error) {
+	graph := estimateArgs.Graph
+
+	if missing := missingNodes(ds, graph); len(missing) > 0 {
+		return nil, fmt.Errorf("dataset has no column for graph nodes: %s", strings.Join(missing, ", "))
+	}
+
+	result, err := s.estimate(ctx, ds, estimateArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Window = window(ds)
+	result.Nodes = nodes(ds)
 
 	return result, nil
 }
 
-func (s *Service) fetch(ctx context.Context, vars []variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) ([]byte, error) {
-	start = start.UTC().Truncate(step).Truncate(0)
-	end = end.UTC().Truncate(step).Truncate(0)
+func (s *Service) WhatIf(
+	ctx context.Context,
+	vars []variable.VariableDefinition,
+	start time.Time,
+	end time.Time,
+	step time.Duration,
+	whatIfArgs WhatIfArgs,
+) (*WhatIfResult, error) {
+	graph, err := s.orientedGraph(whatIfArgs.Graph, whatIfArgs.AllowUnoriented)
+	if err != nil {
+		return nil, err
+	}
 
-	results := make(map[string]map[time.Time]float64)
+	// 1. fetch and stitch
+	ds, err := s.fetch(ctx, vars, start, end, step)
+	if err != nil {
+		return nil, err
+	}
 
-	// 1. scatter
-	for _, v := range vars {
-		log.Printf("ORCHESTRATOR: Fetching '%s' from %s...", v.Name, v.Source.Loc)
+	// 2. fit the structural equations
+	fitted, err := s.estimate(ctx, ds, EstimateArgs{Graph: graph})
+	if err != nil {
+		return nil, err
+	}
 
-		var dataFetcher fetcher.Fetcher
+	// 3. replay history under the interventions
+	result, err := simulate(ds, fitted.Models, whatIfArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate interventions: %w", err)
+	}
 
-		impls, ok := s.fetchers[v.Source.Type]
-		if !ok {
-			return nil, fmt.Errorf("unknown source type '%s' for variable '%s'", v.Source.Type, v.Name)
-		}
+	return result, nil
+}
 
-		dataFetcher, ok = impls[v.Source.Impl]
-		if !ok {
-			return nil, fmt.Errorf("unknown %s implementation '%s' for variable '%s'", v.Source.Type, v.Source.Impl, v.Name)
-		}
+func (s *Service) fetch(ctx context.Context, vars []variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (*dataset.Dataset, error) {
+	start, end = align(start, end, step)
 
-		series, err := dataFetcher.Fetch(ctx, v, start, end, step)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch '%s': %w", v.Name, err)
-		}
+	order, err := config.EvaluationOrder(vars)
+	if err != nil {
+		return nil, err
+	}
 
-		results[v.Name] = series
+	excluded, err := s.exclusions(ctx, start, end)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. stitch
-	var buf bytes.Buffer
-	writer := csv.NewWriter(&buf)
+	// 1. scatter
+	fetched, err := s.scatter(ctx, vars, start, end, step)
+	if err != nil {
+		return nil, err
+	}
 
-	// make the header row
-	header := make([]string, len(vars))
-	for i, v := range vars {
-		header[i] = v.Name
+	vars, results, series, err := s.expand(vars, fetched)
+	if err != nil {
+		return nil, err
 	}
-	if err := writer.Write(header); err != nil {
+
+	// resample every fetched variable onto the step grid
+	resampled := map[string]resampling{}
+	for _, v := range vars {
+		if v.Derived() {
+			continue
+		}
+		var r resampling
+		results[v.Name], r = resample(results[v.Name], start, end, step, resamplePolicy(v))
+		resampled[v.Name] = r
+	}
+
+	indicate(vars, results)
+
+	if order, err = config.EvaluationOrder(vars); err != nil {
 		return nil, err
 	}
 
-	// keep track of the last known for each column
-	lastKnown := make([]string, len(vars))
-	for i := range lastKnown {
-		lastKnown[i] = "0.0"
+	// 2. stitch
+	ds := &dataset.Dataset{
+		Step:    step,
+		Columns: make([]dataset.Column, len(vars)),
+	}
+	for i, v := range vars {
+		ds.Columns[i] = dataset.Column{Name: v.Name, Provenance: provenance(v)}
+		if c, ok := series[v.Name]; ok {
+			ds.Columns[i].Provenance.Series, ds.Columns[i].Provenance.Variable = c.series, c.from
+		}
 	}
 
-	// iterate over steps
+	// iterate over steps, leaving the gaps as NaN
 	current := start.Truncate(step)
 	endTime := end.Truncate(step)
 
 	for !current.After(endTime) {
-		// make the rows
-		row := make([]string, len(vars))
+		ds.Timestamps = append(ds.Timestamps, current)
+		// fill the row
 		for i, v := range vars {
 			val, ok := results[v.Name][current]
 			if !ok {
-				zeroOK := v.Source.Type == "traces" && v.TraceQuery != nil && v.TraceQuery.Dimension == "calls"
-				if zeroOK {
-					row[i] = "0.0"
-					lastKnown[i] = "0.0"
-				} else {
-					row[i] = lastKnown[i]
-				}
-			} else {
-				val := strconv.FormatFloat(val, 'f', 6, 64)
-				row[i] = val
-				lastKnown[i] = val
+				val = math.NaN()
+			}
+			ds.Columns[i].Values = append(ds.Columns[i].Values, val)
+		}
+		// add a step
+		current = current.Add(step)
+	}
+
+	// 3. derive and fill the gaps, each variable after those it's derived from
+	raw := make([][]float64, len(ds.Columns))
+	for _, i := range order {
+		if vars[i].Derived() {
+			ds.Columns[i].Values = derive(ds, vars[i])
+		}
+		raw[i] = slices.Clone(ds.Columns[i].Values)
+		ds.Columns[i].Values = fillValues(ds.Columns[i].Values, fillPolicy(vars[i]))
+	}
+
+	dropRows(ds, vars, raw)
+
+	// 4. mask the excluded windows, report on the quality of what's left
+	// and transform
+	masked := mask(ds, excluded)
+
+	report := quality(vars, resampled, raw, ds, masked)
+	report.Violations = violations(report, s.options.QualityRules)
+	report.Excluded = excluded
+
+	transform(ds, vars)
+
+	// 5. diagnose and, if asked, remedy non-stationary columns
+	checkStationarity(ds, report, s.options.MakeStationary)
+
+	if s.options.QualityReport != nil {
+		s.options.QualityReport(report)
+	}
+
+	// 6. check the quality
+	if err := checkSparsity(ds.Names(), raw, s.options.MaxMissingRatio); err != nil {
+		return nil, err
+	}
+
+	if err := checkQuality(report); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// align snaps the window onto the step grid every fetcher buckets by.
+func align(start time.Time, end time.Time, step time.Duration) (time.Time, time.Time) {
+	return start.UTC().Truncate(step).Truncate(0), end.UTC().Truncate(step).Truncate(0)
+}
+
+// window records the range of ds so that results can be reproduced.
+func window(ds *dataset.Dataset) *causal.Window {
+	if len(ds.Timestamps) == 0 {
+		return nil
+	}
+	return &causal.Window{
+		Start: ds.Timestamps[0].UTC().Format(time.RFC3339),
+		End:   ds.Timestamps[len(ds.Timestamps)-1].UTC().Format(time.RFC3339),
+		Step:  ds.Step.String(),
+	}
+}
+
+// missingNodes lists the graph nodes and edge endpoints that ds has no
+// column for.
+func missingNodes(ds *dataset.Dataset, graph *causal.CausalGraph) []string {
+	var missing []string
+
+	check := func(name string) {
+		if ds.Index(name) < 0 && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+
+	for _, n := range graph.GetNodes() {
+		check(n.Label)
+	}
+	for _, e := range graph.GetEdges() {
+		check(e.Source)
+		check(e.Target)
+	}
+
+	return missing
+}
+
+// toCSV encodes ds the way the worker expects it: a header row of variable
+// names followed by one row per step, with an empty cell where a value is
+// missing.
+func toCSV(ds *dataset.Dataset) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(ds.Names()); err != nil {
+		return nil, err
+	}
+
+	row := make([]string, len(ds.Columns))
+	for t := 0; t < ds.Len(); t++ {
+		for i, c := range ds.Columns {
+			if dataset.Missing(c.Values[t]) {
+				row[i] = ""
+				continue
 			}
+			row[i] = strconv.FormatFloat(c.Values[t], 'f', 6, 64)
 		}
 		if err := writer.Write(row); err != nil {
 			return nil, err
 		}
-		// add a step
-		current = current.Add(step)
 	}
 
 	writer.Flush()
-	bs := buf.Bytes()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
 
-	return bs, nil
+	return buf.Bytes(), nil
 }
 
-func (s *Service) discover(ctx context.Context, csvData []byte, discovery DiscoveryArgs) (*causal.CausalGraph, error) {
+// breaks lists the rows of ds that start a new run, the way the requests
+// carry them.
+func breaks(ds *dataset.Dataset) []int32 {
+	var out []int32
+	for _, b := range ds.Breaks {
+		out = append(out, int32(b))
+	}
+	return out
+}
+
+// scatter fetches every variable that isn't derived. Split variables get
+// all of their series, the others exactly one.
+func (s *Service) scatter(ctx context.Context, vars []variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) (map[string][]fetcher.Series, error) {
+	// resolve every fetcher before issuing any query
+	dataFetchers := make([]fetcher.Fetcher, len(vars))
+	for i, v := range vars {
+		if v.Derived() {
+			continue
+		}
+
+		impls, ok := s.fetchers[v.Source.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown source type '%s' for variable '%s'", v.Source.Type, v.Name)
+		}
+
+		dataFetcher, ok := sourceClient(impls, v.Source)
+		if !ok {
+			return nil, fmt.Errorf("unknown %s implementation '%s' for variable '%s'", v.Source.Type, v.Source.Impl, v.Name)
+		}
+
+		if _, ok := dataFetcher.(fetcher.MultiFetcher); v.Split() && !ok {
+			return nil, fmt.Errorf("%s implementation '%s' can't split variable '%s' by series", v.Source.Type, v.Source.Impl, v.Name)
+		}
+
+		dataFetchers[i] = dataFetcher
+	}
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	global := newSemaphore(s.options.Concurrency)
+	perImpl := map[string]semaphore{}
+	for impl, n := range s.options.ImplConcurrency {
+		perImpl[impl] = newSemaphore(n)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mtx     sync.Mutex
+		results = make(map[string][]fetcher.Series, len(vars))
+		errs    = make([]error, len(vars))
+		done    int
+		total   int
+	)
+
+	for _, f := range dataFetchers {
+		if f != nil {
+			total++
+		}
+	}
+	s.progress(StageFetch, 0, total)
+
+	for i, v := range vars {
+		if v.Derived() {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := acquire(fetchCtx, perImpl[v.Source.Impl], global)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer release()
+
+			s.options.Logger.Printf("ORCHESTRATOR: Fetching '%s' from %s...", v.Name, v.Source.Loc)
+
+			series, err := fetchSeries(fetchCtx, dataFetchers[i], v, start, end, step)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to fetch '%s': %w", v.Name, err)
+				// a hard error makes the run useless so stop everyone else
+				cancel()
+				return
+			}
+
+			mtx.Lock()
+			results[v.Name] = series
+			done++
+			s.progress(StageFetch, done, total)
+			mtx.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	// only report errors that weren't caused by our own cancellation
+	var failed []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, context.Canceled) && ctx.Err() == nil {
+			continue
+		}
+		failed = append(failed, err)
+	}
+
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to fetch %d of %d variables:\n%w", len(failed), len(vars), errors.Join(failed...))
+	}
+
+	return results, nil
+}
+
+func fetchSeries(ctx context.Context, f fetcher.Fetcher, v variable.VariableDefinition, start time.Time, end time.Time, step time.Duration) ([]fetcher.Series, error) {
+	if v.Split() {
+		return f.(fetcher.MultiFetcher).FetchAll(ctx, v, start, end, step)
+	}
+
+	points, err := f.Fetch(ctx, v, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	return []fetcher.Series{{Points: points}}, nil
+}
+
+// discover hands ds to the discoverer as is when it can take a dataset and
+// as CSV otherwise.
+func (s *Service) discover(ctx context.Context, ds *dataset.Dataset, discovery DiscoveryArgs) (*causal.CausalGraph, error) {
 	req := &causal.DiscoverRequest{
-		CsvData: string(csvData),
 		MaxLag:  discovery.MaxLag,
 		PcAlpha: discovery.PcAlpha,
+		Breaks:  breaks(ds),
 	}
 
-	graph, err := s.discoverer.Discover(ctx, req)
+	var graph *causal.CausalGraph
+	var err error
+
+	s.progress(StageDiscover, 0, 1)
+
+	if d, ok := s.discoverer.(discoverer.DatasetDiscoverer); ok {
+		graph, err = d.DiscoverDataset(ctx, ds, req)
+	} else {
+		var csvData []byte
+		if csvData, err = toCSV(ds); err != nil {
+			return nil, err
+		}
+		req.CsvData = string(csvData)
+		graph, err = s.discoverer.Discover(ctx, req)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover causes: %w", err)
 	}
 
+	s.progress(StageDiscover, 1, 1)
+
+	if discovery.MinStrength > 0 {
+		// A backend that doesn't report partial correlations leaves them all
+		// at zero, which would silently prune every edge.
+		if len(graph.Edges) > 0 && !slices.ContainsFunc(graph.Edges, func(e *causal.Edge) bool { return e.PartialCorr != 0 }) {
+			return nil, fmt.Errorf("failed to apply min strength %g: the discoverer reported no edge strengths", discovery.MinStrength)
+		}
+		graph.Edges = slices.DeleteFunc(graph.Edges, func(e *causal.Edge) bool {
+			return math.Abs(float64(e.PartialCorr)) < float64(discovery.MinStrength)
+		})
+	}
+
 	return graph, nil
 }
 
-func (s *Service) estimate(ctx context.Context, csvData []byte, estimation EstimateArgs) (*causal.EstimateResponse, error) {
+// estimate hands ds to the estimator as is when it can take a dataset and as
+// CSV otherwise.
+func (s *Service) estimate(ctx context.Context, ds *dataset.Dataset, estimation EstimateArgs) (*causal.EstimateResponse, error) {
 	req := &causal.EstimateRequest{
-		CsvData: string(csvData),
-		Graph:   estimation.Graph,
+		Graph:           estimation.Graph,
+		ConfidenceLevel: estimation.ConfidenceLevel,
+		Breaks:          breaks(ds),
+	}
+
+	if estimation.Bootstrap.Samples > 0 {
+		req.Bootstrap = &causal.Bootstrap{
+			Samples:   estimation.Bootstrap.Samples,
+			BlockSize: estimation.Bootstrap.BlockSize,
+			Seed:      estimation.Bootstrap.Seed,
+		}
 	}
 
-	rsp, err := s.estimator.Estimate(ctx, req)
+	var rsp *causal.EstimateResponse
+	var err error
+
+	s.progress(StageEstimate, 0, 1)
+
+	if e, ok := s.estimator.(estimator.DatasetEstimator); ok {
+		rsp, err = e.EstimateDataset(ctx, ds, req)
+	} else {
+		var csvData []byte
+		if csvData, err = toCSV(ds); err != nil {
+			return nil, err
+		}
+		req.CsvData = string(csvData)
+		rsp, err = s.estimator.Estimate(ctx, req)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform estimation: %w", err)
 	}
 
+	s.progress(StageEstimate, 1, 1)
+
 	return rsp, nil
 }
 
-func New(fs map[string]map[string]fetcher.Fetcher, d discoverer.Discoverer, e estimator.Estimator) *Service {
+func New(fs map[string]map[string]fetcher.Fetcher, d discoverer.Discoverer, e estimator.Estimator, opts ...Option) *Service {
+	options := NewOptions(opts...)
+
 	return &Service{
 		fetchers:   fs,
 		discoverer: d,
 		estimator:  e,
+		options:    options,
 	}
 }
@@ -0,0 +1,22 @@
+package orchestrator
+
+const (
+	StageFetch    = "fetch"
+	StageDiscover = "discover"
+	StageEstimate = "estimate"
+)
+
+// Progress is how far a run has come: Done of the Total steps of its current
+// Stage. A fetch has a step per fetched variable; discovery and estimation
+// have a single one.
+type Progress struct {
+	Stage string `json:"stage"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+func (s *Service) progress(stage string, done int, total int) {
+	if s.options.Progress != nil {
+		s.options.Progress(Progress{Stage: stage, Done: done, Total: total})
+	}
+}
@@ -0,0 +1,71 @@
+package orchestrator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+// provenance describes where v's data comes from without any credentials.
+func provenance(v variable.VariableDefinition) dataset.Provenance {
+	p := dataset.Provenance{
+		Type:       v.Source.Type,
+		Impl:       v.Source.Impl,
+		Loc:        redactLoc(v.Source.Loc),
+		Fill:       describeFill(fillPolicy(v)),
+		Transforms: describeTransforms(v.Transforms),
+	}
+
+	if !v.Derived() {
+		p.Resample = describeResample(resamplePolicy(v))
+	}
+
+	switch {
+	case v.Derived():
+		p.Query = v.Expression
+	case len(v.MetricsQuery) > 0:
+		p.Query = v.MetricsQuery
+	case v.TraceQuery != nil:
+		p.Query = describeTraceQuery(v.TraceQuery)
+	case v.EventQuery != nil:
+		p.Query = describeEventQuery(v)
+	}
+
+	return p
+}
+
+// redactLoc drops the user info of URL-like locations such as ClickHouse
+// DSNs so that exported files never carry passwords.
+func redactLoc(loc string) string {
+	u, err := url.Parse(loc)
+	if err != nil || u.User == nil {
+		return loc
+	}
+	u.User = nil
+	return u.String()
+}
+
+func describeTraceQuery(q *variable.TraceQueryDetails) string {
+	parts := []string{
+		"service=" + q.ServiceName,
+		"dimension=" + q.Dimension,
+	}
+
+	if len(q.AggregationOption) > 0 {
+		parts = append(parts, "aggregation="+q.AggregationOption)
+	}
+	if len(q.SpanName) > 0 {
+		parts = append(parts, "span_name="+q.SpanName)
+	}
+	if len(q.SpanKind) > 0 {
+		parts = append(parts, "span_kind="+q.SpanKind)
+	}
+	for _, a := range q.AttributeQueries {
+		parts = append(parts, fmt.Sprintf("%s %s %s", a.Key, a.Operator, a.Value))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,178 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/stats"
+)
+
+// outlierScore is the robust z-score beyond which an observation counts as
+// an outlier (Iglewicz and Hoaglin).
+const outlierScore = 3.5
+
+// QualityReport describes what the fetchers returned for each variable and
+// what the stitch step made of it.
+type QualityReport struct {
+	// Steps is how many steps are left outside the excluded windows. The
+	// per-step counts of each variable cover only those, its point counts
+	// the whole fetch.
+	Steps      int               `json:"steps"`
+	Variables  []VariableQuality `json:"variables"`
+	Violations []string          `json:"violations,omitempty"`
+	// Excluded are the exclusion windows that overlap the fetch.
+	Excluded []Exclusion `json:"excluded,omitempty"`
+}
+
+type VariableQuality struct {
+	Variable string `json:"variable"`
+	Fill     string `json:"fill"`
+	// Resolution is the native resolution of the fetched points.
+	Resolution string `json:"resolution,omitempty"`
+	// Points is how many points the fetcher returned.
+	Points int `json:"points"`
+	// Unused points are outside the window and are ignored.
+	Unused int `json:"unused"`
+	// Duplicates are points that fall into a step another point already
+	// occupies although the native resolution is no finer than the step.
+	Duplicates int `json:"duplicates"`
+	// Upsampled is how many steps were filled in from a coarser point.
+	Upsampled int `json:"upsampled"`
+	// Observed is how many steps have a fetched value.
+	Observed int `json:"observed"`
+	// Filled is how many steps the fill policy imputed.
+	Filled int `json:"filled"`
+	// Missing is how many steps are still missing after filling.
+	Missing int `json:"missing"`
+	// Outliers is how many observed values lie more than 3.5 robust
+	// standard deviations from the median.
+	Outliers int `json:"outliers"`
+	// Constant is set when the filled series has no variance.
+	Constant bool `json:"constant"`
+	// Stationarity is diagnosed on the transformed series.
+	Stationarity *Stationarity `json:"stationarity,omitempty"`
+}
+
+// quality builds the report from how the fetched points were resampled,
+// the stitched columns before filling and the filled and masked dataset,
+// skipping the masked steps.
+func quality(vars []variable.VariableDefinition, resampled map[string]resampling, raw [][]float64, ds *dataset.Dataset, masked []bool) *QualityReport {
+	report := &QualityReport{
+		Variables: make([]VariableQuality, len(vars)),
+	}
+
+	for _, m := range masked {
+		if !m {
+			report.Steps++
+		}
+	}
+
+	for i, v := range vars {
+		q := VariableQuality{
+			Variable: v.Name,
+			Fill:     describeFill(fillPolicy(v)),
+		}
+
+		// what came back from the fetcher
+		if r, ok := resampled[v.Name]; ok {
+			q.Resolution = r.resolution.String()
+			q.Points, q.Unused, q.Duplicates, q.Upsampled = r.points, r.unused, r.duplicates, r.upsampled
+		}
+
+		// what the stitch and fill steps made of it
+		var observed []float64
+		for t, val := range raw[i] {
+			if masked[t] {
+				continue
+			}
+			filled := ds.Columns[i].Values[t]
+			switch {
+			case !dataset.Missing(val):
+				q.Observed++
+				observed = append(observed, val)
+			case !dataset.Missing(filled):
+				q.Filled++
+			}
+			if dataset.Missing(filled) {
+				q.Missing++
+			}
+		}
+
+		for _, z := range stats.RobustZScores(observed) {
+			if math.Abs(z) > outlierScore {
+				q.Outliers++
+			}
+		}
+
+		q.Constant = constant(ds.Columns[i].Values)
+
+		report.Variables[i] = q
+	}
+
+	return report
+}
+
+// constant reports whether values has fewer than two distinct observations.
+func constant(values []float64) bool {
+	seen := math.NaN()
+	for _, v := range values {
+		if dataset.Missing(v) {
+			continue
+		}
+		if dataset.Missing(seen) {
+			seen = v
+			continue
+		}
+		if v != seen {
+			return false
+		}
+	}
+	return true
+}
+
+// violations lists every breach of rules in report.
+func violations(report *QualityReport, rules variable.QualityRules) []string {
+	var broken []string
+
+	ratio := func(n int) float64 {
+		if report.Steps == 0 {
+			return 0
+		}
+		return float64(n) / float64(report.Steps)
+	}
+
+	for _, q := range report.Variables {
+		if rules.FailOnConstant && q.Constant {
+			broken = append(broken, fmt.Sprintf("%s: constant (zero variance)", q.Variable))
+		}
+		if r := ratio(q.Filled); rules.MaxFilledRatio > 0 && r > rules.MaxFilledRatio {
+			broken = append(broken, fmt.Sprintf("%s: %.1f%% of steps filled (max %.1f%%)", q.Variable, 100*r, 100*rules.MaxFilledRatio))
+		}
+		if r := ratio(q.Outliers); rules.MaxOutlierRatio > 0 && r > rules.MaxOutlierRatio {
+			broken = append(broken, fmt.Sprintf("%s: %.1f%% of steps are outliers (max %.1f%%)", q.Variable, 100*r, 100*rules.MaxOutlierRatio))
+		}
+		if r := ratio(q.Duplicates); rules.MaxDuplicateRatio > 0 && r > rules.MaxDuplicateRatio {
+			broken = append(broken, fmt.Sprintf("%s: %.1f%% of steps have duplicate points (max %.1f%%)", q.Variable, 100*r, 100*rules.MaxDuplicateRatio))
+		}
+	}
+
+	return broken
+}
+
+func checkQuality(report *QualityReport) error {
+	if len(report.Violations) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "data quality check failed:")
+	for _, v := range report.Violations {
+		fmt.Fprintf(&b, "\n  - %s", v)
+	}
+
+	return errors.New(b.String())
+}
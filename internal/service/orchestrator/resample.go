@@ -0,0 +1,214 @@
+package orchestrator
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+)
+
+// resampling records how a variable's points were brought onto the grid.
+type resampling struct {
+	// resolution is the native resolution, the usual spacing of the points.
+	resolution time.Duration
+	points     int
+	// unused points lie outside the window.
+	unused int
+	// duplicates share a step with another point although the native
+	// resolution is no finer than the step.
+	duplicates int
+	// upsampled steps have no point of their own and were filled in from a
+	// coarser one.
+	upsampled int
+}
+
+// resamplePolicy fills in the defaults: counts are summed and everything
+// else averaged, timestamps mark the start of their interval and coarse
+// points are held.
+func resamplePolicy(v variable.VariableDefinition) variable.ResamplePolicy {
+	var p variable.ResamplePolicy
+	if v.Resample != nil {
+		p = *v.Resample
+	}
+
+	if len(p.Aggregation) == 0 {
+		p.Aggregation = variable.ResampleMean
+		if v.Source.Type == "events" || v.Source.Type == "traces" && v.TraceQuery != nil && v.TraceQuery.Dimension == "calls" {
+			p.Aggregation = variable.ResampleSum
+		}
+	}
+	if len(p.Label) == 0 {
+		p.Label = variable.LabelStart
+	}
+	if len(p.Upsample) == 0 {
+		p.Upsample = variable.UpsampleHold
+	}
+
+	return p
+}
+
+func describeResample(p variable.ResamplePolicy) string {
+	return p.Aggregation + "(label=" + p.Label + ", upsample=" + p.Upsample + ")"
+}
+
+// resample maps points onto the steps from start to end. Each point
+// describes the interval of the native resolution that starts or, with
+// label end, ends at its timestamp.
+func resample(points map[time.Time]float64, start time.Time, end time.Time, step time.Duration, p variable.ResamplePolicy) (map[time.Time]float64, resampling) {
+	times := make([]time.Time, 0, len(points))
+	for t := range points {
+		times = append(times, t.UTC())
+	}
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+
+	r := resampling{resolution: resolution(times, step), points: len(times)}
+
+	// intervalStart is where the interval a point describes begins
+	intervalStart := func(t time.Time) time.Time {
+		if p.Label == variable.LabelEnd {
+			return t.Add(-r.resolution)
+		}
+		return t
+	}
+
+	// 1. downsample: aggregate the points of each step
+	bucketed := map[time.Time][]float64{}
+	var order []time.Time
+	for _, t := range times {
+		from := intervalStart(t)
+		bucket := from.Truncate(step)
+		if p.Label == variable.LabelEnd && r.resolution < step {
+			// a fine interval lies in the step that contains its end
+			bucket = t.Add(-time.Nanosecond).Truncate(step)
+		}
+		if bucket.Before(start) || bucket.After(end) {
+			r.unused++
+			continue
+		}
+		if _, ok := bucketed[bucket]; !ok {
+			order = append(order, bucket)
+		} else if r.resolution >= step {
+			r.duplicates++
+		}
+		bucketed[bucket] = append(bucketed[bucket], points[t])
+	}
+
+	out := make(map[time.Time]float64, len(bucketed))
+	for _, bucket := range order {
+		if val := aggregateStep(bucketed[bucket], p.Aggregation); !math.IsNaN(val) {
+			out[bucket] = val
+		}
+	}
+
+	// 2. upsample: spread coarse points over the steps they cover
+	if r.resolution <= step || p.Upsample == variable.UpsampleNone {
+		return out, r
+	}
+
+	// a sum is spread evenly rather than repeated
+	scale := 1.0
+	if p.Aggregation == variable.ResampleSum {
+		scale = float64(step) / float64(r.resolution)
+	}
+	for bucket := range out {
+		out[bucket] *= scale
+	}
+
+	for i, t := range times {
+		val := points[t]
+		if math.IsNaN(val) {
+			continue
+		}
+
+		from := intervalStart(t)
+		next, hasNext := time.Time{}, false
+		if i+1 < len(times) && !math.IsNaN(points[times[i+1]]) {
+			next = intervalStart(times[i+1])
+			// further apart than one interval (and a half) is a real gap
+			hasNext = next.Sub(from) <= r.resolution+r.resolution/2
+		}
+
+		for bucket := from.Truncate(step).Add(step); bucket.Before(from.Add(r.resolution)); bucket = bucket.Add(step) {
+			if bucket.Before(start) || bucket.After(end) {
+				continue
+			}
+			if _, ok := out[bucket]; ok {
+				continue
+			}
+
+			filled := val
+			if p.Upsample == variable.UpsampleLinear {
+				if !hasNext {
+					continue
+				}
+				frac := float64(bucket.Sub(from)) / float64(next.Sub(from))
+				filled = val + frac*(points[times[i+1]]-val)
+			}
+
+			out[bucket] = filled * scale
+			r.upsampled++
+		}
+	}
+
+	return out, r
+}
+
+// resolution is the most common spacing of the sorted times, rounded to
+// the second. The mode rather than the median keeps gaps in the data from
+// coarsening it. A spacing seen only once is no evidence of a resolution,
+// so that falls back to the step.
+func resolution(times []time.Time, step time.Duration) time.Duration {
+	counts := map[time.Duration]int{}
+	for i := 1; i < len(times); i++ {
+		gap := times[i].Sub(times[i-1])
+		if gap >= time.Second {
+			gap = gap.Round(time.Second)
+		}
+		if gap > 0 {
+			counts[gap]++
+		}
+	}
+
+	var best time.Duration
+	for gap, n := range counts {
+		if n > counts[best] || n == counts[best] && gap < best {
+			best = gap
+		}
+	}
+
+	if counts[best] < 2 {
+		return step
+	}
+
+	return best
+}
+
+// aggregateStep combines the values of one step in time order, skipping
+// NaN. It is NaN when there is nothing to combine.
+func aggregateStep(vals []float64, how string) float64 {
+	var observed []float64
+	for _, val := range vals {
+		if !math.IsNaN(val) {
+			observed = append(observed, val)
+		}
+	}
+	if len(observed) == 0 {
+		return math.NaN()
+	}
+
+	switch how {
+	case variable.ResampleSum:
+		return sum(observed)
+	case variable.ResampleMin:
+		return slices.Min(observed)
+	case variable.ResampleMax:
+		return slices.Max(observed)
+	case variable.ResampleFirst:
+		return observed[0]
+	case variable.ResampleLast:
+		return observed[len(observed)-1]
+	default:
+		return sum(observed) / float64(len(observed))
+	}
+}
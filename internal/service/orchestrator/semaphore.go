@@ -0,0 +1,40 @@
+package orchestrator
+
+import "context"
+
+// semaphore is a counting semaphore. A nil semaphore never blocks.
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+	return make(semaphore, n)
+}
+
+// acquire takes a slot from each semaphore in order and returns a func that
+// gives them all back. It gives up as soon as ctx is done.
+func acquire(ctx context.Context, sems ...semaphore) (func(), error) {
+	var held []semaphore
+
+	release := func() {
+		for _, sem := range held {
+			<-sem
+		}
+	}
+
+	for _, sem := range sems {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			held = append(held, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
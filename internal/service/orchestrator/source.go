@@ -0,0 +1,22 @@
+package orchestrator
+
+import (
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+)
+
+// SourceKey is the key of a fetcher or annotator that reads only the source
+// of impl at loc, e.g. "file:deploys.yml". One keyed by the bare impl reads
+// every location of impl, e.g. a mock.
+func SourceKey(impl string, loc string) string {
+	return impl + ":" + loc
+}
+
+// sourceClient returns the client of clients that reads src: the one keyed
+// by its location, else the one keyed by its impl.
+func sourceClient[T any](clients map[string]T, src *variable.Source) (T, bool) {
+	if c, ok := clients[SourceKey(src.Impl, src.Loc)]; ok {
+		return c, true
+	}
+	c, ok := clients[src.Impl]
+	return c, ok
+}
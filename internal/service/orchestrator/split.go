@@ -0,0 +1,213 @@
+package orchestrator
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+)
+
+// defaultMaxSeries caps the variables a split variable fans out into, since
+// every variable adds to the cost of discovery.
+const defaultMaxSeries = 20
+
+// expand replaces every split variable with one variable per series and
+// collects the points of every variable by name, along with the series
+// behind each split one.
+func (s *Service) expand(vars []variable.VariableDefinition, fetched map[string][]fetcher.Series) ([]variable.VariableDefinition, map[string]map[time.Time]float64, map[string]splitSeries, error) {
+	var expanded []variable.VariableDefinition
+	results := map[string]map[time.Time]float64{}
+	series := map[string]splitSeries{}
+
+	for _, v := range vars {
+		if !v.Split() {
+			expanded = append(expanded, v)
+			if s := fetched[v.Name]; len(s) > 0 {
+				results[v.Name] = s[0].Points
+			}
+			continue
+		}
+
+		if len(fetched[v.Name]) == 0 {
+			s.options.Logger.Printf("ORCHESTRATOR: WARNING: '%s' returned no series to split", v.Name)
+			continue
+		}
+
+		for _, c := range s.split(v, fetched[v.Name]) {
+			expanded = append(expanded, c.variable)
+			results[c.variable.Name] = c.points
+			series[c.variable.Name] = c
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, v := range expanded {
+		if seen[v.Name] {
+			return nil, nil, nil, fmt.Errorf("more than one variable is named '%s' after splitting series (set series_name to tell them apart)", v.Name)
+		}
+		seen[v.Name] = true
+	}
+
+	return expanded, results, series, nil
+}
+
+type splitSeries struct {
+	variable variable.VariableDefinition
+	points   map[time.Time]float64
+	series   string
+	// from is the name of the split variable.
+	from string
+}
+
+// split turns each of v's series into a variable, keeping those with the
+// largest means and aggregating or dropping the rest.
+func (s *Service) split(v variable.VariableDefinition, all []fetcher.Series) []splitSeries {
+	means := make([]float64, len(all))
+	idx := make([]int, len(all))
+	for i, s := range all {
+		means[i], idx[i] = pointsMean(s.Points), i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int {
+		return cmp.Compare(means[b], means[a])
+	})
+
+	ranked := make([]fetcher.Series, len(all))
+	for i, j := range idx {
+		ranked[i] = all[j]
+	}
+
+	limit := v.MaxSeries
+	if limit == 0 {
+		limit = defaultMaxSeries
+	}
+	kept, tail := ranked, []fetcher.Series(nil)
+	if len(ranked) > limit {
+		kept, tail = ranked[:limit], ranked[limit:]
+	}
+
+	child := v
+	child.SplitBy, child.SeriesName, child.MaxSeries, child.OtherSeries = nil, "", 0, ""
+
+	var out []splitSeries
+	for _, s := range kept {
+		c := child
+		c.Name = seriesName(v, s.Labels)
+		out = append(out, splitSeries{variable: c, points: s.Points, series: describeSeries(v, s.Labels), from: v.Name})
+	}
+	slices.SortFunc(out, func(a, b splitSeries) int {
+		return strings.Compare(a.variable.Name, b.variable.Name)
+	})
+
+	switch {
+	case len(tail) == 0:
+	case len(v.OtherSeries) == 0:
+		s.options.Logger.Printf("ORCHESTRATOR: WARNING: '%s' returned %d series, dropping the %d with the smallest means (set other_series to keep them as '%s_other')", v.Name, len(ranked), len(tail), v.Name)
+	default:
+		c := child
+		c.Name = v.Name + "_other"
+		out = append(out, splitSeries{
+			variable: c,
+			points:   aggregate(tail, v.OtherSeries),
+			series:   fmt.Sprintf("other: %s of %d series", v.OtherSeries, len(tail)),
+			from:     v.Name,
+		})
+	}
+
+	return out
+}
+
+// seriesName fills in v's series_name template with the labels, or joins
+// v's name and the split_by label values.
+func seriesName(v variable.VariableDefinition, labels map[string]string) string {
+	if len(v.SeriesName) == 0 {
+		parts := []string{v.Name}
+		for _, label := range v.SplitBy {
+			parts = append(parts, sanitize(labels[label]))
+		}
+		return strings.Join(parts, "_")
+	}
+
+	var b strings.Builder
+	rest := v.SeriesName
+	for {
+		before, after, ok := strings.Cut(rest, "{{")
+		b.WriteString(before)
+		if !ok {
+			break
+		}
+		label, after, _ := strings.Cut(after, "}}")
+		b.WriteString(sanitize(labels[strings.TrimSpace(label)]))
+		rest = after
+	}
+
+	return b.String()
+}
+
+// sanitize keeps label values usable as variable names, e.g. in the
+// expressions of derived variables.
+func sanitize(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, value)
+}
+
+func describeSeries(v variable.VariableDefinition, labels map[string]string) string {
+	parts := make([]string, len(v.SplitBy))
+	for i, label := range v.SplitBy {
+		parts[i] = fmt.Sprintf("%s=%s", label, labels[label])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// aggregate combines the series at each time one of them has a point.
+func aggregate(series []fetcher.Series, how string) map[time.Time]float64 {
+	byTime := map[time.Time][]float64{}
+	for _, s := range series {
+		for t, val := range s.Points {
+			byTime[t] = append(byTime[t], val)
+		}
+	}
+
+	out := make(map[time.Time]float64, len(byTime))
+	for t, vals := range byTime {
+		switch how {
+		case "sum":
+			out[t] = sum(vals)
+		case "avg":
+			out[t] = sum(vals) / float64(len(vals))
+		case "min":
+			out[t] = slices.Min(vals)
+		case "max":
+			out[t] = slices.Max(vals)
+		}
+	}
+
+	return out
+}
+
+func pointsMean(points map[time.Time]float64) float64 {
+	if len(points) == 0 {
+		return math.Inf(-1)
+	}
+	total := 0.0
+	for _, val := range points {
+		total += val
+	}
+	return total / float64(len(points))
+}
+
+func sum(vals []float64) float64 {
+	total := 0.0
+	for _, val := range vals {
+		total += val
+	}
+	return total
+}
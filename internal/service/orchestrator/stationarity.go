@@ -0,0 +1,128 @@
+package orchestrator
+
+import (
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/stats"
+)
+
+// minStationaritySamples is the fewest observations the tests are run on.
+const minStationaritySamples = 20
+
+const (
+	VerdictStationary   = "stationary"
+	VerdictTrend        = "trend"
+	VerdictUnitRoot     = "unit_root"
+	VerdictLevelShift   = "level_shift"
+	VerdictInconclusive = "inconclusive"
+)
+
+const (
+	RemedyDetrend = "detrend"
+	RemedyDiff    = "diff"
+)
+
+// Stationarity combines an ADF test (null: unit root) and a KPSS test
+// (null: stationary) on one column.
+type Stationarity struct {
+	Verdict      string  `json:"verdict"`
+	ADF          float64 `json:"adf"`
+	ADFCritical  float64 `json:"adf_critical"`
+	KPSS         float64 `json:"kpss"`
+	KPSSCritical float64 `json:"kpss_critical"`
+	// Remedy is what would make the column stationary, if anything.
+	Remedy string `json:"remedy,omitempty"`
+	// Applied is set when the remedy was applied to the column.
+	Applied bool `json:"applied,omitempty"`
+}
+
+// diagnose tests the longest run of consecutive observed values of a column
+// for stationarity. Both tests regress each value on the ones before it, so
+// gaps are not spliced together as if their ends were one step apart.
+func diagnose(values []float64) *Stationarity {
+	obs := longestRun(values)
+
+	result := &Stationarity{Verdict: VerdictInconclusive}
+	if len(obs) < minStationaritySamples {
+		return result
+	}
+
+	adf, err := stats.ADF(obs)
+	if err != nil {
+		return result
+	}
+	kpss, err := stats.KPSS(obs, false)
+	if err != nil {
+		return result
+	}
+
+	result.ADF, result.ADFCritical = adf.Statistic, adf.Critical
+	result.KPSS, result.KPSSCritical = kpss.Statistic, kpss.Critical
+
+	unitRoot := adf.Statistic >= adf.Critical
+	levelStationary := kpss.Statistic <= kpss.Critical
+
+	switch {
+	case !unitRoot && levelStationary:
+		result.Verdict = VerdictStationary
+	case unitRoot && levelStationary:
+		// neither test is decisive, typically on short or noisy series
+	default:
+		if trend, err := stats.KPSS(obs, true); err == nil && trend.Statistic <= trend.Critical {
+			result.Verdict, result.Remedy = VerdictTrend, RemedyDetrend
+		} else if unitRoot {
+			result.Verdict, result.Remedy = VerdictUnitRoot, RemedyDiff
+		} else {
+			// mean-reverting but not around one level, e.g. deploy-driven
+			// level shifts
+			result.Verdict, result.Remedy = VerdictLevelShift, RemedyDiff
+		}
+	}
+
+	return result
+}
+
+// checkStationarity diagnoses every column of ds into report and, if
+// remedy is set, applies the remedies and records them as transforms.
+func checkStationarity(ds *dataset.Dataset, report *QualityReport, remedy bool) {
+	for i := range ds.Columns {
+		col := &ds.Columns[i]
+		result := diagnose(col.Values)
+
+		if remedy && len(result.Remedy) > 0 {
+			if values, err := applyRemedy(col.Values, result.Remedy); err == nil {
+				col.Values = values
+				col.Provenance.Transforms = append(col.Provenance.Transforms, result.Remedy)
+				result.Applied = true
+			}
+		}
+
+		report.Variables[i].Stationarity = result
+	}
+}
+
+func applyRemedy(values []float64, remedy string) ([]float64, error) {
+	switch remedy {
+	case RemedyDetrend:
+		return stats.Detrend(values, true)
+	case RemedyDiff:
+		return dataset.Diff(values, 1), nil
+	}
+	return values, nil
+}
+
+// longestRun returns the longest run of values without a missing one, the
+// first one on ties.
+func longestRun(values []float64) []float64 {
+	var best []float64
+	start := 0
+	for t := 0; t <= len(values); t++ {
+		if t < len(values) && !dataset.Missing(values[t]) {
+			continue
+		}
+		if t-start > len(best) {
+			best = values[start:t]
+		}
+		start = t + 1
+	}
+	return best
+}
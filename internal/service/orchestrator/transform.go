@@ -0,0 +1,103 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+// transform applies each variable's transforms, in order, to its filled
+// column. Those that look back are applied to each run between the breaks
+// of ds on its own, so that no window reaches across an excluded one.
+func transform(ds *dataset.Dataset, vars []variable.VariableDefinition) {
+	for i, v := range vars {
+		col := &ds.Columns[i]
+		for _, t := range v.Transforms {
+			if !looksBack(t) {
+				col.Values = applyTransform(t, col.Values, ds)
+				continue
+			}
+			values := make([]float64, 0, len(col.Values))
+			for _, r := range ds.Runs() {
+				values = append(values, applyTransform(t, col.Values[r[0]:r[1]], ds)...)
+			}
+			col.Values = values
+		}
+	}
+}
+
+// looksBack reports whether t combines a value with those of earlier steps.
+func looksBack(t variable.Transform) bool {
+	switch t.Type {
+	case variable.TransformRate, variable.TransformDerivative, variable.TransformDiff, variable.TransformSeasonalDiff,
+		variable.TransformRollingMean, variable.TransformRollingMedian:
+		return true
+	default:
+		return false
+	}
+}
+
+func applyTransform(t variable.Transform, values []float64, ds *dataset.Dataset) []float64 {
+	switch t.Type {
+	case variable.TransformRate:
+		return dataset.Rate(values, ds.Step)
+	case variable.TransformDerivative:
+		return dataset.Derivative(values, ds.Step)
+	case variable.TransformDiff:
+		return dataset.Diff(values, 1)
+	case variable.TransformLog:
+		return dataset.Log(values)
+	case variable.TransformLog1p:
+		return dataset.Log1p(values)
+	case variable.TransformZScore:
+		return dataset.ZScore(values)
+	case variable.TransformRollingMean:
+		return dataset.RollingMean(values, t.Window)
+	case variable.TransformRollingMedian:
+		return dataset.RollingMedian(values, t.Window)
+	case variable.TransformWinsorize:
+		return dataset.Winsorize(values, t.Lower, t.Upper)
+	case variable.TransformSeasonalDiff:
+		return dataset.Diff(values, t.Period)
+	default:
+		return values
+	}
+}
+
+func describeTransforms(ts []variable.Transform) []string {
+	var out []string
+	for _, t := range ts {
+		switch t.Type {
+		case variable.TransformRollingMean, variable.TransformRollingMedian:
+			out = append(out, fmt.Sprintf("%s(window=%d)", t.Type, t.Window))
+		case variable.TransformSeasonalDiff:
+			out = append(out, fmt.Sprintf("%s(period=%d)", t.Type, t.Period))
+		case variable.TransformWinsorize:
+			out = append(out, fmt.Sprintf("%s(lower=%g, upper=%g)", t.Type, t.Lower, t.Upper))
+		default:
+			out = append(out, t.Type)
+		}
+	}
+	return out
+}
+
+// nodes describes the columns of ds as graph nodes, recording the transforms
+// from their provenance.
+func nodes(ds *dataset.Dataset) []*causal.Node {
+	out := make([]*causal.Node, len(ds.Columns))
+	for i, c := range ds.Columns {
+		out[i] = &causal.Node{Id: int32(i), Label: c.Name, Transforms: c.Provenance.Transforms}
+	}
+	return out
+}
+
+// annotate records the transforms of ds on the nodes of graph.
+func annotate(graph *causal.CausalGraph, ds *dataset.Dataset) {
+	for _, n := range graph.GetNodes() {
+		if idx := ds.Index(n.Label); idx >= 0 {
+			n.Transforms = ds.Columns[idx].Provenance.Transforms
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/render"
+)
+
+const (
+	InterventionSet   = "set"
+	InterventionScale = "scale"
+	InterventionShift = "shift"
+)
+
+// Intervention replaces the mechanism of Variable between Start and End
+// (inclusive, zero means unbounded). Set pins the variable to Value, Scale
+// multiplies and Shift adds Value to what it would otherwise have been.
+type Intervention struct {
+	Variable string
+	Op       string
+	Value    float64
+	Start    time.Time
+	End      time.Time
+}
+
+func (i Intervention) apply(natural float64) float64 {
+	switch i.Op {
+	case InterventionScale:
+		return natural * i.Value
+	case InterventionShift:
+		return natural + i.Value
+	default:
+		return i.Value
+	}
+}
+
+func (i Intervention) active(t time.Time) bool {
+	return (i.Start.IsZero() || !t.Before(i.Start)) && (i.End.IsZero() || !t.After(i.End))
+}
+
+type WhatIfArgs struct {
+	Graph         *causal.CausalGraph
+	Interventions []Intervention
+	// Outcomes to report. Empty means every variable that wasn't intervened on.
+	Outcomes []string
+	// AllowUnoriented simulates without the graph's undirected, bidirected
+	// and conflicting edges instead of refusing it.
+	AllowUnoriented bool
+	// AllowTransformed intervenes on transformed variables in their
+	// transformed units instead of refusing to.
+	AllowTransformed bool
+}
+
+type WhatIfResult struct {
+	Timestamps []time.Time
+	Models     map[string]*causal.ModelInfo
+	Outcomes   []OutcomeResult
+}
+
+// OutcomeResult compares the observed and counterfactual series of one
+// variable. The means are taken from the first intervened step onwards so
+// that lagged effects after the intervention ends are included.
+type OutcomeResult struct {
+	Variable string
+	// Transforms are what the variable went through before the simulation,
+	// so the series, means and delta are in their units. Empty means the
+	// variable's own units.
+	Transforms         []string
+	Observed           []float64
+	Counterfactual     []float64
+	ObservedMean       float64
+	CounterfactualMean float64
+	Delta              float64
+	DeltaPct           float64
+}
+
+type term struct {
+	parent int
+	lag    int
+	coef   float64
+}
+
+type mechanism struct {
+	intercept float64
+	terms     []term
+	maxLag    int
+}
+
+// simulate runs the interventions through the fitted SCM. Noise terms are
+// recovered from the observed data (abduction) and held fixed so that the
+// counterfactual differs from the observation only downstream of the
+// interventions.
+func simulate(ds *dataset.Dataset, models map[string]*causal.ModelInfo, args WhatIfArgs) (*WhatIfResult, error) {
+	n := len(ds.Columns)
+	T := ds.Len()
+
+	// 1. parse the fitted mechanisms
+	mechanisms := make([]*mechanism, n)
+	for node, model := range models {
+		idx := ds.Index(node)
+		if idx < 0 {
+			return nil, fmt.Errorf("model for unknown variable '%s'", node)
+		}
+
+		m := &mechanism{intercept: float64(model.Intercept)}
+		for k, feature := range model.Features {
+			name, lag, err := parseFeature(feature)
+			if err != nil {
+				return nil, err
+			}
+			p := ds.Index(name)
+			if p < 0 {
+				return nil, fmt.Errorf("model for '%s' uses unknown variable '%s'", node, name)
+			}
+			m.terms = append(m.terms, term{parent: p, lag: lag, coef: float64(model.Coefficients[k])})
+			m.maxLag = max(m.maxLag, lag)
+		}
+		mechanisms[idx] = m
+	}
+
+	order, err := contemporaneousOrder(mechanisms)
+	if err != nil {
+		return nil, err
+	}
+
+	interventions := make([][]Intervention, n)
+	for _, i := range args.Interventions {
+		idx := ds.Index(i.Variable)
+		if idx < 0 {
+			return nil, fmt.Errorf("cannot intervene on unknown variable '%s'", i.Variable)
+		}
+		// the transforms can't be undone around the intervention, so it
+		// would act on the transformed series
+		if transforms := ds.Columns[idx].Provenance.Transforms; len(transforms) > 0 && !args.AllowTransformed {
+			units := render.DescribeTransforms(&causal.Node{Label: i.Variable, Transforms: transforms})
+			return nil, fmt.Errorf("cannot intervene on '%s', which is transformed: the intervention would apply to %s", i.Variable, units)
+		}
+		interventions[idx] = append(interventions[idx], i)
+	}
+
+	observed := make([][]float64, n)
+	for i, c := range ds.Columns {
+		observed[i] = c.Values
+	}
+
+	// 2. abduction: noise is whatever the mechanism doesn't explain
+	noise := make([][]float64, n)
+	for v, m := range mechanisms {
+		if m == nil {
+			continue
+		}
+		noise[v] = make([]float64, T)
+		for t := m.maxLag; t < T; t++ {
+			if ds.Crosses(t, m.maxLag) {
+				noise[v][t] = math.NaN()
+				continue
+			}
+			noise[v][t] = observed[v][t] - m.predict(observed, t)
+		}
+	}
+
+	// 3. action + prediction, step by step in causal order
+	cf := make([][]float64, n)
+	for v := range cf {
+		cf[v] = make([]float64, T)
+	}
+
+	firstIntervened := -1
+	for t := 0; t < T; t++ {
+		for _, v := range order {
+			natural := observed[v][t]
+			if m := mechanisms[v]; m != nil && t >= m.maxLag {
+				natural = m.predict(cf, t) + noise[v][t]
+			}
+			if math.IsNaN(natural) {
+				// a gap upstream or in v itself, or lags reaching across an
+				// excluded window: replay what was observed
+				natural = observed[v][t]
+			}
+
+			for _, i := range interventions[v] {
+				if i.active(ds.Timestamps[t]) {
+					natural = i.apply(natural)
+					if firstIntervened < 0 {
+						firstIntervened = t
+					}
+				}
+			}
+
+			cf[v][t] = natural
+		}
+	}
+
+	if firstIntervened < 0 {
+		return nil, fmt.Errorf("no intervention overlaps the analysis window")
+	}
+
+	// 4. summarize the outcomes
+	outcomes := args.Outcomes
+	if len(outcomes) == 0 {
+		for v, c := range ds.Columns {
+			if len(interventions[v]) == 0 {
+				outcomes = append(outcomes, c.Name)
+			}
+		}
+	}
+
+	result := &WhatIfResult{
+		Timestamps: ds.Timestamps,
+		Models:     models,
+	}
+
+	for _, name := range outcomes {
+		v := ds.Index(name)
+		if v < 0 {
+			return nil, fmt.Errorf("unknown outcome variable '%s'", name)
+		}
+
+		o := OutcomeResult{
+			Variable:           name,
+			Transforms:         ds.Columns[v].Provenance.Transforms,
+			Observed:           observed[v],
+			Counterfactual:     cf[v],
+			ObservedMean:       mean(observed[v][firstIntervened:]),
+			CounterfactualMean: mean(cf[v][firstIntervened:]),
+		}
+		o.Delta = o.CounterfactualMean - o.ObservedMean
+		if o.ObservedMean != 0 {
+			o.DeltaPct = 100 * o.Delta / math.Abs(o.ObservedMean)
+		}
+
+		result.Outcomes = append(result.Outcomes, o)
+	}
+
+	return result, nil
+}
+
+func (m *mechanism) predict(values [][]float64, t int) float64 {
+	y := m.intercept
+	for _, term := range m.terms {
+		y += term.coef * values[term.parent][t-term.lag]
+	}
+	return y
+}
+
+// contemporaneousOrder sorts the variables so that every lag 0 parent comes
+// before its child.
+func contemporaneousOrder(mechanisms []*mechanism) ([]int, error) {
+	n := len(mechanisms)
+
+	indegree := make([]int, n)
+	children := make([][]int, n)
+	for v, m := range mechanisms {
+		if m == nil {
+			continue
+		}
+		for _, term := range m.terms {
+			if term.lag == 0 && !slices.Contains(children[term.parent], v) {
+				children[term.parent] = append(children[term.parent], v)
+				indegree[v]++
+			}
+		}
+	}
+
+	var order, queue []int
+	for v := range n {
+		if indegree[v] == 0 {
+			queue = append(queue, v)
+		}
+	}
+
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		order = append(order, v)
+		for _, c := range children[v] {
+			indegree[c]--
+			if indegree[c] == 0 {
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	if len(order) != n {
+		return nil, fmt.Errorf("graph has a cycle among lag 0 edges")
+	}
+
+	return order, nil
+}
+
+// parseFeature splits a model feature like "db_wait_lag2" into its variable
+// and lag.
+func parseFeature(feature string) (string, int, error) {
+	idx := strings.LastIndex(feature, "_lag")
+	if idx < 0 {
+		return "", 0, fmt.Errorf("malformed feature '%s'", feature)
+	}
+
+	lag, err := strconv.Atoi(feature[idx+len("_lag"):])
+	if err != nil || lag < 0 {
+		return "", 0, fmt.Errorf("malformed feature '%s'", feature)
+	}
+
+	return feature[:idx], lag, nil
+}
+
+func mean(xs []float64) float64 {
+	sum, n := 0.0, 0
+	for _, x := range xs {
+		if dataset.Missing(x) {
+			continue
+		}
+		sum += x
+		n++
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	return sum / float64(n)
+}
@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// BootstrapResult summarizes the sampling distribution of OLS coefficients
+// under a moving block bootstrap.
+type BootstrapResult struct {
+	StdErrors []float64
+	PValues   []float64
+	CILower   []float64
+	CIUpper   []float64
+	// Samples is the number of resamples that could be fitted.
+	Samples int
+}
+
+// BlockBootstrapOLS refits OLS on samples resamples of y built by adding
+// overlapping blocks of blockSize consecutive residuals back onto the fitted
+// values, which preserves the autocorrelation that the analytic standard
+// errors ignore. A blockSize <= 0 picks n^(1/3) and a seed of 0 picks a fresh
+// seed. P-values use a normal approximation of coef / bootstrap SE and the
+// intervals are percentile intervals at the given level.
+func BlockBootstrapOLS(x [][]float64, y []float64, fit *Fit, samples int, blockSize int, level float64, seed uint64) *BootstrapResult {
+	n := len(y)
+	p := len(fit.Coefficients)
+
+	if blockSize <= 0 {
+		blockSize = max(1, int(math.Round(math.Cbrt(float64(n)))))
+	}
+	blockSize = min(blockSize, n)
+
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+
+	draws := make([][]float64, p)
+
+	by := make([]float64, n)
+	for range samples {
+		for t := 0; t < n; {
+			start := rng.IntN(n - blockSize + 1)
+			for r := start; r < start+blockSize && t < n; r++ {
+				by[t] = y[t] - fit.Residuals[t] + fit.Residuals[r]
+				t++
+			}
+		}
+
+		bfit, err := OLS(x, by)
+		if err != nil {
+			continue
+		}
+		for j, c := range bfit.Coefficients {
+			draws[j] = append(draws[j], c)
+		}
+	}
+
+	result := &BootstrapResult{
+		StdErrors: make([]float64, p),
+		PValues:   make([]float64, p),
+		CILower:   make([]float64, p),
+		CIUpper:   make([]float64, p),
+	}
+
+	if p > 0 {
+		result.Samples = len(draws[0])
+	}
+
+	for j := range p {
+		d := draws[j]
+		if len(d) < 2 {
+			result.StdErrors[j], result.PValues[j] = math.NaN(), math.NaN()
+			result.CILower[j], result.CIUpper[j] = math.NaN(), math.NaN()
+			continue
+		}
+
+		m := Mean(d)
+		ss := 0.0
+		for _, v := range d {
+			ss += (v - m) * (v - m)
+		}
+		se := math.Sqrt(ss / float64(len(d)-1))
+
+		result.StdErrors[j] = se
+		result.PValues[j] = 2 * (1 - NormalCDF(math.Abs(fit.Coefficients[j]/se)))
+
+		slices.Sort(d)
+		result.CILower[j] = quantile(d, (1-level)/2)
+		result.CIUpper[j] = quantile(d, 1-(1-level)/2)
+	}
+
+	return result
+}
+
+// quantile linearly interpolates the q-th quantile of sorted xs.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrSingular = errors.New("matrix is singular")
+
+// invert returns the inverse of the square matrix a using Gauss-Jordan
+// elimination with partial pivoting. a is left untouched.
+func invert(a [][]float64) ([][]float64, error) {
+	n := len(a)
+
+	m := make([][]float64, n)
+	for i := range a {
+		m[i] = make([]float64, 2*n)
+		copy(m[i], a[i])
+		m[i][n+i] = 1
+	}
+
+	scale := 0.0
+	for i := range a {
+		for j := range a[i] {
+			scale = math.Max(scale, math.Abs(a[i][j]))
+		}
+	}
+	eps := 1e-12 * math.Max(scale, 1)
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < eps {
+			return nil, ErrSingular
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		p := m[col][col]
+		for j := range m[col] {
+			m[col][j] /= p
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+			f := m[row][col]
+			for j := range m[row] {
+				m[row][j] -= f * m[col][j]
+			}
+		}
+	}
+
+	inv := make([][]float64, n)
+	for i := range m {
+		inv[i] = m[i][n:]
+	}
+
+	return inv, nil
+}
@@ -0,0 +1,132 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// Fit is an ordinary least squares fit of y on the columns of X plus an
+// intercept. The inference fields assume independent, homoskedastic errors.
+type Fit struct {
+	Intercept    float64
+	Coefficients []float64
+	Residuals    []float64
+
+	StdErrors        []float64
+	TStatistics      []float64
+	PValues          []float64
+	RSquared         float64
+	ResidualVariance float64
+	// DF is the residual degrees of freedom.
+	DF int
+}
+
+// ConfidenceInterval returns the two-sided interval for coefficient i at the
+// given level (e.g., 0.95).
+func (f *Fit) ConfidenceInterval(i int, level float64) (float64, float64) {
+	if f.DF <= 0 {
+		return math.NaN(), math.NaN()
+	}
+	q := StudentTQuantile(1-(1-level)/2, float64(f.DF))
+	return f.Coefficients[i] - q*f.StdErrors[i], f.Coefficients[i] + q*f.StdErrors[i]
+}
+
+// OLS fits y = intercept + X·beta by least squares. x is row-major: x[i] holds
+// the features of observation i.
+func OLS(x [][]float64, y []float64) (*Fit, error) {
+	n := len(y)
+	if len(x) != n {
+		return nil, fmt.Errorf("design matrix has %d rows but response has %d", len(x), n)
+	}
+
+	p := 1
+	if n > 0 {
+		p += len(x[0])
+	}
+
+	if n < p {
+		return nil, fmt.Errorf("not enough observations (%d) to fit %d parameters", n, p)
+	}
+
+	// normal equations over [1 | X]
+	xtx := make([][]float64, p)
+	for i := range xtx {
+		xtx[i] = make([]float64, p)
+	}
+	xty := make([]float64, p)
+
+	row := make([]float64, p)
+	for i := 0; i < n; i++ {
+		row[0] = 1
+		copy(row[1:], x[i])
+		for a := 0; a < p; a++ {
+			xty[a] += row[a] * y[i]
+			for b := a; b < p; b++ {
+				xtx[a][b] += row[a] * row[b]
+			}
+		}
+	}
+	for a := 0; a < p; a++ {
+		for b := 0; b < a; b++ {
+			xtx[a][b] = xtx[b][a]
+		}
+	}
+
+	inv, err := invert(xtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve normal equations (collinear features?): %w", err)
+	}
+
+	beta := make([]float64, p)
+	for a := 0; a < p; a++ {
+		for b := 0; b < p; b++ {
+			beta[a] += inv[a][b] * xty[b]
+		}
+	}
+
+	residuals := make([]float64, n)
+	ssr := 0.0
+	for i := 0; i < n; i++ {
+		pred := beta[0]
+		for j, v := range x[i] {
+			pred += beta[j+1] * v
+		}
+		residuals[i] = y[i] - pred
+		ssr += residuals[i] * residuals[i]
+	}
+
+	yMean := Mean(y)
+	sst := 0.0
+	for _, v := range y {
+		sst += (v - yMean) * (v - yMean)
+	}
+
+	fit := &Fit{
+		Intercept:    beta[0],
+		Coefficients: beta[1:],
+		Residuals:    residuals,
+		StdErrors:    make([]float64, p-1),
+		TStatistics:  make([]float64, p-1),
+		PValues:      make([]float64, p-1),
+		DF:           n - p,
+	}
+
+	if sst > 0 {
+		fit.RSquared = 1 - ssr/sst
+	}
+
+	if fit.DF > 0 {
+		fit.ResidualVariance = ssr / float64(fit.DF)
+	} else {
+		fit.ResidualVariance = math.NaN()
+	}
+
+	for j := 1; j < p; j++ {
+		se := math.Sqrt(fit.ResidualVariance * inv[j][j])
+		fit.StdErrors[j-1] = se
+		fit.TStatistics[j-1] = beta[j] / se
+		fit.PValues[j-1] = StudentTTwoSided(beta[j]/se, float64(fit.DF))
+	}
+
+	return fit, nil
+}
@@ -0,0 +1,183 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// ParCorrResult is the outcome of a partial correlation independence test.
+type ParCorrResult struct {
+	// Value is the partial correlation of x and y given z.
+	Value float64
+	// Statistic is the t statistic of Value.
+	Statistic float64
+	// PValue is the two-sided analytic p-value of Statistic.
+	PValue float64
+	// DF is the degrees of freedom of the test.
+	DF int
+}
+
+// ParCorr tests x ⫫ y | z by correlating the residuals of x and y after
+// regressing each on z. z is row-major and may have zero columns.
+func ParCorr(x []float64, y []float64, z [][]float64) (*ParCorrResult, error) {
+	n := len(x)
+	if len(y) != n || len(z) != n {
+		return nil, fmt.Errorf("mismatched sample sizes x=%d y=%d z=%d", len(x), len(y), len(z))
+	}
+
+	dimZ := 0
+	if n > 0 {
+		dimZ = len(z[0])
+	}
+
+	df := n - 2 - dimZ
+	if df < 1 {
+		return nil, fmt.Errorf("not enough samples (%d) to condition on %d variables", n, dimZ)
+	}
+
+	rx, err := residuals(x, z)
+	if err != nil {
+		return nil, err
+	}
+
+	ry, err := residuals(y, z)
+	if err != nil {
+		return nil, err
+	}
+
+	val := Corr(rx, ry)
+
+	// guard against |val| == 1 blowing up the statistic
+	val = math.Max(-1+1e-12, math.Min(1-1e-12, val))
+
+	stat := val * math.Sqrt(float64(df)/(1-val*val))
+
+	return &ParCorrResult{
+		Value:     val,
+		Statistic: stat,
+		PValue:    StudentTTwoSided(stat, float64(df)),
+		DF:        df,
+	}, nil
+}
+
+// AIC scores the linear model of y on z by n·log(RSS) + 2k, with RSS taken
+// over standardized y and k the number of columns of z, as tigramite's
+// ParCorr does to compare conditioning sets of the same y. Lower is better.
+func AIC(y []float64, z [][]float64) (float64, error) {
+	n := len(y)
+	if len(z) != n {
+		return 0, fmt.Errorf("mismatched sample sizes y=%d z=%d", n, len(z))
+	}
+
+	k := 0
+	if n > 0 {
+		k = len(z[0])
+	}
+
+	if n < k+2 {
+		return 0, fmt.Errorf("not enough samples (%d) to score %d variables", n, k)
+	}
+
+	r, err := residuals(y, z)
+	if err != nil {
+		return 0, err
+	}
+
+	m := Mean(y)
+	var rss, tss float64
+	for i := range y {
+		d := y[i] - m
+		rss += r[i] * r[i]
+		tss += d * d
+	}
+
+	if tss == 0 {
+		return math.Inf(-1), nil
+	}
+
+	return float64(n)*math.Log(rss/(tss/float64(n))) + 2*float64(k), nil
+}
+
+// Corr returns the Pearson correlation of a and b, or 0 if either is constant.
+func Corr(a []float64, b []float64) float64 {
+	ma, mb := Mean(a), Mean(b)
+
+	var sab, saa, sbb float64
+	for i := range a {
+		da, db := a[i]-ma, b[i]-mb
+		sab += da * db
+		saa += da * da
+		sbb += db * db
+	}
+
+	if saa == 0 || sbb == 0 {
+		return 0
+	}
+
+	return sab / math.Sqrt(saa*sbb)
+}
+
+// Mean returns the arithmetic mean of xs.
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+
+	return sum / float64(len(xs))
+}
+
+func residuals(v []float64, z [][]float64) ([]float64, error) {
+	// constant conditions carry no information but make the design singular
+	z = dropConstant(z)
+
+	if len(z) == 0 || len(z[0]) == 0 {
+		m := Mean(v)
+		out := make([]float64, len(v))
+		for i := range v {
+			out[i] = v[i] - m
+		}
+		return out, nil
+	}
+
+	fit, err := OLS(z, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return fit.Residuals, nil
+}
+
+func dropConstant(z [][]float64) [][]float64 {
+	if len(z) == 0 {
+		return z
+	}
+
+	var keep []int
+	for j := range z[0] {
+		for i := 1; i < len(z); i++ {
+			if z[i][j] != z[0][j] {
+				keep = append(keep, j)
+				break
+			}
+		}
+	}
+
+	if len(keep) == len(z[0]) {
+		return z
+	}
+
+	out := make([][]float64, len(z))
+	for i := range z {
+		out[i] = make([]float64, len(keep))
+		for k, j := range keep {
+			out[i][k] = z[i][j]
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"math"
+	"slices"
+)
+
+// madScale makes the median absolute deviation a consistent estimator of the
+// standard deviation of normal data.
+const madScale = 1.4826
+
+// Median is the median of xs, or NaN if xs is empty.
+func Median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// RobustZScores scores each value by its distance from the median in units
+// of the scaled median absolute deviation. If more than half of xs are equal
+// the deviation is zero and every score is zero.
+func RobustZScores(xs []float64) []float64 {
+	scores := make([]float64, len(xs))
+
+	med := Median(xs)
+
+	deviations := make([]float64, len(xs))
+	for i, x := range xs {
+		deviations[i] = math.Abs(x - med)
+	}
+
+	mad := madScale * Median(deviations)
+	if mad == 0 || math.IsNaN(mad) {
+		return scores
+	}
+
+	for i, x := range xs {
+		scores[i] = (x - med) / mad
+	}
+
+	return scores
+}
+
+// Quantile is the q-th quantile of xs, interpolating linearly between the
+// closest ranks, or NaN if xs is empty.
+func Quantile(xs []float64, q float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
@@ -0,0 +1,150 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// KPSS 5% critical values (Kwiatkowski et al., 1992) for level and trend
+// stationarity.
+const (
+	kpssLevelCritical = 0.463
+	kpssTrendCritical = 0.146
+)
+
+// ADFResult is the outcome of an augmented Dickey-Fuller test with a
+// constant. The null hypothesis is a unit root.
+type ADFResult struct {
+	// Statistic is the t statistic of the lagged level.
+	Statistic float64
+	// Lags is the number of lagged differences in the regression.
+	Lags int
+	// Critical is the 5% critical value for the sample size. A Statistic
+	// below it rejects the unit root.
+	Critical float64
+}
+
+// ADF runs the augmented Dickey-Fuller test on y with floor((n-1)^(1/3))
+// lagged differences (Said and Dickey).
+func ADF(y []float64) (*ADFResult, error) {
+	n := len(y)
+	lags := int(math.Floor(math.Cbrt(float64(n - 1))))
+
+	// Δy_t = a + b·y_{t-1} + Σ c_i·Δy_{t-i} + e_t
+	var x [][]float64
+	var dy []float64
+	for t := lags + 1; t < n; t++ {
+		row := []float64{y[t-1]}
+		for i := 1; i <= lags; i++ {
+			row = append(row, y[t-i]-y[t-i-1])
+		}
+		x = append(x, row)
+		dy = append(dy, y[t]-y[t-1])
+	}
+
+	fit, err := OLS(x, dy)
+	if err != nil {
+		return nil, fmt.Errorf("adf regression failed: %w", err)
+	}
+
+	stat := fit.TStatistics[0]
+	if math.IsNaN(stat) || math.IsInf(stat, 0) {
+		return nil, fmt.Errorf("adf statistic is undefined")
+	}
+
+	// MacKinnon (2010) response surface for the 5% critical value with a
+	// constant
+	T := float64(len(dy))
+	critical := -2.86154 - 2.8903/T - 4.234/(T*T) - 40.040/(T*T*T)
+
+	return &ADFResult{Statistic: stat, Lags: lags, Critical: critical}, nil
+}
+
+// KPSSResult is the outcome of a KPSS test. The null hypothesis is
+// stationarity around a level, or around a linear trend.
+type KPSSResult struct {
+	Statistic float64
+	// Lags is the Newey-West bandwidth of the long-run variance.
+	Lags int
+	// Critical is the 5% critical value. A Statistic above it rejects
+	// stationarity.
+	Critical float64
+}
+
+// KPSS runs the KPSS test on y around a level or, with trend, around a
+// linear trend, using a bandwidth of floor(12·(n/100)^(1/4)).
+func KPSS(y []float64, trend bool) (*KPSSResult, error) {
+	n := len(y)
+	if n < 3 {
+		return nil, fmt.Errorf("not enough observations (%d) for kpss", n)
+	}
+
+	resid, err := Detrend(y, trend)
+	if err != nil {
+		return nil, err
+	}
+
+	lags := min(int(math.Floor(12*math.Pow(float64(n)/100, 0.25))), n-1)
+
+	// long-run variance with Bartlett weights
+	lrv := 0.0
+	for _, e := range resid {
+		lrv += e * e
+	}
+	for s := 1; s <= lags; s++ {
+		cov := 0.0
+		for t := s; t < n; t++ {
+			cov += resid[t] * resid[t-s]
+		}
+		lrv += 2 * (1 - float64(s)/float64(lags+1)) * cov
+	}
+	lrv /= float64(n)
+
+	if lrv <= 0 {
+		return nil, fmt.Errorf("kpss long-run variance is not positive")
+	}
+
+	partial, eta := 0.0, 0.0
+	for _, e := range resid {
+		partial += e
+		eta += partial * partial
+	}
+	eta /= float64(n) * float64(n) * lrv
+
+	critical := kpssLevelCritical
+	if trend {
+		critical = kpssTrendCritical
+	}
+
+	return &KPSSResult{Statistic: eta, Lags: lags, Critical: critical}, nil
+}
+
+// Detrend returns y minus its mean or, with trend, minus its least squares
+// line over time. Missing (NaN) values are left out of the fit and stay
+// missing.
+func Detrend(y []float64, trend bool) ([]float64, error) {
+	var x [][]float64
+	var obs []float64
+	for t, v := range y {
+		if !math.IsNaN(v) {
+			x = append(x, []float64{float64(t)})
+			obs = append(obs, v)
+		}
+	}
+
+	mean := Mean(obs)
+	level := func(int) float64 { return mean }
+	if trend {
+		fit, err := OLS(x, obs)
+		if err != nil {
+			return nil, fmt.Errorf("detrending failed: %w", err)
+		}
+		level = func(t int) float64 { return fit.Intercept + fit.Coefficients[0]*float64(t) }
+	}
+
+	resid := make([]float64, len(y))
+	for t, v := range y {
+		resid[t] = v - level(t)
+	}
+	return resid, nil
+}
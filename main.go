@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -14,85 +15,482 @@ func main() {
 		Name:  "caus",
 		Usage: "Causal discovery for your metrics and trace aggregates",
 		Commands: []*cli.Command{
+			{
+				Name:  "fetch",
+				Usage: "Fetch and align the variables and export the dataset without running any analysis",
+				Flags: slices.Concat(
+					[]cli.Flag{
+						&cli.StringFlag{
+							Name:     "vars",
+							Aliases:  []string{"v"},
+							Usage:    "Path to vars.yml config",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:    "out",
+							Aliases: []string{"o"},
+							Usage:   "Path to write the dataset to ('-' for stdout). csv and jsonl get a <out>.meta.json sidecar with provenance",
+							Value:   "-",
+						},
+						&cli.StringFlag{
+							Name:  "format",
+							Usage: "Output format: 'csv', 'parquet' or 'jsonl' (default: from the --out extension, else csv)",
+						},
+					},
+					windowFlags(),
+					fetchFlags(),
+				),
+				Action: cmd.Fetch,
+			},
 			{
 				Name: "discover",
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:     "vars",
-						Aliases:  []string{"v"},
-						Usage:    "Path to vars.yml config",
-						Required: true,
-					},
-					&cli.DurationFlag{
-						Name:    "start",
-						Aliases: []string{"s"},
-						Usage:   "How long ago to start (e.g., '2h', '30m')",
-						Value:   2 * time.Hour,
-					},
-					&cli.DurationFlag{
-						Name:    "end",
-						Aliases: []string{"e"},
-						Usage:   "How long ago to end (e.g., '0m' for now)",
-						Value:   5 * time.Minute,
-					},
-					&cli.DurationFlag{
-						Name:  "step",
-						Usage: "Data resolution (e.g., 1m, 15s)",
-						Value: time.Minute,
-					},
-					&cli.IntFlag{
-						Name:  "lag",
-						Usage: "Max causal lag to check",
-						Value: 3,
-					},
-					&cli.Float64Flag{
-						Name:  "alpha",
-						Usage: "Significance level (e.g., 0.05)",
-						Value: 0.05,
-					},
-					&cli.BoolFlag{
-						Name:  "json",
-						Usage: "Print the resulting graph to stdout as json",
-						Value: false,
+				Flags: slices.Concat(
+					[]cli.Flag{
+						&cli.StringFlag{
+							Name:    "vars",
+							Aliases: []string{"v"},
+							Usage:   "Path to vars.yml config (optional with --dataset, where it selects the columns)",
+						},
+						&cli.StringFlag{
+							Name:  "dataset",
+							Usage: "Path to a dataset exported by 'caus fetch' (csv, parquet or jsonl) to use instead of fetching",
+						},
+						&cli.StringFlag{
+							Name:  "discoverer",
+							Usage: "Discovery backend: 'worker' (python gRPC worker) or 'native' (in-process)",
+							Value: "worker",
+						},
 					},
-				},
+					workerFlags(2*time.Minute),
+					windowFlags(),
+					fetchFlags(),
+					[]cli.Flag{
+						&cli.IntFlag{
+							Name:  "lag",
+							Usage: "Max causal lag to check",
+							Value: 3,
+						},
+						&cli.Float64Flag{
+							Name:  "alpha",
+							Usage: "PC1 level that selects each variable's candidate parents (0 lets PCMCI pick it per variable); edges are kept at p <= 0.05",
+							Value: 0.05,
+						},
+						&cli.Float64Flag{
+							Name:  "min-strength",
+							Usage: "Drop edges whose absolute partial correlation is below this (e.g., 0.1)",
+							Value: 0,
+						},
+						&cli.BoolFlag{
+							Name:  "json",
+							Usage: "Print the resulting graph to stdout as json (short for --format=json)",
+							Value: false,
+						},
+						&cli.StringFlag{
+							Name:  "format",
+							Usage: "How to print the resulting graph: 'text', 'json', or 'dot', 'mermaid' or 'graphml' to draw it",
+							Value: "text",
+						},
+					},
+				),
+				Before: cmd.LoadWorkerConfig,
 				Action: cmd.Discover,
 			},
 			{
 				Name: "estimate",
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:     "graph",
-						Aliases:  []string{"g"},
-						Usage:    "Path to graph.json",
-						Required: true,
-					},
-					&cli.StringFlag{
-						Name:     "vars",
-						Aliases:  []string{"v"},
-						Usage:    "Path to vars.yml config",
-						Required: true,
-					},
-					&cli.DurationFlag{
-						Name:    "start",
-						Aliases: []string{"s"},
-						Usage:   "How long ago to start (e.g., '2h', '30m')",
-						Value:   2 * time.Hour,
-					},
-					&cli.DurationFlag{
-						Name:    "end",
-						Aliases: []string{"e"},
-						Usage:   "How long ago to end (e.g., '0m' for now)",
-						Value:   5 * time.Minute,
-					},
-					&cli.DurationFlag{
-						Name:  "step",
-						Usage: "Data resolution (e.g., 1m, 15s)",
-						Value: time.Minute,
+				Flags: slices.Concat(
+					[]cli.Flag{
+						&cli.StringFlag{
+							Name:     "graph",
+							Aliases:  []string{"g"},
+							Usage:    "Path to graph.json",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:    "vars",
+							Aliases: []string{"v"},
+							Usage:   "Path to vars.yml config (optional with --dataset, where it selects the columns)",
+						},
+						&cli.StringFlag{
+							Name:  "dataset",
+							Usage: "Path to a dataset exported by 'caus fetch' (csv, parquet or jsonl) to use instead of fetching",
+						},
+						&cli.BoolFlag{
+							Name:  "allow-unoriented",
+							Usage: "Ignore undirected, bidirected and conflicting edges instead of refusing the graph",
+							Value: false,
+						},
+						&cli.StringFlag{
+							Name:  "estimator",
+							Usage: "Estimation backend: 'worker' (python gRPC worker) or 'native' (in-process)",
+							Value: "worker",
+						},
 					},
-				},
+					workerFlags(2*time.Minute),
+					[]cli.Flag{
+						&cli.Float64Flag{
+							Name:  "confidence",
+							Usage: "Confidence level of the coefficient intervals",
+							Value: 0.95,
+						},
+						&cli.IntFlag{
+							Name:  "bootstrap",
+							Usage: "Number of block bootstrap resamples for the standard errors (0 for analytic)",
+							Value: 0,
+						},
+						&cli.IntFlag{
+							Name:  "block-size",
+							Usage: "Block length for the bootstrap (0 for n^(1/3))",
+							Value: 0,
+						},
+						&cli.Uint64Flag{
+							Name:  "seed",
+							Usage: "Seed for the bootstrap resamples, to reproduce a run (0 for a fresh one)",
+							Value: 0,
+						},
+					},
+					windowFlags(),
+					fetchFlags(),
+					[]cli.Flag{
+						&cli.BoolFlag{
+							Name:  "json",
+							Usage: "Print the coefficients to stdout as json, e.g. for 'caus graph render --estimate'",
+							Value: false,
+						},
+					},
+				),
+				Before: cmd.LoadWorkerConfig,
 				Action: cmd.Estimate,
 			},
+			{
+				Name:  "whatif",
+				Usage: "Simulate interventions through the fitted causal model",
+				Flags: slices.Concat(
+					[]cli.Flag{
+						&cli.StringFlag{
+							Name:     "graph",
+							Aliases:  []string{"g"},
+							Usage:    "Path to graph.json",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     "vars",
+							Aliases:  []string{"v"},
+							Usage:    "Path to vars.yml config",
+							Required: true,
+						},
+						&cli.StringSliceFlag{
+							Name:     "do",
+							Usage:    "Intervention: 'name=*k' (scale), 'name=+k' (shift) or 'name=set:k' (pin)",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  "do-start",
+							Usage: "When the interventions start, in the same formats as --start (default: window start)",
+						},
+						&cli.StringFlag{
+							Name:  "do-end",
+							Usage: "When the interventions end, in the same formats as --end (default: window end)",
+						},
+						&cli.StringSliceFlag{
+							Name:  "outcome",
+							Usage: "Variable to report (default: every variable not intervened on)",
+						},
+						&cli.BoolFlag{
+							Name:  "allow-unoriented",
+							Usage: "Ignore undirected, bidirected and conflicting edges instead of refusing the graph",
+							Value: false,
+						},
+						&cli.BoolFlag{
+							Name:  "allow-transformed",
+							Usage: "Intervene on transformed variables in their transformed units instead of refusing to",
+							Value: false,
+						},
+						&cli.StringFlag{
+							Name:  "estimator",
+							Usage: "Estimation backend: 'worker' (python gRPC worker) or 'native' (in-process)",
+							Value: "worker",
+						},
+					},
+					workerFlags(2*time.Minute),
+					windowFlags(),
+					fetchFlags(),
+					[]cli.Flag{
+						&cli.BoolFlag{
+							Name:  "series",
+							Usage: "Print the observed and counterfactual series, not just the summary",
+							Value: false,
+						},
+						&cli.BoolFlag{
+							Name:  "json",
+							Usage: "Print the results to stdout as json",
+							Value: false,
+						},
+					},
+				),
+				Before: cmd.LoadWorkerConfig,
+				Action: cmd.WhatIf,
+			},
+			{
+				Name:  "serve",
+				Usage: "Serve discovery and estimation over gRPC and JSON/HTTP",
+				Flags: slices.Concat(
+					[]cli.Flag{
+						&cli.StringFlag{
+							Name:     "sources",
+							Usage:    "Path to sources.yml: the sources requests may use, with their credentials",
+							EnvVars:  []string{"CAUS_SERVE_SOURCES"},
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:    "grpc-addr",
+							Usage:   "Address to serve the caus.v1alpha1 gRPC API on (any address beyond loopback requires --token)",
+							EnvVars: []string{"CAUS_SERVE_GRPC_ADDR"},
+							Value:   "127.0.0.1:8080",
+						},
+						&cli.StringFlag{
+							Name:    "http-addr",
+							Usage:   "Address to serve the JSON/HTTP gateway on ('' to disable; any address beyond loopback requires --token)",
+							EnvVars: []string{"CAUS_SERVE_HTTP_ADDR"},
+							Value:   "127.0.0.1:8081",
+						},
+						&cli.StringFlag{
+							Name:    "token",
+							Usage:   "Bearer token every request must carry (default: none, which is only allowed on loopback addresses)",
+							EnvVars: []string{"CAUS_SERVE_TOKEN"},
+						},
+						&cli.StringFlag{
+							Name:    "tls-cert",
+							Usage:   "PEM certificate to serve gRPC and JSON/HTTP over TLS with (requires --tls-key)",
+							EnvVars: []string{"CAUS_SERVE_TLS_CERT"},
+						},
+						&cli.StringFlag{
+							Name:    "tls-key",
+							Usage:   "PEM private key of --tls-cert",
+							EnvVars: []string{"CAUS_SERVE_TLS_KEY"},
+						},
+						&cli.StringFlag{
+							Name:    "jobs-db",
+							Usage:   "Path to the file that keeps jobs, their logs and results across restarts ('' to disable jobs)",
+							EnvVars: []string{"CAUS_SERVE_JOBS_DB"},
+							Value:   "caus-jobs.db",
+						},
+						&cli.IntFlag{
+							Name:  "max-jobs",
+							Usage: "Max jobs running at once; the others wait in the queue (0 for unlimited)",
+							Value: 2,
+						},
+						&cli.DurationFlag{
+							Name:    "job-timeout",
+							Usage:   "Max time a job may run once it started (0 for none)",
+							EnvVars: []string{"CAUS_SERVE_JOB_TIMEOUT"},
+						},
+						&cli.StringFlag{
+							Name:  "discoverer",
+							Usage: "Discovery backend: 'worker' (python gRPC worker) or 'native' (in-process)",
+							Value: "worker",
+						},
+						&cli.StringFlag{
+							Name:  "estimator",
+							Usage: "Estimation backend: 'worker' (python gRPC worker) or 'native' (in-process)",
+							Value: "worker",
+						},
+					},
+					workerFlags(0),
+					[]cli.Flag{
+						&cli.IntFlag{
+							Name:  "concurrency",
+							Usage: "Max fetches in flight across all sources, per request (0 for unlimited)",
+							Value: 8,
+						},
+						&cli.StringSliceFlag{
+							Name:  "source-concurrency",
+							Usage: "Max fetches in flight per source impl, per request (e.g., 'prometheus=2')",
+						},
+					},
+				),
+				Before: cmd.LoadWorkerConfig,
+				Action: cmd.Serve,
+			},
+			{
+				Name:  "job",
+				Usage: "Run discovery and estimation as jobs of a 'caus serve' and follow them",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "discover",
+						Usage: "Submit a discovery job and print its ID",
+						Flags: jobFlags(append(jobRunFlags(),
+							&cli.BoolFlag{
+								Name:  "wait",
+								Usage: "Follow the job's log to stderr and print its result once it finishes, instead of its ID",
+							},
+							&cli.BoolFlag{
+								Name:  "json",
+								Usage: "With --wait, print the result to stdout as json",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "With --wait, how to print the graph: 'text', 'json', 'dot', 'mermaid' or 'graphml'",
+								Value: "text",
+							},
+							&cli.IntFlag{
+								Name:  "lag",
+								Usage: "Max causal lag to check",
+								Value: 3,
+							},
+							&cli.Float64Flag{
+								Name:  "alpha",
+								Usage: "PC1 level that selects each variable's candidate parents (0 lets PCMCI pick it per variable); edges are kept at p <= 0.05",
+								Value: 0.05,
+							},
+							&cli.Float64Flag{
+								Name:  "min-strength",
+								Usage: "Drop edges whose absolute partial correlation is below this (e.g., 0.1)",
+								Value: 0,
+							},
+						)...),
+						Action: cmd.JobDiscover,
+					},
+					{
+						Name:  "estimate",
+						Usage: "Submit an estimation job and print its ID",
+						Flags: jobFlags(append(jobRunFlags(),
+							&cli.StringFlag{
+								Name:     "graph",
+								Aliases:  []string{"g"},
+								Usage:    "Path to graph.json",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "wait",
+								Usage: "Follow the job's log to stderr and print its result once it finishes, instead of its ID",
+							},
+							&cli.BoolFlag{
+								Name:  "json",
+								Usage: "With --wait, print the result to stdout as json",
+							},
+							&cli.BoolFlag{
+								Name:  "allow-unoriented",
+								Usage: "Ignore undirected, bidirected and conflicting edges instead of refusing the graph",
+								Value: false,
+							},
+							&cli.Float64Flag{
+								Name:  "confidence",
+								Usage: "Confidence level of the coefficient intervals",
+								Value: 0.95,
+							},
+							&cli.IntFlag{
+								Name:  "bootstrap",
+								Usage: "Number of block bootstrap resamples for the standard errors (0 for analytic)",
+								Value: 0,
+							},
+							&cli.IntFlag{
+								Name:  "block-size",
+								Usage: "Block length for the bootstrap (0 for n^(1/3))",
+								Value: 0,
+							},
+							&cli.Uint64Flag{
+								Name:  "seed",
+								Usage: "Seed for the bootstrap resamples, to reproduce a run (0 for a fresh one)",
+								Value: 0,
+							},
+						)...),
+						Action: cmd.JobEstimate,
+					},
+					{
+						Name:      "status",
+						Usage:     "Print a job's state and progress",
+						ArgsUsage: "<id>",
+						Flags:     jobFlags(),
+						Action:    cmd.JobStatus,
+					},
+					{
+						Name:  "list",
+						Usage: "List jobs, newest first",
+						Flags: jobFlags(
+							&cli.StringFlag{
+								Name:  "state",
+								Usage: "Only list jobs in this state: 'queued', 'running', 'succeeded', 'failed' or 'cancelled'",
+							},
+							&cli.IntFlag{
+								Name:  "limit",
+								Usage: "Max jobs to list (0 for all)",
+								Value: 20,
+							},
+						),
+						Action: cmd.JobList,
+					},
+					{
+						Name:      "logs",
+						Usage:     "Print a job's log to stderr",
+						ArgsUsage: "<id>",
+						Flags: jobFlags(
+							&cli.BoolFlag{
+								Name:    "follow",
+								Aliases: []string{"f"},
+								Usage:   "Keep printing new entries until the job finishes",
+							},
+						),
+						Action: cmd.JobLogs,
+					},
+					{
+						Name:      "cancel",
+						Usage:     "Cancel a queued or running job",
+						ArgsUsage: "<id>",
+						Flags:     jobFlags(),
+						Action:    cmd.JobCancel,
+					},
+					{
+						Name:      "result",
+						Usage:     "Print the graph or coefficients of a finished job",
+						ArgsUsage: "<id>",
+						Flags: jobFlags(
+							&cli.BoolFlag{
+								Name:  "json",
+								Usage: "Print the result to stdout as json",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "How to print a graph: 'text', 'json', 'dot', 'mermaid' or 'graphml'",
+								Value: "text",
+							},
+						),
+						Action: cmd.JobResult,
+					},
+				},
+			},
+			{
+				Name:  "graph",
+				Usage: "Work with saved graphs",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "render",
+						Usage: "Draw a graph as Graphviz DOT, Mermaid or GraphML, with the coefficients of an estimate of it",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "graph",
+								Aliases:  []string{"g"},
+								Usage:    "Path to graph.json",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "estimate",
+								Usage: "Path to the json of an estimate of the graph ('caus estimate --json'), to label its edges with their coefficients",
+							},
+							&cli.StringFlag{
+								Name:    "out",
+								Aliases: []string{"o"},
+								Usage:   "Path to write the drawing to ('-' for stdout)",
+								Value:   "-",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: 'dot', 'mermaid' or 'graphml' (default: from the --out extension, else dot)",
+							},
+						},
+						Action: cmd.RenderGraph,
+					},
+				},
+			},
 		},
 	}
 
@@ -100,3 +498,202 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// jobFlags are the flags of a job subcommand: how to reach the server, then
+// flags.
+func jobFlags(flags ...cli.Flag) []cli.Flag {
+	return append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "server",
+			Usage:   "Address of the gRPC API of 'caus serve'",
+			EnvVars: []string{"CAUS_SERVER"},
+			Value:   "localhost:8080",
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			Usage:   "Bearer token of the server",
+			EnvVars: []string{"CAUS_SERVE_TOKEN"},
+		},
+		&cli.BoolFlag{
+			Name:    "server-tls",
+			Usage:   "Connect to the server over TLS, verified against the system roots unless --server-ca is set",
+			EnvVars: []string{"CAUS_SERVER_TLS"},
+		},
+		&cli.StringFlag{
+			Name:    "server-ca",
+			Usage:   "PEM file of the CA that signed the server's certificate (implies --server-tls)",
+			EnvVars: []string{"CAUS_SERVER_CA"},
+		},
+	}, flags...)
+}
+
+// workerFlags are the flags of the commands that call the python worker:
+// how to reach it, and how to retry it. timeout is the default deadline of
+// each call.
+func workerFlags(timeout time.Duration) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "worker-api",
+			Usage: "API the worker is spoken to with: 'v1alpha2' (streamed columns) or 'v1alpha1' (one CSV message)",
+			Value: "v1alpha2",
+		},
+		&cli.StringFlag{
+			Name:    "worker-addr",
+			Usage:   "Address of the python gRPC worker",
+			EnvVars: []string{"CAUS_WORKER_ADDR"},
+			Value:   "localhost:50051",
+		},
+		&cli.DurationFlag{
+			Name:    "worker-timeout",
+			Usage:   "Deadline of each call to the worker (0 for none)",
+			EnvVars: []string{"CAUS_WORKER_TIMEOUT"},
+			Value:   timeout,
+		},
+		&cli.BoolFlag{
+			Name:    "worker-tls",
+			Usage:   "Connect to the worker over TLS, verified against the system roots unless --worker-ca is set",
+			EnvVars: []string{"CAUS_WORKER_TLS"},
+		},
+		&cli.StringFlag{
+			Name:    "worker-ca",
+			Usage:   "PEM file of the CA that signed the worker's certificate (implies --worker-tls)",
+			EnvVars: []string{"CAUS_WORKER_CA"},
+		},
+		&cli.StringFlag{
+			Name:    "worker-cert",
+			Usage:   "PEM client certificate for mutual TLS, with --worker-key",
+			EnvVars: []string{"CAUS_WORKER_CERT"},
+		},
+		&cli.StringFlag{
+			Name:    "worker-key",
+			Usage:   "PEM key of --worker-cert",
+			EnvVars: []string{"CAUS_WORKER_KEY"},
+		},
+		&cli.StringFlag{
+			Name:    "worker-token",
+			Usage:   "Bearer token sent with every call to the worker (requires worker TLS unless the worker is on loopback)",
+			EnvVars: []string{"CAUS_WORKER_TOKEN"},
+		},
+		&cli.IntFlag{
+			Name:    "worker-retries",
+			Usage:   "Retries of a call while the worker is unavailable",
+			EnvVars: []string{"CAUS_WORKER_RETRIES"},
+			Value:   3,
+		},
+		&cli.DurationFlag{
+			Name:    "worker-backoff",
+			Usage:   "Backoff before the first retry, doubling (with jitter) after each one",
+			EnvVars: []string{"CAUS_WORKER_BACKOFF"},
+			Value:   500 * time.Millisecond,
+		},
+		&cli.DurationFlag{
+			Name:    "worker-max-backoff",
+			Usage:   "Upper bound of the backoff between retries",
+			EnvVars: []string{"CAUS_WORKER_MAX_BACKOFF"},
+			Value:   10 * time.Second,
+		},
+		&cli.StringFlag{
+			Name:    "worker-config",
+			Usage:   "YAML file of worker-* flag values, used for flags that are neither passed nor set in the environment",
+			EnvVars: []string{"CAUS_WORKER_CONFIG"},
+		},
+	}
+}
+
+// windowFlags are the flags of the window the variables are fetched over.
+func windowFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "start",
+			Aliases: []string{"s"},
+			Usage:   "When to start: how long ago (e.g., '2h'), an RFC3339 timestamp or unix seconds",
+			Value:   "2h",
+		},
+		&cli.StringFlag{
+			Name:    "end",
+			Aliases: []string{"e"},
+			Usage:   "When to end: how long ago (e.g., '0m' for now), an RFC3339 timestamp or unix seconds",
+			Value:   "5m",
+		},
+		&cli.StringFlag{
+			Name:  "around",
+			Usage: "Center the window on this time (e.g., an incident's RFC3339 timestamp) instead of --start/--end",
+		},
+		&cli.DurationFlag{
+			Name:  "radius",
+			Usage: "How far either side of --around the window reaches",
+			Value: time.Hour,
+		},
+		&cli.DurationFlag{
+			Name:  "step",
+			Usage: "Data resolution (e.g., 1m, 15s)",
+			Value: time.Minute,
+		},
+	}
+}
+
+// fetchFlags are the flags of how the variables are fetched and prepared
+// for the analysis.
+func fetchFlags() []cli.Flag {
+	return append([]cli.Flag{
+		&cli.IntFlag{
+			Name:  "concurrency",
+			Usage: "Max fetches in flight across all sources (0 for unlimited)",
+			Value: 8,
+		},
+		&cli.StringSliceFlag{
+			Name:  "source-concurrency",
+			Usage: "Max fetches in flight per source impl (e.g., 'prometheus=2')",
+		},
+		&cli.StringFlag{
+			Name:  "quality",
+			Usage: "How to print the data quality report to stderr: 'table', 'json' or 'none'",
+			Value: "table",
+		},
+	}, analysisFlags()...)
+}
+
+// analysisFlags are the flags of which data the analysis runs on, both here
+// and in a job.
+func analysisFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "make-stationary",
+			Usage: "Detrend or difference every variable the stationarity tests find non-stationary",
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "Window to leave out of the analysis as 'start/end' (e.g., '2024-03-12T14:00:00Z/2024-03-12T14:30:00Z' or '3h/2h')",
+		},
+	}
+}
+
+// jobRunFlags are the flags of the run a job does, which cmd.jobRun passes on
+// to the server. Its window is resolved there.
+func jobRunFlags() []cli.Flag {
+	return append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "vars",
+			Aliases:  []string{"v"},
+			Usage:    "Path to vars.yml config",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "start",
+			Aliases: []string{"s"},
+			Usage:   "When to start: how long ago (e.g., '2h'), an RFC3339 timestamp or unix seconds, resolved on the server",
+			Value:   "2h",
+		},
+		&cli.StringFlag{
+			Name:    "end",
+			Aliases: []string{"e"},
+			Usage:   "When to end: how long ago (e.g., '0m' for now), an RFC3339 timestamp or unix seconds, resolved on the server",
+			Value:   "5m",
+		},
+		&cli.DurationFlag{
+			Name:  "step",
+			Usage: "Data resolution (e.g., 1m, 15s)",
+			Value: time.Minute,
+		},
+	}, analysisFlags()...)
+}
@@ -0,0 +1,97 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/w-h-a/caus/internal/client/annotator"
+	"github.com/w-h-a/caus/internal/client/annotator/file"
+	"github.com/w-h-a/caus/internal/client/annotator/grafana"
+)
+
+func TestGrafanaAnnotator_Annotations(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	var auth string
+	var query map[string][]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		query = r.URL.Query()
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"time": start.Add(5 * time.Minute).UnixMilli(), "timeEnd": start.Add(9 * time.Minute).UnixMilli(), "text": "deploy", "tags": []string{"deploy"}},
+			{"time": start.Add(30 * time.Minute).UnixMilli(), "timeEnd": 0, "text": "flag flip", "tags": []string{"deploy", "flags"}},
+		})
+	}))
+	defer srv.Close()
+
+	a := grafana.NewAnnotator(annotator.WithLocation(srv.URL), annotator.WithApiKey("secret"))
+
+	// Act
+	annotations, err := a.Annotations(context.Background(), []string{"deploy"}, start, end)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "Bearer secret", auth)
+	assert.Equal(t, []string{"deploy"}, query["tags"])
+	assert.Equal(t, []string{"1710252000000"}, query["from"])
+	assert.Equal(t, []string{"1710255600000"}, query["to"])
+
+	require.Len(t, annotations, 2)
+	assert.Equal(t, start.Add(5*time.Minute), annotations[0].Start)
+	assert.Equal(t, start.Add(9*time.Minute), annotations[0].End)
+	assert.Equal(t, start.Add(30*time.Minute), annotations[1].End) // a point in time
+}
+
+func TestFileAnnotator_Annotations(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	path := filepath.Join(t.TempDir(), "annotations.yml")
+	content := `
+- start: 2024-03-12T14:05:00Z
+  end: 2024-03-12T14:09:00Z
+  text: deploy checkout
+  tags: [deploy, checkout]
+- start: 2024-03-12T14:30:00Z
+  text: load test
+  tags: [load]
+- start: 2024-03-12T16:00:00Z
+  text: deploy payments
+  tags: [deploy]
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	a := file.NewAnnotator(annotator.WithLocation(path))
+
+	// Act
+	annotations, err := a.Annotations(context.Background(), []string{"deploy"}, start, end)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, annotations, 1) // the load test lacks the tag, the other deploy is outside the window
+	assert.Equal(t, "deploy checkout", annotations[0].Text)
+	assert.Equal(t, start.Add(5*time.Minute), annotations[0].Start)
+	assert.Equal(t, start.Add(9*time.Minute), annotations[0].End)
+}
@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/config"
+)
+
+func derivedVar(name string, expression string) variable.VariableDefinition {
+	return variable.VariableDefinition{Name: name, Source: &variable.Source{Type: "derived"}, Expression: expression}
+}
+
+func TestConfig_EvaluationOrder(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	vars := []variable.VariableDefinition{
+		derivedVar("error_pct", "100 * error_ratio"),
+		derivedVar("error_ratio", "errors / calls"),
+		{Name: "errors", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		{Name: "calls", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	// Act
+	order, err := config.EvaluationOrder(vars)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []int{2, 3, 1, 0}, order)
+}
+
+func TestConfig_EvaluationOrderErrors(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	cases := map[string][]variable.VariableDefinition{
+		"variable 'ratio' references unknown variable 'calls'": {
+			derivedVar("ratio", "errors / calls"),
+			{Name: "errors", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		},
+		"derived variables form a cycle: a -> b -> c -> a": {
+			derivedVar("a", "b + 1"),
+			derivedVar("b", "c * 2"),
+			derivedVar("c", "a - 1"),
+		},
+		"variable 'busy' references 'cpu', which is split into one variable per series": {
+			derivedVar("busy", "cpu * 100"),
+			{Name: "cpu", Source: &variable.Source{Type: "metrics", Impl: "mock"}, SplitBy: []string{"pod"}},
+		},
+		"derived variables form a cycle: self -> self": {
+			derivedVar("self", "self + 1"),
+		},
+	}
+
+	for expected, vars := range cases {
+		// Act
+		_, err := config.EvaluationOrder(vars)
+
+		// Assert
+		require.Error(t, err, expected)
+		assert.Equal(t, expected, err.Error())
+	}
+}
+
+func TestConfig_ParseDerived(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	invalid := map[string]string{
+		"config validation failed: variable 'ratio' has an invalid expression: unexpected end of expression": `
+variables:
+  - name: ratio
+    source: {type: derived}
+    expression: "errors /"
+`,
+		"config validation failed: variable[0] 'ratio' invalid: expression is required for source type 'derived'": `
+variables:
+  - name: ratio
+    source: {type: derived}
+`,
+	}
+
+	for expected, input := range invalid {
+		// Act
+		_, err := config.ParseConfig([]byte(input))
+
+		// Assert
+		require.Error(t, err, expected)
+		assert.Equal(t, expected, err.Error())
+	}
+}
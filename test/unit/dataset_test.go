@@ -0,0 +1,231 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+func exportFixture() *dataset.Dataset {
+	t0 := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	return &dataset.Dataset{
+		Timestamps: []time.Time{t0, t0.Add(time.Minute), t0.Add(2 * time.Minute)},
+		Step:       time.Minute,
+		Columns: []dataset.Column{
+			{
+				Name:       "latency",
+				Values:     []float64{1.5, math.NaN(), 3.25},
+				Provenance: dataset.Provenance{Type: "metrics", Impl: "prometheus", Loc: "http://prom:9090", Query: "avg(latency)"},
+			},
+			{
+				Name:       "cpu",
+				Values:     []float64{0.1, 0.2, 0.3},
+				Provenance: dataset.Provenance{Type: "metrics", Impl: "prometheus", Loc: "http://prom:9090", Query: "avg(cpu)"},
+			},
+		},
+	}
+}
+
+func TestDataset_WriteCSV(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	ds := exportFixture()
+	var buf bytes.Buffer
+
+	// Act
+	err := dataset.Write(&buf, ds, dataset.FormatCSV)
+	require.NoError(t, err)
+
+	// Assert
+	expected := "timestamp,latency,cpu\n" +
+		"2023-10-01T10:00:00Z,1.5,0.1\n" +
+		"2023-10-01T10:01:00Z,,0.2\n" +
+		"2023-10-01T10:02:00Z,3.25,0.3\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDataset_WriteJSONL(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	ds := exportFixture()
+	var buf bytes.Buffer
+
+	// Act
+	err := dataset.Write(&buf, ds, dataset.FormatJSONL)
+	require.NoError(t, err)
+
+	// Assert
+	expected := `{"timestamp":"2023-10-01T10:00:00Z","latency":1.5,"cpu":0.1}` + "\n" +
+		`{"timestamp":"2023-10-01T10:01:00Z","latency":null,"cpu":0.2}` + "\n" +
+		`{"timestamp":"2023-10-01T10:02:00Z","latency":3.25,"cpu":0.3}` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDataset_WriteParquet(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	ds := exportFixture()
+	var buf bytes.Buffer
+
+	// Act
+	err := dataset.Write(&buf, ds, dataset.FormatParquet)
+	require.NoError(t, err)
+
+	// Assert
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), f.NumRows())
+
+	raw, ok := f.Lookup(dataset.MetadataKey)
+	require.True(t, ok)
+	var meta dataset.Metadata
+	require.NoError(t, json.Unmarshal([]byte(raw), &meta))
+	assert.Equal(t, "1m0s", meta.Step)
+	assert.Equal(t, ds.Timestamps[0], meta.Start)
+	assert.Equal(t, ds.Timestamps[2], meta.End)
+	require.Len(t, meta.Columns, 2)
+	assert.Equal(t, "latency", meta.Columns[0].Name)
+	assert.Equal(t, "avg(latency)", meta.Columns[0].Query)
+
+	rows := make([]parquet.Row, 3)
+	n, _ := parquet.NewReader(bytes.NewReader(buf.Bytes())).ReadRows(rows)
+	require.Equal(t, 3, n)
+
+	columns := map[string]int{}
+	for i, path := range f.Schema().Columns() {
+		columns[path[0]] = i
+	}
+	assert.Equal(t, ds.Timestamps[1].UnixMilli(), rows[1][columns["timestamp"]].Int64())
+	assert.True(t, rows[1][columns["latency"]].IsNull())
+	assert.Equal(t, 3.25, rows[2][columns["latency"]].Double())
+	assert.Equal(t, 0.2, rows[1][columns["cpu"]].Double())
+}
+
+func TestDataset_WriteReservedName(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	ds := exportFixture()
+	ds.Columns[1].Name = "timestamp"
+
+	// Act
+	err := dataset.Write(&bytes.Buffer{}, ds, dataset.FormatCSV)
+
+	// Assert
+	assert.ErrorContains(t, err, "reserved")
+}
+
+func TestDataset_ReadFileRoundTrip(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	for _, format := range dataset.SupportedFormats {
+		t.Run(string(format), func(t *testing.T) {
+			// Arrange
+			ds := exportFixture()
+			ds.Breaks = []int{1, 2}
+			path := filepath.Join(t.TempDir(), "export."+string(format))
+
+			var buf bytes.Buffer
+			require.NoError(t, dataset.Write(&buf, ds, format))
+			require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+
+			if format != dataset.FormatParquet {
+				var meta bytes.Buffer
+				require.NoError(t, dataset.WriteMetadata(&meta, ds))
+				require.NoError(t, os.WriteFile(path+".meta.json", meta.Bytes(), 0o644))
+			}
+
+			// Act
+			got, err := dataset.ReadFile(path)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, ds.Timestamps, got.Timestamps)
+			assert.Equal(t, time.Minute, got.Step)
+			assert.Equal(t, []string{"latency", "cpu"}, got.Names())
+			assert.Equal(t, 1.5, got.Columns[0].Values[0])
+			assert.True(t, dataset.Missing(got.Columns[0].Values[1]))
+			assert.Equal(t, []float64{0.1, 0.2, 0.3}, got.Columns[1].Values)
+			assert.Equal(t, ds.Columns[1].Provenance, got.Columns[1].Provenance)
+			assert.Equal(t, []int{1, 2}, got.Breaks)
+		})
+	}
+}
+
+func TestDataset_ReadFileWithoutMetadata(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "hand_made.csv")
+	csvData := "timestamp,a,b\n" +
+		"1696154400,1,2\n" +
+		"1696154700,3,\n" +
+		"1696155000,5,6\n"
+	require.NoError(t, os.WriteFile(path, []byte(csvData), 0o644))
+
+	// Act
+	ds, err := dataset.ReadFile(path)
+	require.NoError(t, err)
+
+	selected, selectErr := ds.Select([]string{"b", "a"})
+	_, missingErr := ds.Select([]string{"a", "c"})
+
+	// Assert
+	assert.Equal(t, 5*time.Minute, ds.Step) // inferred from the timestamps
+	assert.Equal(t, time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC), ds.Timestamps[0])
+	assert.True(t, dataset.Missing(ds.Columns[1].Values[1]))
+
+	require.NoError(t, selectErr)
+	assert.Equal(t, []string{"b", "a"}, selected.Names())
+	assert.ErrorContains(t, missingErr, "no column for: c")
+}
+
+func TestDataset_ReadFileUneven(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "gappy.jsonl")
+	jsonl := `{"timestamp":"2023-10-01T10:00:00Z","a":1}` + "\n" +
+		`{"timestamp":"2023-10-01T10:01:00Z","a":2}` + "\n" +
+		`{"timestamp":"2023-10-01T10:05:00Z","a":3}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(jsonl), 0o644))
+
+	// Act
+	_, err := dataset.ReadFile(path)
+
+	// Assert
+	assert.ErrorContains(t, err, "not evenly spaced")
+}
@@ -0,0 +1,141 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/annotator"
+	httpannotator "github.com/w-h-a/caus/internal/client/annotator/http"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+	"github.com/w-h-a/caus/internal/client/fetcher/events"
+	"github.com/w-h-a/caus/internal/client/fetcher/prometheus"
+)
+
+func TestEvents_Count(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Minute)
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * time.Minute) }
+
+	found := []annotator.Annotation{
+		{Start: ts(1).Add(10 * time.Second), End: ts(1).Add(10 * time.Second)},
+		{Start: ts(1).Add(50 * time.Second), End: ts(1).Add(50 * time.Second)},
+		{Start: ts(2).Add(30 * time.Second), End: ts(3).Add(30 * time.Second)}, // spans two steps
+		{Start: ts(9), End: ts(9)}, // outside the window
+	}
+
+	// Act
+	counts := events.Count(found, start, end, time.Minute)
+
+	// Assert
+	assert.Equal(t, map[time.Time]float64{ts(0): 0, ts(1): 2, ts(2): 1, ts(3): 1, ts(4): 0}, counts)
+}
+
+func TestEvents_HTTPFetcher(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Minute)
+
+	var query map[string][]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"time": start.Add(30 * time.Second).Format(time.RFC3339), "text": "deploy", "tags": []string{"deploy"}},
+			{"time": start.Add(90 * time.Second).Unix(), "tags": []string{"deploy"}},
+			{"time": start.Add(100 * time.Second).Unix(), "tags": []string{"flag"}}, // filtered out by tag
+		})
+	}))
+	defer srv.Close()
+
+	f := events.NewFetcher(
+		fetcher.WithLocation(srv.URL),
+		events.WithAnnotator(httpannotator.NewAnnotator(annotator.WithLocation(srv.URL+"?team=checkout"))),
+	)
+
+	v := variable.VariableDefinition{
+		Name:       "deploys",
+		Source:     &variable.Source{Type: "events", Impl: "http", Loc: srv.URL},
+		EventQuery: &variable.EventQueryDetails{Tags: []string{"deploy"}},
+	}
+
+	// Act
+	counts, err := f.Fetch(context.Background(), v, start, end, time.Minute)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"checkout"}, query["team"])
+	assert.Equal(t, []string{"deploy"}, query["tags"])
+	assert.Equal(t, []string{start.Format(time.RFC3339)}, query["start"])
+	assert.Equal(t, map[time.Time]float64{start: 1, start.Add(time.Minute): 1, end: 0}, counts)
+}
+
+func TestEvents_PrometheusChanges(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Minute)
+
+	var promQL, from string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		promQL, from = r.Form.Get("query"), r.Form.Get("start")
+		sample := func(at time.Time, v string) []any { return []any{float64(at.Unix()), v} }
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "success",
+			"data": map[string]any{
+				"resultType": "matrix",
+				"result": []map[string]any{{
+					"metric": map[string]string{},
+					"values": []any{
+						sample(start.Add(time.Minute), "0"),
+						sample(start.Add(2*time.Minute), "2"),
+						sample(start.Add(3*time.Minute), "0"),
+					},
+				}},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	f := prometheus.NewFetcher(fetcher.WithLocation(srv.URL))
+
+	v := variable.VariableDefinition{
+		Name:       "deploys",
+		Source:     &variable.Source{Type: "events", Impl: "prometheus", Loc: srv.URL},
+		EventQuery: &variable.EventQueryDetails{Query: `kube_deployment_status_observed_generation{deployment="checkout"}`},
+	}
+
+	// Act
+	counts, err := f.Fetch(context.Background(), v, start, end, time.Minute)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, `sum(changes(kube_deployment_status_observed_generation{deployment="checkout"}[1m]))`, promQL)
+	assert.Equal(t, strconv.FormatInt(start.Add(time.Minute).Unix(), 10), from) // evaluated at the end of each step
+	assert.Equal(t, map[time.Time]float64{start: 0, start.Add(time.Minute): 2, end: 0}, counts)
+}
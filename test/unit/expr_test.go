@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/w-h-a/caus/internal/expr"
+)
+
+func TestExpr_Eval(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	env := map[string]float64{"errors": 5, "calls": 50, "cpu.used": 1.5, "cpu.limit": 2}
+	lookup := func(name string) float64 { return env[name] }
+
+	cases := map[string]float64{
+		"errors / calls":                0.1,
+		"1 + 2 * 3":                     7,
+		"(1 + 2) * 3":                   9,
+		"-errors + 10":                  5,
+		"2 - 3 - 4":                     -5,
+		"100 * cpu.used / cpu.limit":    75,
+		"max(errors, calls, 7)":         50,
+		"min(abs(-3), 2.5e0)":           2.5,
+		"1e-3 * calls":                  0.05,
+		"errors / (calls - 50)":         math.NaN(),
+		"max(errors / (calls - 50), 1)": math.NaN(),
+	}
+
+	for src, expected := range cases {
+		// Act
+		e, err := expr.Parse(src)
+		require.NoError(t, err, src)
+		actual := e.Eval(lookup)
+
+		// Assert
+		if math.IsNaN(expected) {
+			assert.True(t, math.IsNaN(actual), "%s: expected NaN, got %v", src, actual)
+			continue
+		}
+		assert.InDelta(t, expected, actual, 1e-9, src)
+	}
+}
+
+func TestExpr_Refs(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Act
+	e, err := expr.Parse("(errors + timeouts) / max(calls, 1) + errors")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"errors", "timeouts", "calls"}, e.Refs())
+}
+
+func TestExpr_ParseErrors(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	cases := map[string]string{
+		"errors /":        "unexpected end of expression",
+		"errors calls":    "unexpected 'calls' at position 8",
+		"(errors / calls": "unexpected end of expression",
+		"errors % calls":  "unexpected '%' at position 8",
+		"sqrt(errors)":    "unknown function 'sqrt' at position 1",
+		"abs(1, 2)":       "wrong number of arguments to abs at position 1",
+		"max()":           "wrong number of arguments to max at position 1",
+		"1.2.3":           "invalid number '1.2.3' at position 1",
+	}
+
+	for src, expected := range cases {
+		// Act
+		_, err := expr.Parse(src)
+
+		// Assert
+		require.Error(t, err, src)
+		assert.Contains(t, err.Error(), expected, src)
+	}
+}
@@ -0,0 +1,99 @@
+package unit
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+func TestFill_Forward(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	values := []float64{nan, 1, nan, 2, nan, nan, nan, 3}
+
+	// Act
+	unlimited := dataset.FillForward(values, 0)
+	bounded := dataset.FillForward(values, 2)
+
+	// Assert
+	assertSeries(t, []float64{nan, 1, 1, 2, 2, 2, 2, 3}, unlimited) // nothing to carry into the leading gap
+	assertSeries(t, []float64{nan, 1, 1, 2, nan, nan, nan, 3}, bounded)
+	assert.True(t, math.IsNaN(values[2]), "input must not be modified")
+}
+
+func TestFill_Linear(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	values := []float64{nan, 0, nan, nan, 3, nan, nan, nan, 7, nan}
+
+	// Act
+	unlimited := dataset.FillLinear(values, 0)
+	bounded := dataset.FillLinear(values, 2)
+
+	// Assert
+	assertSeries(t, []float64{nan, 0, 1, 2, 3, 4, 5, 6, 7, nan}, unlimited) // no extrapolation at the edges
+	assertSeries(t, []float64{nan, 0, 1, 2, 3, nan, nan, nan, 7, nan}, bounded)
+}
+
+func TestFill_ConstantAndMean(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	values := []float64{2, nan, 4, nan}
+
+	// Act
+	zero := dataset.FillConstant(values, 0)
+	mean := dataset.FillConstant(values, dataset.Mean(values))
+
+	// Assert
+	assertSeries(t, []float64{2, 0, 4, 0}, zero)
+	assertSeries(t, []float64{2, 3, 4, 3}, mean)
+	assert.True(t, math.IsNaN(dataset.Mean([]float64{nan, nan})))
+}
+
+func TestFill_Gaps(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+
+	// Act
+	gaps := dataset.Gaps([]float64{nan, 1, nan, nan, 2, nan})
+
+	// Assert
+	assert.Equal(t, []dataset.Gap{{Start: 0, End: 1}, {Start: 2, End: 4}, {Start: 5, End: 6}}, gaps)
+}
+
+func assertSeries(t *testing.T, expected []float64, actual []float64) {
+	t.Helper()
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	for i := range expected {
+		if math.IsNaN(expected[i]) {
+			assert.True(t, math.IsNaN(actual[i]), "index %d: expected NaN, got %v", i, actual[i])
+			continue
+		}
+		assert.InDelta(t, expected[i], actual[i], 1e-9, "index %d", i)
+	}
+}
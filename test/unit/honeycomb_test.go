@@ -0,0 +1,151 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+	"github.com/w-h-a/caus/internal/client/fetcher/honeycomb"
+)
+
+type honeycombStandIn struct {
+	mu        sync.Mutex
+	apiKeys   []string
+	querySpec map[string]any
+	polls     int
+}
+
+func (h *honeycombStandIn) handler(t0 time.Time, t1 time.Time) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /1/queries/{dataset}", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.apiKeys = append(h.apiKeys, r.Header.Get("X-Honeycomb-Team"))
+		_ = json.NewDecoder(r.Body).Decode(&h.querySpec)
+		h.querySpec["dataset"] = r.PathValue("dataset")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "q1"})
+	})
+
+	mux.HandleFunc("POST /1/query_results/{dataset}", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "r1", "complete": false})
+	})
+
+	mux.HandleFunc("GET /1/query_results/{dataset}/{id}", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.polls++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":       r.PathValue("id"),
+			"complete": true,
+			"data": map[string]any{
+				"series": []map[string]any{
+					{"time": t0.Format(time.RFC3339), "data": map[string]any{"P95(duration_ms)": 120.5}},
+					{"time": t1.Format(time.RFC3339), "data": map[string]any{"P95(duration_ms)": 98.0}},
+				},
+			},
+		})
+	})
+
+	return mux
+}
+
+func TestHoneycombFetcher_Fetch(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Minute)
+	step := time.Minute
+
+	t0 := start
+	t1 := t0.Add(step)
+
+	standIn := &honeycombStandIn{}
+	server := httptest.NewServer(standIn.handler(t0, t1))
+	defer server.Close()
+
+	hFetcher := honeycomb.NewFetcher(
+		fetcher.WithLocation(server.URL),
+		fetcher.WithApiKey("secret"),
+	)
+
+	v := variable.VariableDefinition{
+		Name:   "checkout_latency",
+		Source: &variable.Source{Type: "traces", Impl: "honeycomb", Loc: server.URL},
+		TraceQuery: &variable.TraceQueryDetails{
+			ServiceName:       "checkout",
+			Dimension:         "duration",
+			AggregationOption: "p95",
+			SpanName:          "POST /orders",
+			SpanKind:          "SERVER",
+			AttributeQueries: []variable.AttributeQuery{
+				{Key: "http.route", Value: "/orders", Operator: "equals"},
+				{Key: "error", Value: "true", Operator: "equals"},
+			},
+		},
+	}
+
+	// Act
+	series, err := hFetcher.Fetch(context.Background(), v, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, map[time.Time]float64{t0: 120.5, t1: 98.0}, series)
+	assert.Equal(t, 1, standIn.polls)
+	assert.Equal(t, []string{"secret"}, standIn.apiKeys)
+
+	spec := standIn.querySpec
+	assert.Equal(t, "checkout", spec["dataset"])
+	assert.Equal(t, float64(60), spec["granularity"])
+	assert.Equal(t, float64(start.Unix()), spec["start_time"])
+	assert.Equal(t, float64(end.Unix()), spec["end_time"])
+	assert.Equal(t, []any{map[string]any{"op": "P95", "column": "duration_ms"}}, spec["calculations"])
+	assert.Equal(t, []any{
+		map[string]any{"column": "name", "op": "=", "value": "POST /orders"},
+		map[string]any{"column": "span.kind", "op": "=", "value": "server"},
+		map[string]any{"column": "http.route", "op": "=", "value": "/orders"},
+		map[string]any{"column": "error", "op": "=", "value": true},
+	}, spec["filters"])
+}
+
+func TestHoneycombFetcher_FetchError(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"unknown API key"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	hFetcher := honeycomb.NewFetcher(fetcher.WithLocation(server.URL))
+
+	v := variable.VariableDefinition{
+		Name:       "checkout_calls",
+		Source:     &variable.Source{Type: "traces", Impl: "honeycomb", Loc: server.URL},
+		TraceQuery: &variable.TraceQueryDetails{ServiceName: "checkout", Dimension: "calls"},
+	}
+
+	// Act
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	_, err := hFetcher.Fetch(context.Background(), v, start, start.Add(time.Minute), time.Minute)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
@@ -0,0 +1,313 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/jobstore"
+	"github.com/w-h-a/caus/internal/client/jobstore/bolt"
+	"github.com/w-h-a/caus/internal/service/jobs"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"google.golang.org/protobuf/proto"
+)
+
+func openJobStore(t *testing.T, path string) jobstore.Store {
+	store, err := bolt.NewStore(jobstore.WithLocation(path))
+	require.NoError(t, err)
+	return store
+}
+
+// waitJob follows the log of a job until it finishes and returns the job.
+func waitJob(t *testing.T, svc *jobs.Service, id string) (*caus.Job, []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var messages []string
+	err := svc.Logs(ctx, id, 0, true, func(e *caus.LogEntry) error {
+		messages = append(messages, e.Message)
+		return nil
+	})
+	require.NoError(t, err)
+
+	job, err := svc.Get(ctx, id)
+	require.NoError(t, err)
+
+	return job, messages
+}
+
+// blockingJob runs until its context is done, after signalling started.
+func blockingJob(started chan<- struct{}) jobs.Func {
+	return func(ctx context.Context) (proto.Message, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+}
+
+func TestJobs_Store(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	store := openJobStore(t, path)
+
+	graph := &causal.CausalGraph{Nodes: []*causal.Node{{Id: 0, Label: "cpu"}}}
+	older := &caus.Job{Id: "a", Kind: jobs.KindDiscover, State: jobs.StateSucceeded, CreatedAt: 1000, Result: &caus.Job_Graph{Graph: graph}}
+	newer := &caus.Job{Id: "b", Kind: jobs.KindEstimate, State: jobs.StateRunning, CreatedAt: 2000}
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, older))
+	require.NoError(t, store.Put(ctx, newer))
+	for _, msg := range []string{"one", "two", "three"} {
+		_, err := store.AppendLog(ctx, "a", time.UnixMilli(1500), msg)
+		require.NoError(t, err)
+	}
+
+	// Act
+	require.NoError(t, store.Close())
+	store = openJobStore(t, path)
+	defer store.Close()
+
+	got, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+
+	list, err := store.List(ctx)
+	require.NoError(t, err)
+
+	logs, err := store.Logs(ctx, "a", 1)
+	require.NoError(t, err)
+
+	_, missing := store.Get(ctx, "c")
+
+	// Assert
+	assert.True(t, proto.Equal(older, got))
+
+	require.Len(t, list, 2)
+	assert.Equal(t, "b", list[0].Id)
+	assert.Equal(t, "a", list[1].Id)
+	assert.Nil(t, list[1].Result)
+
+	require.Len(t, logs, 2)
+	assert.Equal(t, uint64(2), logs[0].Seq)
+	assert.Equal(t, "two", logs[0].Message)
+	assert.Equal(t, "three", logs[1].Message)
+	assert.Equal(t, int64(1500), logs[1].Time)
+
+	assert.ErrorIs(t, missing, jobstore.ErrNotFound)
+}
+
+func TestJobs_Succeed(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	store := openJobStore(t, filepath.Join(t.TempDir(), "jobs.db"))
+	defer store.Close()
+
+	svc, err := jobs.New(store)
+	require.NoError(t, err)
+
+	graph := &causal.CausalGraph{Nodes: []*causal.Node{{Id: 0, Label: "cpu"}}}
+
+	task := svc.NewTask(jobs.KindDiscover)
+
+	// Act
+	queued, err := svc.Submit(context.Background(), task, func(ctx context.Context) (proto.Message, error) {
+		task.Logger().Printf("fetching %d variables", 2)
+		task.Progress(orchestrator.Progress{Stage: orchestrator.StageFetch, Done: 2, Total: 2})
+		return graph, nil
+	})
+	require.NoError(t, err)
+
+	job, messages := waitJob(t, svc, task.ID())
+
+	// Assert
+	assert.Equal(t, task.ID(), queued.Id)
+	assert.Equal(t, jobs.StateQueued, queued.State)
+
+	assert.Equal(t, jobs.StateSucceeded, job.State)
+	assert.Empty(t, job.Error)
+	assert.True(t, proto.Equal(graph, job.GetGraph()))
+	assert.True(t, proto.Equal(&caus.JobProgress{Stage: "fetch", Done: 2, Total: 2}, job.Progress))
+	assert.NotZero(t, job.StartedAt)
+	assert.GreaterOrEqual(t, job.FinishedAt, job.StartedAt)
+
+	assert.Equal(t, []string{"Queued discover job", "Started", "fetching 2 variables", "Finished as succeeded"}, messages)
+}
+
+func TestJobs_Fail(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	store := openJobStore(t, filepath.Join(t.TempDir(), "jobs.db"))
+	defer store.Close()
+
+	svc, err := jobs.New(store)
+	require.NoError(t, err)
+
+	task := svc.NewTask(jobs.KindEstimate)
+
+	// Act
+	_, err = svc.Submit(context.Background(), task, func(ctx context.Context) (proto.Message, error) {
+		return nil, errors.New("failed to fetch 'cpu': connection refused")
+	})
+	require.NoError(t, err)
+
+	job, messages := waitJob(t, svc, task.ID())
+
+	// Assert
+	assert.Equal(t, jobs.StateFailed, job.State)
+	assert.Equal(t, "failed to fetch 'cpu': connection refused", job.Error)
+	assert.Nil(t, job.Result)
+	assert.Equal(t, "Finished as failed: failed to fetch 'cpu': connection refused", messages[len(messages)-1])
+}
+
+func TestJobs_Cancel(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	store := openJobStore(t, filepath.Join(t.TempDir(), "jobs.db"))
+	defer store.Close()
+
+	svc, err := jobs.New(store, jobs.WithMaxRunning(1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	running := svc.NewTask(jobs.KindDiscover)
+	_, err = svc.Submit(ctx, running, blockingJob(started))
+	require.NoError(t, err)
+	<-started
+
+	waiting := svc.NewTask(jobs.KindDiscover)
+	_, err = svc.Submit(ctx, waiting, blockingJob(make(chan struct{})))
+	require.NoError(t, err)
+
+	// Act
+	queued, err := svc.Get(ctx, waiting.ID())
+	require.NoError(t, err)
+
+	_, err = svc.Cancel(ctx, waiting.ID())
+	require.NoError(t, err)
+	cancelledQueued, _ := waitJob(t, svc, waiting.ID())
+
+	_, err = svc.Cancel(ctx, running.ID())
+	require.NoError(t, err)
+	cancelledRunning, messages := waitJob(t, svc, running.ID())
+
+	_, again := svc.Cancel(ctx, running.ID())
+	_, missing := svc.Cancel(ctx, "nope")
+
+	// Assert
+	assert.Equal(t, jobs.StateQueued, queued.State)
+
+	assert.Equal(t, jobs.StateCancelled, cancelledQueued.State)
+	assert.Zero(t, cancelledQueued.StartedAt)
+
+	assert.Equal(t, jobs.StateCancelled, cancelledRunning.State)
+	assert.Equal(t, "cancelled", cancelledRunning.Error)
+	assert.Contains(t, messages, "Cancel requested")
+
+	require.ErrorIs(t, again, jobs.ErrFinished)
+	assert.Equal(t, "job already finished (cancelled)", again.Error())
+	require.ErrorIs(t, missing, jobs.ErrNotFound)
+	assert.Equal(t, "job not found: 'nope'", missing.Error())
+}
+
+func TestJobs_Timeout(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	store := openJobStore(t, filepath.Join(t.TempDir(), "jobs.db"))
+	defer store.Close()
+
+	svc, err := jobs.New(store, jobs.WithTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	task := svc.NewTask(jobs.KindDiscover)
+
+	// Act
+	_, err = svc.Submit(context.Background(), task, blockingJob(make(chan struct{})))
+	require.NoError(t, err)
+
+	job, _ := waitJob(t, svc, task.ID())
+
+	// Assert
+	assert.Equal(t, jobs.StateFailed, job.State)
+	assert.Equal(t, "interrupted: timed out after 50ms", job.Error)
+	assert.NotZero(t, job.StartedAt)
+}
+
+func TestJobs_CloseAndRecover(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	store := openJobStore(t, path)
+
+	svc, err := jobs.New(store)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	interrupted := svc.NewTask(jobs.KindDiscover)
+	_, err = svc.Submit(ctx, interrupted, blockingJob(started))
+	require.NoError(t, err)
+	<-started
+
+	// a job that was running when its process died
+	require.NoError(t, store.Put(ctx, &caus.Job{Id: "orphan", Kind: jobs.KindEstimate, State: jobs.StateRunning, CreatedAt: 1}))
+
+	// Act
+	require.NoError(t, svc.Close(ctx))
+	_, afterClose := svc.Submit(ctx, svc.NewTask(jobs.KindDiscover), blockingJob(make(chan struct{})))
+	require.NoError(t, store.Close())
+
+	store = openJobStore(t, path)
+	defer store.Close()
+
+	svc, err = jobs.New(store)
+	require.NoError(t, err)
+
+	shutDown, err := svc.Get(ctx, interrupted.ID())
+	require.NoError(t, err)
+
+	orphan, messages := waitJob(t, svc, "orphan")
+
+	// Assert
+	assert.ErrorIs(t, afterClose, jobs.ErrShutdown)
+
+	assert.Equal(t, jobs.StateFailed, shutDown.State)
+	assert.Equal(t, "interrupted: the server is shutting down", shutDown.Error)
+
+	assert.Equal(t, jobs.StateFailed, orphan.State)
+	assert.Equal(t, "interrupted: the server restarted", orphan.Error)
+	assert.Equal(t, []string{"Finished as failed: interrupted: the server restarted"}, messages)
+}
@@ -0,0 +1,148 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+	"github.com/w-h-a/caus/internal/client/discoverer/native"
+	discovererv1alpha2 "github.com/w-h-a/caus/internal/client/discoverer/v1alpha2"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestNativeDiscoverer_GroundTruth(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	csvData, err := os.ReadFile("../test_data/ground_truth.csv")
+	require.NoError(t, err)
+
+	bs, err := os.ReadFile("../test_graph/ground_truth_graph.json")
+	require.NoError(t, err)
+	var expected causal.CausalGraph
+	require.NoError(t, protojson.Unmarshal(bs, &expected))
+
+	nDiscoverer := native.NewDiscoverer()
+
+	// Act
+	strict, err := nDiscoverer.Discover(context.Background(), &causal.DiscoverRequest{
+		CsvData: string(csvData),
+		MaxLag:  3,
+		PcAlpha: 0.005,
+	})
+	require.NoError(t, err)
+
+	chosen, err := nDiscoverer.Discover(context.Background(), &causal.DiscoverRequest{
+		CsvData: string(csvData),
+		MaxLag:  3,
+		PcAlpha: 0, // picked per variable
+	})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, expected.Nodes[0].Label, strict.Nodes[0].Label)
+	assert.Equal(t, len(expected.Nodes), len(strict.Nodes))
+	assert.Subset(t, edgeKeys(strict.Edges), edgeKeys(expected.Edges))
+	assert.ElementsMatch(t, edgeKeys(strict.Edges), edgeKeys(chosen.Edges)) // pc_alpha only selects parents, links are kept at the MCI level
+
+	truth := edgeKeys(expected.Edges)
+	for _, e := range append(strict.Edges, chosen.Edges...) {
+		assert.LessOrEqual(t, e.PValue, float32(0.05), e.String())
+		if slices.Contains(truth, edgeKeys([]*causal.Edge{e})[0]) {
+			assert.Greater(t, e.PartialCorr, float32(0.3), e.String()) // every true link has a coefficient of 0.5
+			assert.Greater(t, e.Statistic, float32(0), e.String())
+		} else {
+			assert.Less(t, math.Abs(float64(e.PartialCorr)), 0.1, e.String()) // a false positive at the 5% level is weak
+		}
+	}
+}
+
+func TestNativeDiscoverer_MatchesWorker(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) == 0 {
+		t.Log("SKIPPING INTEGRATION TEST")
+		return
+	}
+
+	// Arrange
+	csvData, err := os.ReadFile("../test_data/ground_truth.csv")
+	require.NoError(t, err)
+
+	addr := os.Getenv("CAUS_WORKER_ADDR")
+	if len(addr) == 0 {
+		addr = "localhost:50051"
+	}
+
+	wDiscoverer, err := discovererv1alpha2.NewDiscoverer(discoverer.WithLocation(addr))
+	require.NoError(t, err)
+
+	nDiscoverer := native.NewDiscoverer()
+
+	for _, pcAlpha := range []float32{0.01, 0} {
+		req := &causal.DiscoverRequest{
+			CsvData: string(csvData),
+			MaxLag:  3,
+			PcAlpha: pcAlpha,
+		}
+
+		// Act
+		fromWorker, err := wDiscoverer.Discover(context.Background(), req)
+		require.NoError(t, err)
+
+		fromNative, err := nDiscoverer.Discover(context.Background(), req)
+		require.NoError(t, err)
+
+		// Assert
+		assert.ElementsMatch(t, edgeKeys(fromWorker.Edges), edgeKeys(fromNative.Edges), "pc_alpha %g", pcAlpha)
+	}
+}
+
+func TestNativeDiscoverer_Contemporaneous(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	// a and b share a same-step driver, so they are linked at lag 0 with no
+	// way to tell which way round
+	rng := rand.New(rand.NewPCG(1, 2))
+	var sb strings.Builder
+	sb.WriteString("a,b\n")
+	for range 300 {
+		common := rng.NormFloat64()
+		fmt.Fprintf(&sb, "%f,%f\n", common+0.5*rng.NormFloat64(), common+0.5*rng.NormFloat64())
+	}
+
+	nDiscoverer := native.NewDiscoverer()
+
+	// Act
+	graph, err := nDiscoverer.Discover(context.Background(), &causal.DiscoverRequest{
+		CsvData: sb.String(),
+		MaxLag:  1,
+		PcAlpha: 0.01,
+	})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"a -undirected-> b (lag 0)"}, edgeKeys(graph.Edges))
+}
+
+func edgeKeys(edges []*causal.Edge) []string {
+	keys := make([]string, len(edges))
+	for i, e := range edges {
+		keys[i] = fmt.Sprintf("%s -%s-> %s (lag %d)", e.Source, e.Type, e.Target, e.Lag)
+	}
+	return keys
+}
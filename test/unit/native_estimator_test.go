@@ -0,0 +1,183 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/estimator/native"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestNativeEstimator_ExactFit(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	// y[t] = 2 + 3*x[t-1] - 0.5*x[t]
+	csvData := "x,y\n" +
+		"1,0\n" +
+		"2,4\n" +
+		"4,6\n" +
+		"3,12.5\n" +
+		"7,7.5\n"
+
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "x"}, {Id: 1, Label: "y"}},
+		Edges: []*causal.Edge{
+			{Source: "x", Target: "y", Type: "directed", Lag: 1},
+			{Source: "x", Target: "y", Type: "directed", Lag: 0},
+		},
+	}
+
+	nEstimator := native.NewEstimator()
+
+	// Act
+	rsp, err := nEstimator.Estimate(context.Background(), &causal.EstimateRequest{CsvData: csvData, Graph: graph})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, rsp.Models, 1) // x has no parents so it gets no model
+	model := rsp.Models["y"]
+	require.NotNil(t, model)
+	assert.Equal(t, []string{"x_lag1", "x_lag0"}, model.Features)
+	assert.InDelta(t, 3.0, model.Coefficients[0], 1e-4)
+	assert.InDelta(t, -0.5, model.Coefficients[1], 1e-4)
+	assert.InDelta(t, 2.0, model.Intercept, 1e-4)
+}
+
+func TestNativeEstimator_GroundTruth(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	csvData, err := os.ReadFile("../test_data/ground_truth.csv")
+	require.NoError(t, err)
+
+	bs, err := os.ReadFile("../test_graph/ground_truth_graph.json")
+	require.NoError(t, err)
+	var graph causal.CausalGraph
+	require.NoError(t, protojson.Unmarshal(bs, &graph))
+
+	nEstimator := native.NewEstimator()
+
+	// Act
+	rsp, err := nEstimator.Estimate(context.Background(), &causal.EstimateRequest{CsvData: string(csvData), Graph: &graph})
+	require.NoError(t, err)
+
+	// Assert
+	// every link in the generating process has a coefficient of 0.5
+	require.Len(t, rsp.Models, 3)
+	for node, model := range rsp.Models {
+		for i, feature := range model.Features {
+			assert.InDelta(t, 0.5, model.Coefficients[i], 0.1, "%s <- %s", node, feature)
+		}
+		assert.InDelta(t, 0.0, model.Intercept, 0.1, node)
+	}
+	assert.Equal(t, []string{"service_b_lag1", "service_a_lag1"}, rsp.Models["service_b"].Features)
+}
+
+func TestNativeEstimator_Inference(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	// textbook regression: slope 0.6, intercept 2.2, SSR 2.4 on 3 df
+	csvData := "x,y\n" +
+		"1,2\n" +
+		"2,4\n" +
+		"3,5\n" +
+		"4,4\n" +
+		"5,5\n"
+
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "x"}, {Id: 1, Label: "y"}},
+		Edges: []*causal.Edge{
+			{Source: "x", Target: "y", Type: "directed", Lag: 0},
+		},
+	}
+
+	nEstimator := native.NewEstimator()
+
+	// Act
+	rsp, err := nEstimator.Estimate(context.Background(), &causal.EstimateRequest{CsvData: csvData, Graph: graph})
+	require.NoError(t, err)
+
+	// Assert
+	model := rsp.Models["y"]
+	require.NotNil(t, model)
+	assert.Equal(t, "analytic", model.Inference)
+	assert.InDelta(t, 0.95, model.ConfidenceLevel, 1e-6)
+	assert.Equal(t, int32(5), model.NObs)
+	assert.InDelta(t, 0.6, model.Coefficients[0], 1e-4)
+	assert.InDelta(t, 0.2828, model.StandardErrors[0], 1e-4)
+	assert.InDelta(t, 2.1213, model.TStatistics[0], 1e-4)
+	assert.InDelta(t, 0.1240, model.PValues[0], 1e-3)
+	assert.InDelta(t, -0.3001, model.CiLower[0], 1e-3)
+	assert.InDelta(t, 1.5001, model.CiUpper[0], 1e-3)
+	assert.InDelta(t, 0.6, model.RSquared, 1e-4)
+	assert.InDelta(t, 0.8, model.ResidualVariance, 1e-4)
+}
+
+func TestNativeEstimator_Bootstrap(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	csvData, err := os.ReadFile("../test_data/ground_truth.csv")
+	require.NoError(t, err)
+
+	bs, err := os.ReadFile("../test_graph/ground_truth_graph.json")
+	require.NoError(t, err)
+	var graph causal.CausalGraph
+	require.NoError(t, protojson.Unmarshal(bs, &graph))
+
+	req := &causal.EstimateRequest{
+		CsvData:         string(csvData),
+		Graph:           &graph,
+		ConfidenceLevel: 0.99,
+		Bootstrap:       &causal.Bootstrap{Samples: 300, BlockSize: 5, Seed: 42},
+	}
+
+	nEstimator := native.NewEstimator()
+
+	// Act
+	first, err := nEstimator.Estimate(context.Background(), req)
+	require.NoError(t, err)
+
+	second, err := nEstimator.Estimate(context.Background(), req)
+	require.NoError(t, err)
+
+	req.Bootstrap.Seed = 0
+	unseeded, err := nEstimator.Estimate(context.Background(), req)
+	require.NoError(t, err)
+
+	reseeded, err := nEstimator.Estimate(context.Background(), req)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, first.Models, 3)
+	for node, model := range first.Models {
+		assert.Equal(t, "bootstrap", model.Inference, node)
+		assert.InDelta(t, 0.99, model.ConfidenceLevel, 1e-6, node)
+		assert.Equal(t, second.Models[node].StandardErrors, model.StandardErrors, "same seed, same resamples")
+		assert.NotEqual(t, reseeded.Models[node].StandardErrors, unseeded.Models[node].StandardErrors, "seed 0, fresh resamples")
+		for i, feature := range model.Features {
+			assert.Greater(t, model.StandardErrors[i], float32(0), "%s <- %s", node, feature)
+			assert.Less(t, model.PValues[i], float32(0.001), "%s <- %s", node, feature)
+			assert.LessOrEqual(t, model.CiLower[i], float32(0.5), "%s <- %s", node, feature)
+			assert.GreaterOrEqual(t, model.CiUpper[i], float32(0.5), "%s <- %s", node, feature)
+		}
+	}
+}
@@ -1,9 +1,15 @@
 package unit
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
+	"errors"
+	"log"
+	"math"
+	"math/rand"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -12,13 +18,22 @@ import (
 	"github.com/stretchr/testify/require"
 	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
 	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	"github.com/w-h-a/caus/internal/client/annotator"
+	"github.com/w-h-a/caus/internal/client/annotator/file"
+	mockannotator "github.com/w-h-a/caus/internal/client/annotator/mock"
 	mockdiscoverer "github.com/w-h-a/caus/internal/client/discoverer/mock"
 	noopdisc "github.com/w-h-a/caus/internal/client/discoverer/noop"
 	mockestimator "github.com/w-h-a/caus/internal/client/estimator/mock"
+	nativeest "github.com/w-h-a/caus/internal/client/estimator/native"
 	noopest "github.com/w-h-a/caus/internal/client/estimator/noop"
 	"github.com/w-h-a/caus/internal/client/fetcher"
+	csvfetcher "github.com/w-h-a/caus/internal/client/fetcher/csv"
+	"github.com/w-h-a/caus/internal/client/fetcher/events"
 	mockfetcher "github.com/w-h-a/caus/internal/client/fetcher/mock"
+	"github.com/w-h-a/caus/internal/client/fetcher/random"
+	"github.com/w-h-a/caus/internal/dataset"
 	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"github.com/w-h-a/caus/internal/stats"
 )
 
 func TestOrchestrator_Discover(t *testing.T) {
@@ -70,7 +85,7 @@ func TestOrchestrator_Discover(t *testing.T) {
 		{Name: "var_b", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
 	}
 
-	_, err := svc.Discover(context.Background(), vars, start, end, step, orchestrator.DiscoveryArgs{MaxLag: 1, PcAlpha: 0.05})
+	graph, err := svc.Discover(context.Background(), vars, start, end, step, orchestrator.DiscoveryArgs{MaxLag: 1, PcAlpha: 0.05})
 	require.NoError(t, err)
 
 	// Assert
@@ -94,6 +109,108 @@ func TestOrchestrator_Discover(t *testing.T) {
 	assert.Equal(t, "20.000000", rows[2][1]) // var_b is missing data here so should be filled with last known
 	assert.Equal(t, int32(1), req.MaxLag)
 	assert.Equal(t, float32(0.05), req.PcAlpha)
+	assert.Equal(t, "2023-10-01T17:00:00Z", graph.Window.Start) // resolved to UTC for reruns
+	assert.Equal(t, "2023-10-01T17:02:00Z", graph.Window.End)
+	assert.Equal(t, "1m0s", graph.Window.Step)
+}
+
+func TestOrchestrator_DiscoverMinStrength(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"var_a": {start: 1, end: 2},
+			"var_b": {start: 3, end: 4},
+		}),
+	)
+
+	mDiscoverer := mockdiscoverer.NewDiscoverer(
+		mockdiscoverer.WithGraph(&causal.CausalGraph{
+			Nodes: []*causal.Node{{Id: 0, Label: "var_a"}, {Id: 1, Label: "var_b"}},
+			Edges: []*causal.Edge{
+				{Source: "var_a", Target: "var_b", Type: "directed", Lag: 1, PartialCorr: 0.45, PValue: 0.0001},
+				{Source: "var_b", Target: "var_a", Type: "directed", Lag: 1, PartialCorr: -0.32, PValue: 0.002},
+				{Source: "var_b", Target: "var_b", Type: "directed", Lag: 2, PartialCorr: 0.08, PValue: 0.04},
+			},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, mDiscoverer, noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{Name: "var_a", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		{Name: "var_b", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	// Act
+	graph, err := svc.Discover(context.Background(), vars, start, end, step, orchestrator.DiscoveryArgs{MaxLag: 2, MinStrength: 0.3})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, graph.Edges, 2) // the weak self loop is pruned, the negative one is kept
+	assert.Equal(t, float32(0.45), graph.Edges[0].PartialCorr)
+	assert.Equal(t, float32(-0.32), graph.Edges[1].PartialCorr)
+}
+
+func TestOrchestrator_DiscoverMinStrengthUnreported(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"var_a": {start: 1, end: 2},
+			"var_b": {start: 3, end: 4},
+		}),
+	)
+
+	// an older worker leaves partial_corr unset
+	mDiscoverer := mockdiscoverer.NewDiscoverer(
+		mockdiscoverer.WithGraph(&causal.CausalGraph{
+			Nodes: []*causal.Node{{Id: 0, Label: "var_a"}, {Id: 1, Label: "var_b"}},
+			Edges: []*causal.Edge{
+				{Source: "var_a", Target: "var_b", Type: "directed", Lag: 1, PValue: 0.0001},
+				{Source: "var_b", Target: "var_a", Type: "directed", Lag: 1, PValue: 0.002},
+			},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, mDiscoverer, noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{Name: "var_a", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		{Name: "var_b", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	// Act
+	graph, err := svc.Discover(context.Background(), vars, start, end, step, orchestrator.DiscoveryArgs{MaxLag: 2, MinStrength: 0.3})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, graph)
+	assert.Contains(t, err.Error(), "no edge strengths")
 }
 
 func TestOrchestrator_Estimate(t *testing.T) {
@@ -142,7 +259,7 @@ func TestOrchestrator_Estimate(t *testing.T) {
 		Nodes: []*causal.Node{{Id: 0, Label: "var_a"}},
 	}
 
-	_, err := svc.Estimate(context.Background(), vars, start, end, step, orchestrator.EstimateArgs{Graph: inputGraph})
+	rsp, err := svc.Estimate(context.Background(), vars, start, end, step, orchestrator.EstimateArgs{Graph: inputGraph})
 	require.NoError(t, err)
 
 	// Assert
@@ -151,6 +268,164 @@ func TestOrchestrator_Estimate(t *testing.T) {
 	assert.True(t, len(req.CsvData) > 0)
 	assert.True(t, len(req.Graph.Nodes) == 1)
 	assert.Equal(t, "var_a", req.Graph.Nodes[0].Label)
+	assert.Equal(t, &causal.Window{Start: "2023-10-01T10:00:00Z", End: "2023-10-01T10:02:00Z", Step: "1m0s"}, rsp.Window)
+}
+
+func TestOrchestrator_EstimateUnoriented(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"var_a": {start: 1, end: 2},
+			"var_b": {start: 3, end: 4},
+		}),
+	)
+
+	mEstimator := mockestimator.NewEstimator()
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	var logs bytes.Buffer
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), mEstimator, orchestrator.WithLogger(log.New(&logs, "", 0)))
+
+	vars := []variable.VariableDefinition{
+		{Name: "var_a", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		{Name: "var_b", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	inputGraph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "var_a"}, {Id: 1, Label: "var_b"}},
+		Edges: []*causal.Edge{
+			{Source: "var_a", Target: "var_b", Type: "directed", Lag: 1},
+			{Source: "var_a", Target: "var_b", Type: "undirected", Lag: 0},
+		},
+	}
+
+	// Act
+	_, refused := svc.Estimate(context.Background(), vars, start, end, step, orchestrator.EstimateArgs{Graph: inputGraph})
+	refusedReq := mEstimator.LastRequest()
+
+	_, allowed := svc.Estimate(context.Background(), vars, start, end, step, orchestrator.EstimateArgs{Graph: inputGraph, AllowUnoriented: true})
+
+	// Assert
+	require.Error(t, refused)
+	assert.Contains(t, refused.Error(), "var_a o-o var_b (lag 0)")
+	assert.Nil(t, refusedReq) // refused before fetching or estimating
+
+	require.NoError(t, allowed)
+	req := mEstimator.LastRequest()
+	require.NotNil(t, req)
+	require.Len(t, req.Graph.Edges, 1)
+	assert.Equal(t, "directed", req.Graph.Edges[0].Type)
+	assert.Equal(t, 1, strings.Count(logs.String(), "ignoring 1 edges without a direction"))
+	assert.Len(t, inputGraph.Edges, 2) // the caller's graph is left alone
+}
+
+func TestOrchestrator_FetchProvenance(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"p95": {start: 120, end: 130},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"traces": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{
+			Name:   "p95",
+			Source: &variable.Source{Type: "traces", Impl: "mock", Loc: "clickhouse://reader:hunter2@ch:9000/otel"},
+			TraceQuery: &variable.TraceQueryDetails{
+				ServiceName:       "checkout",
+				Dimension:         "duration",
+				AggregationOption: "p95",
+				AttributeQueries:  []variable.AttributeQuery{{Key: "http.route", Value: "/pay", Operator: "equals"}},
+			},
+		},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, step, ds.Step)
+	assert.Equal(t, []float64{120, 130}, ds.Columns[0].Values)
+	p := ds.Columns[0].Provenance
+	assert.Equal(t, "traces", p.Type)
+	assert.Equal(t, "mock", p.Impl)
+	assert.Equal(t, "clickhouse://ch:9000/otel", p.Loc) // no credentials in exports
+	assert.Equal(t, "service=checkout, dimension=duration, aggregation=p95, http.route equals /pay", p.Query)
+}
+
+func TestOrchestrator_EstimateDataset(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	t0 := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	ds := &dataset.Dataset{
+		Timestamps: []time.Time{t0, t0.Add(5 * time.Minute)},
+		Step:       5 * time.Minute,
+		Columns: []dataset.Column{
+			{Name: "var_a", Values: []float64{1, 2}},
+			{Name: "var_b", Values: []float64{3, 4}},
+		},
+	}
+
+	mEstimator := mockestimator.NewEstimator()
+
+	// no fetchers: replaying a dataset must not query anything
+	svc := orchestrator.New(nil, noopdisc.NewDiscoverer(), mEstimator)
+
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "var_a"}, {Id: 1, Label: "var_b"}},
+		Edges: []*causal.Edge{{Source: "var_a", Target: "var_b", Type: "directed", Lag: 1}},
+	}
+
+	stale := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "var_a"}, {Id: 1, Label: "var_c"}},
+		Edges: []*causal.Edge{{Source: "var_a", Target: "var_c", Type: "directed", Lag: 1}},
+	}
+
+	// Act
+	rsp, err := svc.EstimateDataset(context.Background(), ds, orchestrator.EstimateArgs{Graph: graph})
+	require.NoError(t, err)
+
+	_, staleErr := svc.EstimateDataset(context.Background(), ds, orchestrator.EstimateArgs{Graph: stale})
+
+	// Assert
+	req := mEstimator.LastRequest()
+	require.NotNil(t, req)
+	assert.Equal(t, "var_a,var_b\n1.000000,3.000000\n2.000000,4.000000\n", req.CsvData)
+	assert.Equal(t, &causal.Window{Start: "2023-10-01T10:00:00Z", End: "2023-10-01T10:05:00Z", Step: "5m0s"}, rsp.Window)
+	assert.ErrorContains(t, staleErr, "dataset has no column for graph nodes: var_c")
 }
 
 func TestOrchestrator_FetchAlignment(t *testing.T) {
@@ -188,3 +463,1198 @@ func TestOrchestrator_FetchAlignment(t *testing.T) {
 	expectedStart := start.Truncate(step)
 	assert.Equal(t, expectedStart, mFetcher.CalledStart()) //Fetcher received 10:00:00, NOT 10:00:47
 }
+
+func TestOrchestrator_FetchConcurrency(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	names := []string{"var_a", "var_b", "var_c", "var_d", "var_e", "var_f"}
+
+	mockData := map[string]map[time.Time]float64{}
+	release := map[string]chan struct{}{}
+	vars := []variable.VariableDefinition{}
+	for _, name := range names {
+		mockData[name] = map[time.Time]float64{start: float64(len(vars))}
+		release[name] = make(chan struct{})
+		vars = append(vars, variable.VariableDefinition{Name: name, Source: &variable.Source{Type: "metrics", Impl: "mock"}})
+	}
+
+	entered := make(chan string, len(names))
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(mockData),
+		mockfetcher.WithEntered(entered),
+		mockfetcher.WithRelease(release),
+	)
+
+	mDiscoverer := mockdiscoverer.NewDiscoverer()
+
+	nEstimator := noopest.NewEstimator()
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(
+		fetchers,
+		mDiscoverer,
+		nEstimator,
+		orchestrator.WithConcurrency(4),
+		orchestrator.WithImplConcurrency("mock", 2),
+	)
+
+	// Act
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.Discover(context.Background(), vars, start, end, step, orchestrator.DiscoveryArgs{})
+		done <- err
+	}()
+
+	// release the fetches one at a time, noting how many are in flight each
+	// time another one starts
+	pending := []string{<-entered, <-entered}
+	inFlight := []int{mFetcher.InFlight()}
+	for started := len(pending); started < len(names); started++ {
+		close(release[pending[0]])
+		pending = append(pending[1:], <-entered)
+		inFlight = append(inFlight, mFetcher.InFlight())
+	}
+	for _, name := range pending {
+		close(release[name])
+	}
+
+	err := <-done
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []int{2, 2, 2, 2, 2}, inFlight) // per impl bound is tighter than the global one
+	assert.Equal(t, 2, mFetcher.MaxInFlight())
+
+	rows, _ := csv.NewReader(strings.NewReader(mDiscoverer.LastRequest().CsvData)).ReadAll()
+	assert.Equal(t, []string{"var_a", "var_b", "var_c", "var_d", "var_e", "var_f"}, rows[0]) // column order follows vars, not completion order
+	assert.Equal(t, []string{"0.000000", "1.000000", "2.000000", "3.000000", "4.000000", "5.000000"}, rows[1])
+}
+
+func TestOrchestrator_FetchErrorCancels(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	entered := make(chan string, 2)
+	release := map[string]chan struct{}{"slow": make(chan struct{}), "broken": make(chan struct{})}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithEntered(entered),
+		mockfetcher.WithRelease(release),
+		mockfetcher.WithErrors(map[string]error{"broken": errors.New("boom")}),
+	)
+
+	mDiscoverer := mockdiscoverer.NewDiscoverer()
+
+	nEstimator := noopest.NewEstimator()
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, mDiscoverer, nEstimator)
+
+	vars := []variable.VariableDefinition{
+		{Name: "slow", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		{Name: "broken", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	// Act
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.Discover(context.Background(), vars, start, end, step, orchestrator.DiscoveryArgs{})
+		done <- err
+	}()
+
+	// fail 'broken' only once both fetches are in flight; 'slow' is never
+	// released
+	<-entered
+	<-entered
+	close(release["broken"])
+
+	err := <-done
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch 1 of 2 variables")
+	assert.Contains(t, err.Error(), "failed to fetch 'broken': boom")
+	assert.NotContains(t, err.Error(), "slow")
+	assert.Equal(t, []string{"slow"}, mFetcher.Canceled())
+	assert.Nil(t, mDiscoverer.LastRequest())
+}
+
+func TestOrchestrator_FetchFill(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"default": {ts(1): 5, ts(4): 8},
+			"linear":  {ts(0): 0, ts(4): 4},
+			"mean":    {ts(0): 1, ts(1): 3},
+			"none":    {ts(0): 1, ts(2): 3, ts(3): 4, ts(4): 5},
+			"drop":    {ts(0): 1, ts(1): 1, ts(2): 1, ts(4): 1},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "default", Source: src},
+		{Name: "linear", Source: src, Fill: &variable.FillPolicy{Strategy: variable.FillLinear}},
+		{Name: "mean", Source: src, Fill: &variable.FillPolicy{Strategy: variable.FillMean}},
+		{Name: "none", Source: src, Fill: &variable.FillPolicy{Strategy: variable.FillNone}},
+		{Name: "drop", Source: src, Fill: &variable.FillPolicy{Strategy: variable.FillDrop}},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	nan := math.NaN()
+	assert.Equal(t, 5, ds.Len())                                        // dropped rows keep their place on the grid
+	assertSeries(t, []float64{nan, 5, 5, nan, 8}, ds.Columns[0].Values) // no fake leading zero
+	assertSeries(t, []float64{0, 1, 2, nan, 4}, ds.Columns[1].Values)
+	assertSeries(t, []float64{1, 3, 2, nan, 2}, ds.Columns[2].Values)
+	assertSeries(t, []float64{1, nan, 3, nan, 5}, ds.Columns[3].Values)
+	assertSeries(t, []float64{1, 1, 1, nan, 1}, ds.Columns[4].Values)
+	assert.Equal(t, "ffill", ds.Columns[0].Provenance.Fill)
+	assert.Equal(t, "drop", ds.Columns[4].Provenance.Fill)
+}
+
+func TestOrchestrator_FetchTooSparse(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Minute)
+	step := time.Minute
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"dense":  {start: 1, start.Add(step): 2, start.Add(2 * step): 3, end: 4},
+			"sparse": {end: 1},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator(), orchestrator.WithMaxMissingRatio(0.5))
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "dense", Source: src},
+		{Name: "sparse", Source: src},
+	}
+
+	// Act
+	_, err := svc.Fetch(context.Background(), vars, start, end, step)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sparse: 75.0% missing (3 of 4 steps, longest gap 3 steps)")
+	assert.NotContains(t, err.Error(), "dense")
+}
+
+func TestOrchestrator_FetchQuality(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(5 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"flat": {ts(0): 5, ts(1): 5, ts(2): 5, ts(3): 5, ts(4): 5, ts(5): 5},
+			"spiky": {
+				ts(0): 1, ts(1): 2, ts(2): 1, ts(4): 100, ts(5): 2,
+				ts(1).Add(10 * time.Second): 3, // in an occupied step at a 1m resolution
+				ts(8):                       7, // outside the window
+			},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		fetchers,
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithQualityRules(variable.QualityRules{FailOnConstant: true, MaxFilledRatio: 0.1}),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "flat", Source: src},
+		{Name: "spiky", Source: src},
+	}
+
+	// Act
+	_, err := svc.Fetch(context.Background(), vars, start, end, step)
+
+	// Assert
+	require.NotNil(t, report) // reported even though the rules fail the run
+	assert.Equal(t, 6, report.Steps)
+
+	flat := report.Variables[0]
+	assert.Equal(t, 6, flat.Observed)
+	assert.True(t, flat.Constant)
+
+	spiky := report.Variables[1]
+	assert.Equal(t, "1m0s", spiky.Resolution)
+	assert.Equal(t, 7, spiky.Points)
+	assert.Equal(t, 1, spiky.Unused)
+	assert.Equal(t, 1, spiky.Duplicates)
+	assert.Equal(t, 5, spiky.Observed)
+	assert.Equal(t, 1, spiky.Filled)
+	assert.Equal(t, 0, spiky.Missing)
+	assert.Equal(t, 1, spiky.Outliers)
+	assert.False(t, spiky.Constant)
+
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"flat: constant (zero variance)",
+		"spiky: 16.7% of steps filled (max 10.0%)",
+	}, report.Violations)
+	assert.Contains(t, err.Error(), "data quality check failed")
+	assert.Contains(t, err.Error(), "flat: constant (zero variance)")
+}
+
+func TestOrchestrator_FetchTransforms(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"requests": {ts(0): 0, ts(1): 60, ts(2): 600, ts(3): 6600},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	mDiscoverer := mockdiscoverer.NewDiscoverer(
+		mockdiscoverer.WithGraph(&causal.CausalGraph{
+			Nodes: []*causal.Node{{Id: 0, Label: "requests"}},
+		}),
+	)
+
+	svc := orchestrator.New(fetchers, mDiscoverer, noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{
+			Name:   "requests",
+			Source: &variable.Source{Type: "metrics", Impl: "mock"},
+			Transforms: []variable.Transform{
+				{Type: variable.TransformRate},
+				{Type: variable.TransformLog},
+				{Type: variable.TransformRollingMean, Window: 2},
+			},
+		},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	graph, err := svc.DiscoverDataset(context.Background(), ds, orchestrator.DiscoveryArgs{})
+	require.NoError(t, err)
+
+	// Assert
+	nan := math.NaN()
+	assertSeries(t, []float64{nan, 0, math.Log(3), math.Log(30)}, ds.Columns[0].Values) // rate 1, 9, 100 per second, logged, then averaged
+	expected := []string{"rate", "log", "rolling_mean(window=2)"}
+	assert.Equal(t, expected, ds.Columns[0].Provenance.Transforms)
+	assert.Equal(t, expected, graph.Nodes[0].Transforms)
+}
+
+func TestOrchestrator_FetchDerived(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	traces := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"calls": {ts(0): 100, ts(1): 50, ts(3): 20}, // no calls at ts(2)
+		}),
+	)
+
+	metrics := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"errors": {ts(0): 1, ts(1): 5, ts(2): 0, ts(3): 4},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"traces":  {"mock": traces},
+		"metrics": {"mock": metrics},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{Name: "error_pct", Source: &variable.Source{Type: "derived"}, Expression: "100 * error_ratio", Fill: &variable.FillPolicy{Strategy: variable.FillNone}},
+		{Name: "error_ratio", Source: &variable.Source{Type: "derived"}, Expression: "errors / calls", Fill: &variable.FillPolicy{Strategy: variable.FillNone}},
+		{Name: "errors", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		{
+			Name:       "calls",
+			Source:     &variable.Source{Type: "traces", Impl: "mock"},
+			TraceQuery: &variable.TraceQueryDetails{ServiceName: "checkout", Dimension: "calls"},
+		},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	nan := math.NaN()
+	assertSeries(t, []float64{1, 10, nan, 20}, ds.Columns[0].Values) // 0 errors / 0 calls is undefined
+	assertSeries(t, []float64{0.01, 0.1, nan, 0.2}, ds.Columns[1].Values)
+	assert.Equal(t, "derived", ds.Columns[1].Provenance.Type)
+	assert.Equal(t, "errors / calls", ds.Columns[1].Provenance.Query)
+}
+
+func TestOrchestrator_FetchSplit(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	flat := func(v float64) map[time.Time]float64 {
+		return map[time.Time]float64{start: v, end: v}
+	}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithSeries(map[string][]fetcher.Series{
+			"cpu": {
+				{Labels: map[string]string{"pod": "web-1", "ns": "prod"}, Points: flat(0.9)},
+				{Labels: map[string]string{"pod": "web-2", "ns": "prod"}, Points: flat(0.1)},
+				{Labels: map[string]string{"pod": "db/0", "ns": "prod"}, Points: flat(0.5)},
+				{Labels: map[string]string{"pod": "web-3", "ns": "prod"}, Points: flat(0.2)},
+			},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{
+			Name:         "cpu",
+			Source:       &variable.Source{Type: "metrics", Impl: "mock"},
+			MetricsQuery: "sum by (pod, ns) (rate(cpu[1m]))",
+			SplitBy:      []string{"pod"},
+			SeriesName:   "cpu_{{ pod }}",
+			MaxSeries:    2,
+			OtherSeries:  "sum",
+		},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"cpu_db_0", "cpu_web_1", "cpu_other"}, ds.Names())
+	assert.Equal(t, []float64{0.5, 0.5}, ds.Columns[0].Values)
+	assert.Equal(t, []float64{0.9, 0.9}, ds.Columns[1].Values)
+	assertSeries(t, []float64{0.3, 0.3}, ds.Columns[2].Values) // web-2 + web-3
+	assert.Equal(t, "pod=db/0", ds.Columns[0].Provenance.Series)
+	assert.Equal(t, "other: sum of 2 series", ds.Columns[2].Provenance.Series)
+	assert.Equal(t, "cpu", ds.Columns[2].Provenance.Variable)
+	assert.Equal(t, "sum by (pod, ns) (rate(cpu[1m]))", ds.Columns[2].Provenance.Query)
+}
+
+func TestOrchestrator_FetchSplitSameQuery(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	step := time.Minute
+
+	flat := func(v float64) map[time.Time]float64 {
+		return map[time.Time]float64{start: v, end: v}
+	}
+
+	labels := map[string]string{"pod": "web-1", "zone": "a"}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithSeries(map[string][]fetcher.Series{
+			"by_pod":  {{Labels: labels, Points: flat(1)}},
+			"by_zone": {{Labels: labels, Points: flat(2)}},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	query := "sum by (pod, zone) (rate(cpu[1m]))"
+	vars := []variable.VariableDefinition{
+		{Name: "by_pod", Source: src, MetricsQuery: query, SplitBy: []string{"pod"}},
+		{Name: "by_zone", Source: src, MetricsQuery: query, SplitBy: []string{"zone"}},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"by_pod_web_1", "by_zone_a"}, ds.Names())
+	assert.Equal(t, []string{"by_pod_web_1"}, ds.SeriesOf("by_pod"))
+	assert.Equal(t, []string{"by_zone_a"}, ds.SeriesOf("by_zone"))
+	assert.Empty(t, ds.SeriesOf("cpu"))
+}
+
+func TestOrchestrator_FetchSplitUnsupported(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"random": random.NewFetcher()},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{Name: "cpu", Source: &variable.Source{Type: "metrics", Impl: "random"}, SplitBy: []string{"pod"}},
+	}
+
+	// Act
+	_, err := svc.Fetch(context.Background(), vars, start, start.Add(time.Minute), time.Minute)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, "metrics implementation 'random' can't split variable 'cpu' by series", err.Error())
+}
+
+func TestOrchestrator_FetchMakeStationary(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(99 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	rng := rand.New(rand.NewSource(1))
+	noise := map[time.Time]float64{}
+	trend := map[time.Time]float64{}
+	for i := range 100 {
+		noise[ts(i)] = rng.NormFloat64()
+		trend[ts(i)] = 0.5*float64(i) + rng.NormFloat64()
+	}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"noise": noise,
+			"trend": trend,
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		fetchers,
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithMakeStationary(true),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "noise", Source: src},
+		{Name: "trend", Source: src},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	require.NotNil(t, report)
+
+	noiseResult := report.Variables[0].Stationarity
+	require.NotNil(t, noiseResult)
+	assert.Equal(t, orchestrator.VerdictStationary, noiseResult.Verdict)
+	assert.Empty(t, noiseResult.Remedy)
+	assert.Empty(t, ds.Columns[0].Provenance.Transforms)
+	assert.Equal(t, noise[ts(0)], ds.Columns[0].Values[0])
+
+	trendResult := report.Variables[1].Stationarity
+	require.NotNil(t, trendResult)
+	assert.Equal(t, orchestrator.VerdictTrend, trendResult.Verdict)
+	assert.Equal(t, orchestrator.RemedyDetrend, trendResult.Remedy)
+	assert.True(t, trendResult.Applied)
+	assert.Equal(t, []string{"detrend"}, ds.Columns[1].Provenance.Transforms)
+	assert.InDelta(t, 0, stats.Mean(ds.Columns[1].Values), 1e-9)
+}
+
+func TestOrchestrator_FetchDuplicates(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(5 * time.Minute)
+	step := time.Minute
+
+	// a 1m export with two extra rows, e.g. from a scrape that was retried
+	path := filepath.Join(t.TempDir(), "export.csv")
+	require.NoError(t, os.WriteFile(path, []byte(`timestamp,latency
+2023-10-01T10:00:00Z,1
+2023-10-01T10:01:00Z,2
+2023-10-01T10:01:10Z,2
+2023-10-01T10:02:00Z,3
+2023-10-01T10:03:00Z,4
+2023-10-01T10:03:20Z,4
+2023-10-01T10:04:00Z,5
+2023-10-01T10:05:00Z,6
+`), 0o600))
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		map[string]map[string]fetcher.Fetcher{"metrics": {"csv": csvfetcher.NewFetcher(fetcher.WithLocation(path))}},
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithQualityRules(variable.QualityRules{MaxDuplicateRatio: 0.1}),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	vars := []variable.VariableDefinition{
+		{Name: "latency", Source: &variable.Source{Type: "metrics", Impl: "csv", Loc: path}},
+	}
+
+	// Act
+	_, err := svc.Fetch(context.Background(), vars, start, end, step)
+
+	// Assert
+	require.NotNil(t, report)
+	assert.Equal(t, "1m0s", report.Variables[0].Resolution)
+	assert.Equal(t, 8, report.Variables[0].Points)
+	assert.Equal(t, 2, report.Variables[0].Duplicates)
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"latency: 33.3% of steps have duplicate points (max 10.0%)"}, report.Violations)
+}
+
+func TestOrchestrator_FetchStationarityAcrossGap(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(99 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	// noise, an outage, then a short stretch at another level
+	rng := rand.New(rand.NewSource(1))
+	points := map[time.Time]float64{}
+	for i := range 100 {
+		switch {
+		case i < 70:
+			points[ts(i)] = rng.NormFloat64()
+		case i >= 80:
+			points[ts(i)] = 10 + rng.NormFloat64()
+		}
+	}
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		map[string]map[string]fetcher.Fetcher{"metrics": {"mock": mockfetcher.NewFetcher(mockfetcher.WithData(map[string]map[time.Time]float64{"noise": points}))}},
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	vars := []variable.VariableDefinition{
+		{Name: "noise", Source: &variable.Source{Type: "metrics", Impl: "mock"}, Fill: &variable.FillPolicy{Strategy: variable.FillNone}},
+	}
+
+	// Act
+	_, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	require.NotNil(t, report)
+	assert.Equal(t, orchestrator.VerdictStationary, report.Variables[0].Stationarity.Verdict)
+}
+
+func TestOrchestrator_FetchExclusions(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(9 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	points := map[time.Time]float64{}
+	for i := range 10 {
+		points[ts(i)] = float64(i * i)
+	}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"level":  points,
+			"change": points,
+			"smooth": points,
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	mAnnotator := mockannotator.NewAnnotator(
+		mockannotator.WithAnnotations([]annotator.Annotation{
+			{Start: ts(7).Add(30 * time.Second), End: ts(7).Add(30 * time.Second), Text: "deploy checkout\nv1.2.3"},
+		}),
+	)
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		fetchers,
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithExclusions(
+			variable.Exclusion{Start: ts(3), End: ts(4), Reason: "load test"},
+			variable.Exclusion{Source: &variable.Source{Type: "annotations", Impl: "mock", Loc: "mock"}, Tags: []string{"deploy"}},
+		),
+		orchestrator.WithAnnotator("mock", mAnnotator),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "level", Source: src},
+		{Name: "change", Source: src, Transforms: []variable.Transform{{Type: variable.TransformDiff}}},
+		{Name: "smooth", Source: src, Transforms: []variable.Transform{{Type: variable.TransformRollingMean, Window: 2}}},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	nan := math.NaN()
+	assertSeries(t, []float64{0, 1, 4, nan, nan, 25, 36, nan, 64, 81}, ds.Columns[0].Values)
+	assertSeries(t, []float64{nan, 1, 3, nan, nan, nan, 11, nan, nan, 17}, ds.Columns[1].Values)         // no diff across a masked step
+	assertSeries(t, []float64{nan, 0.5, 2.5, nan, nan, nan, 30.5, nan, nan, 72.5}, ds.Columns[2].Values) // nor a rolling window
+	assert.Equal(t, []int{3, 5, 7, 8}, ds.Breaks)
+
+	require.NotNil(t, report)
+	require.Len(t, report.Excluded, 2)
+	assert.Equal(t, "load test", report.Excluded[0].Reason)
+	assert.Equal(t, 2, report.Excluded[0].Steps)
+	assert.Equal(t, "deploy checkout", report.Excluded[1].Reason)
+	assert.Equal(t, 1, report.Excluded[1].Steps)
+	assert.Equal(t, 7, report.Steps) // the report describes what is left to analyze
+	assert.Equal(t, 7, report.Variables[0].Observed)
+}
+
+func TestOrchestrator_FetchQualityExcluded(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(5 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	// the only variance and the only gap are in the excluded step
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"flat":   {ts(0): 5, ts(1): 5, ts(2): 5, ts(3): 100, ts(4): 5, ts(5): 5},
+			"gappy":  {ts(0): 1, ts(1): 2, ts(2): 1, ts(4): 2, ts(5): 1},
+			"spiked": {ts(0): 1, ts(1): 2, ts(2): 1, ts(3): 100, ts(4): 2, ts(5): 1},
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		fetchers,
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithExclusions(variable.Exclusion{Start: ts(3), End: ts(3)}),
+		orchestrator.WithQualityRules(variable.QualityRules{FailOnConstant: true, MaxFilledRatio: 0.1, MaxOutlierRatio: 0.1}),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "flat", Source: src},
+		{Name: "gappy", Source: src},
+		{Name: "spiked", Source: src},
+	}
+
+	// Act
+	_, err := svc.Fetch(context.Background(), vars, start, end, step)
+
+	// Assert
+	require.NotNil(t, report)
+	assert.Equal(t, 5, report.Steps)
+
+	flat := report.Variables[0]
+	assert.Equal(t, 5, flat.Observed)
+	assert.True(t, flat.Constant)
+
+	gappy := report.Variables[1]
+	assert.Equal(t, 5, gappy.Observed)
+	assert.Equal(t, 0, gappy.Filled)
+
+	spiked := report.Variables[2]
+	assert.Equal(t, 0, spiked.Outliers)
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"flat: constant (zero variance)"}, report.Violations)
+}
+
+func TestOrchestrator_EstimateAcrossExclusion(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(19 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	// y follows x three steps later within a run, but not across the one
+	// step excluded at 10, which is shorter than the lag
+	x := map[time.Time]float64{}
+	y := map[time.Time]float64{}
+	for i := range 20 {
+		x[ts(i)] = float64(i*i%7 + i)
+	}
+	for i := 3; i < 20; i++ {
+		y[ts(i)] = 2 * x[ts(i-3)]
+	}
+	for i := 11; i <= 13; i++ {
+		y[ts(i)] = 100
+	}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{"x": x, "y": y}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(
+		fetchers,
+		noopdisc.NewDiscoverer(),
+		nativeest.NewEstimator(),
+		orchestrator.WithExclusions(variable.Exclusion{Start: ts(10), End: ts(10)}),
+	)
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "x", Source: src},
+		{Name: "y", Source: src},
+	}
+
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "x"}, {Id: 1, Label: "y"}},
+		Edges: []*causal.Edge{{Source: "x", Target: "y", Type: "directed", Lag: 3}},
+	}
+
+	// Act
+	rsp, err := svc.Estimate(context.Background(), vars, start, end, step, orchestrator.EstimateArgs{Graph: graph})
+	require.NoError(t, err)
+
+	// Assert
+	model := rsp.Models["y"]
+	require.NotNil(t, model)
+	assert.Equal(t, int32(13), model.NObs) // rows 3-19 without the masked 10 and the 11-13 reaching back across it
+	assert.InDelta(t, 2, model.Coefficients[0], 1e-4)
+	assert.InDelta(t, 1, model.RSquared, 1e-4)
+}
+
+func TestOrchestrator_FetchExclusionsUnknownAnnotator(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	svc := orchestrator.New(
+		map[string]map[string]fetcher.Fetcher{"metrics": {"mock": mockfetcher.NewFetcher()}},
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithExclusions(variable.Exclusion{Source: &variable.Source{Type: "annotations", Impl: "grafana", Loc: "http://grafana:3000"}}),
+	)
+
+	vars := []variable.VariableDefinition{
+		{Name: "level", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	// Act
+	_, err := svc.Fetch(context.Background(), vars, start, start.Add(time.Hour), time.Minute)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, "no annotator for annotations implementation 'grafana' at 'http://grafana:3000'", err.Error())
+}
+
+func TestOrchestrator_FetchExclusionsFromTwoFiles(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(9 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	points := map[time.Time]float64{}
+	for i := range 10 {
+		points[ts(i)] = float64(i)
+	}
+
+	dir := t.TempDir()
+	deploys := filepath.Join(dir, "deploys.yml")
+	loadTests := filepath.Join(dir, "loadtests.yml")
+	require.NoError(t, os.WriteFile(deploys, []byte("- {start: 2023-10-01T10:02:00Z, text: deploy}\n"), 0o600))
+	require.NoError(t, os.WriteFile(loadTests, []byte("- {start: 2023-10-01T10:06:00Z, end: 2023-10-01T10:07:00Z, text: load test}\n"), 0o600))
+
+	sources := map[string]*variable.Source{
+		deploys:   {Type: "annotations", Impl: "file", Loc: deploys},
+		loadTests: {Type: "annotations", Impl: "file", Loc: loadTests},
+	}
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		map[string]map[string]fetcher.Fetcher{"metrics": {"mock": mockfetcher.NewFetcher(mockfetcher.WithData(map[string]map[time.Time]float64{"level": points}))}},
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithExclusions(
+			variable.Exclusion{Source: sources[deploys]},
+			variable.Exclusion{Source: sources[loadTests]},
+		),
+		orchestrator.WithAnnotator(orchestrator.SourceKey("file", deploys), file.NewAnnotator(annotator.WithLocation(deploys))),
+		orchestrator.WithAnnotator(orchestrator.SourceKey("file", loadTests), file.NewAnnotator(annotator.WithLocation(loadTests))),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	vars := []variable.VariableDefinition{
+		{Name: "level", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	nan := math.NaN()
+	assertSeries(t, []float64{0, 1, nan, 3, 4, 5, nan, nan, 8, 9}, ds.Columns[0].Values)
+
+	require.NotNil(t, report)
+	require.Len(t, report.Excluded, 2)
+	assert.Equal(t, "deploy", report.Excluded[0].Reason)
+	assert.Equal(t, "load test", report.Excluded[1].Reason)
+}
+
+func TestOrchestrator_FetchEvents(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	counts := map[time.Time]float64{ts(1): 3, ts(2): 0} // steps 0 and 3 have no data
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"deploys":     counts,
+			"deploy_rate": counts,
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"events": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	src := &variable.Source{Type: "events", Impl: "mock"}
+	vars := []variable.VariableDefinition{
+		{Name: "deploys", Source: src, EventQuery: &variable.EventQueryDetails{Tags: []string{"deploy"}}},
+		{Name: "deploy_rate", Source: src, EventQuery: &variable.EventQueryDetails{Indicator: variable.IndicatorCount}},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []float64{0, 1, 0, 0}, ds.Columns[0].Values)
+	assert.Equal(t, []float64{0, 3, 0, 0}, ds.Columns[1].Values)
+	assert.Equal(t, "binary(tags=deploy)", ds.Columns[0].Provenance.Query)
+	assert.Equal(t, "zero", ds.Columns[0].Provenance.Fill)
+	assert.Equal(t, "count", ds.Columns[1].Provenance.Query)
+}
+
+func TestOrchestrator_FetchEventsFromTwoFiles(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Minute)
+	step := time.Minute
+
+	dir := t.TempDir()
+	deploys := filepath.Join(dir, "deploys.yml")
+	incidents := filepath.Join(dir, "incidents.yml")
+	require.NoError(t, os.WriteFile(deploys, []byte("- {start: 2023-10-01T10:01:00Z, text: deploy}\n"), 0o600))
+	require.NoError(t, os.WriteFile(incidents, []byte("- {start: 2023-10-01T10:03:00Z, text: incident}\n"), 0o600))
+
+	eventsFetcher := func(loc string) fetcher.Fetcher {
+		return events.NewFetcher(fetcher.WithLocation(loc), events.WithAnnotator(file.NewAnnotator(annotator.WithLocation(loc))))
+	}
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"events": {
+			orchestrator.SourceKey("file", deploys):   eventsFetcher(deploys),
+			orchestrator.SourceKey("file", incidents): eventsFetcher(incidents),
+		},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), noopest.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{Name: "deploys", Source: &variable.Source{Type: "events", Impl: "file", Loc: deploys}, EventQuery: &variable.EventQueryDetails{}},
+		{Name: "incidents", Source: &variable.Source{Type: "events", Impl: "file", Loc: incidents}, EventQuery: &variable.EventQueryDetails{}},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []float64{0, 1, 0, 0}, ds.Columns[0].Values)
+	assert.Equal(t, []float64{0, 0, 0, 1}, ds.Columns[1].Values)
+}
+
+func TestOrchestrator_FetchResample(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(9 * time.Minute)
+	step := time.Minute
+
+	ts := func(i int) time.Time { return start.Add(time.Duration(i) * step) }
+
+	// 15s points, 1 to 40
+	fine := map[time.Time]float64{}
+	for i := range 40 {
+		fine[start.Add(time.Duration(i)*15*time.Second)] = float64(i + 1)
+	}
+
+	// 5m points, two of them after the window
+	fiveMinute := map[time.Time]float64{ts(0): 10, ts(5): 20, ts(10): 30, ts(15): 40}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(map[string]map[time.Time]float64{
+			"fine_mean":   fine,
+			"fine_last":   fine,
+			"hold":        fiveMinute,
+			"linear":      fiveMinute,
+			"calls":       fiveMinute,
+			"coarse_none": fiveMinute,
+		}),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+		"traces":  {"mock": mFetcher},
+	}
+
+	var report *orchestrator.QualityReport
+
+	svc := orchestrator.New(
+		fetchers,
+		noopdisc.NewDiscoverer(),
+		noopest.NewEstimator(),
+		orchestrator.WithQualityReport(func(r *orchestrator.QualityReport) { report = r }),
+	)
+
+	src := &variable.Source{Type: "metrics", Impl: "mock"}
+	none := &variable.FillPolicy{Strategy: variable.FillNone}
+	vars := []variable.VariableDefinition{
+		{Name: "fine_mean", Source: src},
+		{Name: "fine_last", Source: src, Resample: &variable.ResamplePolicy{Aggregation: variable.ResampleLast, Label: variable.LabelEnd}},
+		{Name: "hold", Source: src, Fill: none},
+		{Name: "linear", Source: src, Fill: none, Resample: &variable.ResamplePolicy{Upsample: variable.UpsampleLinear}},
+		{
+			Name:       "calls",
+			Source:     &variable.Source{Type: "traces", Impl: "mock"},
+			TraceQuery: &variable.TraceQueryDetails{ServiceName: "checkout", Dimension: "calls"},
+		},
+		{Name: "coarse_none", Source: src, Fill: none, Resample: &variable.ResamplePolicy{Upsample: variable.UpsampleNone}},
+	}
+
+	// Act
+	ds, err := svc.Fetch(context.Background(), vars, start, end, step)
+	require.NoError(t, err)
+
+	// Assert
+	nan := math.NaN()
+	assertSeries(t, []float64{2.5, 6.5, 10.5, 14.5, 18.5, 22.5, 26.5, 30.5, 34.5, 38.5}, ds.Columns[0].Values)
+	assertSeries(t, []float64{5, 9, 13, 17, 21, 25, 29, 33, 37, 40}, ds.Columns[1].Values) // the first point ends the step before the window
+	assertSeries(t, []float64{10, 10, 10, 10, 10, 20, 20, 20, 20, 20}, ds.Columns[2].Values)
+	assertSeries(t, []float64{10, 12, 14, 16, 18, 20, 22, 24, 26, 28}, ds.Columns[3].Values)
+	assertSeries(t, []float64{2, 2, 2, 2, 2, 4, 4, 4, 4, 4}, ds.Columns[4].Values) // calls are spread, not repeated
+	assertSeries(t, []float64{10, nan, nan, nan, nan, 20, nan, nan, nan, nan}, ds.Columns[5].Values)
+
+	require.NotNil(t, report)
+	assert.Equal(t, "15s", report.Variables[0].Resolution)
+	assert.Equal(t, 0, report.Variables[0].Duplicates)
+	assert.Equal(t, "5m0s", report.Variables[2].Resolution)
+	assert.Equal(t, 8, report.Variables[2].Upsampled)
+	assert.Equal(t, 2, report.Variables[2].Unused)
+	assert.Equal(t, "mean(label=start, upsample=hold)", ds.Columns[0].Provenance.Resample)
+	assert.Equal(t, "sum(label=start, upsample=hold)", ds.Columns[4].Provenance.Resample)
+}
@@ -0,0 +1,295 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	"github.com/w-h-a/caus/internal/render"
+)
+
+func renderFixture() (*causal.CausalGraph, *causal.EstimateResponse) {
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{
+			{Id: 0, Label: "requests", Transforms: []string{"rate"}},
+			{Id: 1, Label: "cpu"},
+			{Id: 2, Label: "latency"},
+		},
+		Edges: []*causal.Edge{
+			{Source: "requests", Target: "cpu", Type: "directed", Lag: 0, Statistic: 6.1, PValue: 0.0001, PartialCorr: 0.5},
+			{Source: "cpu", Target: "latency", Type: "directed", Lag: 2, Statistic: -3.2, PValue: 0.002, PartialCorr: -0.25},
+			{Source: "requests", Target: "latency", Type: "undirected", Lag: 1, Statistic: 1.9, PValue: 0.04, PartialCorr: 0.1},
+			{Source: "cpu", Target: "requests", Type: "conflicting", Lag: 0, Statistic: 2.5, PValue: 0.01, PartialCorr: 0.2},
+		},
+		Window: &causal.Window{Start: "2024-03-12T14:00:00Z", End: "2024-03-12T16:00:00Z", Step: "1m0s"},
+	}
+
+	estimate := &causal.EstimateResponse{
+		Models: map[string]*causal.ModelInfo{
+			"latency": {
+				Features:     []string{"cpu_lag2"},
+				Coefficients: []float32{1.5},
+				CiLower:      []float32{1.25},
+				CiUpper:      []float32{1.75},
+				RSquared:     0.8,
+			},
+		},
+	}
+
+	return graph, estimate
+}
+
+func TestRender_DOT(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	graph, estimate := renderFixture()
+	var buf bytes.Buffer
+
+	// Act
+	err := render.Write(&buf, graph, estimate, render.FormatDOT)
+	require.NoError(t, err)
+
+	// Assert
+	expected := `digraph caus {
+  rankdir=LR;
+  label="Window: 2024-03-12T14:00:00Z -> 2024-03-12T16:00:00Z (Step: 1m0s)";
+  labelloc=t;
+  node [shape=box, style=rounded];
+  edge [fontsize=10];
+  n0 [label="requests\n= rate(requests)"];
+  n1 [label="cpu"];
+  n2 [label="latency\nR² 0.800"];
+  n0 -> n1 [label="lag 0\nstrength 0.500", penwidth=3.0];
+  n1 -> n2 [label="lag 2 = 2m0s\nstrength -0.250\ncoef 1.5000 [1.2500, 1.7500]", penwidth=2.0];
+  n0 -> n2 [label="lag 1 = 1m0s\nstrength 0.100", penwidth=1.4, dir=both, arrowhead=odot, arrowtail=odot, style=dashed];
+  n1 -> n0 [label="lag 0\nstrength 0.200", penwidth=1.8, dir=none, style=dashed, color=red];
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRender_Mermaid(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	graph, estimate := renderFixture()
+	graph.Nodes[1].Label = `cpu "total"`
+	graph.Edges[0].Target = `cpu "total"`
+	graph.Edges[1].Source = `cpu "total"`
+	graph.Edges[3].Source = `cpu "total"`
+	estimate.Models["latency"].Features[0] = `cpu "total"_lag2`
+	var buf bytes.Buffer
+
+	// Act
+	err := render.Write(&buf, graph, estimate, render.FormatMermaid)
+	require.NoError(t, err)
+
+	// Assert
+	expected := `---
+title: "Window: 2024-03-12T14:00:00Z -> 2024-03-12T16:00:00Z (Step: 1m0s)"
+---
+flowchart LR
+  n0["requests<br/>= rate(requests)"]
+  n1["cpu #quot;total#quot;"]
+  n2["latency<br/>R² 0.800"]
+  n0 -->|"lag 0<br/>strength 0.500"| n1
+  n1 -->|"lag 2 = 2m0s<br/>strength -0.250<br/>coef 1.5000 [1.2500, 1.7500]"| n2
+  n0 o--o|"lag 1 = 1m0s<br/>strength 0.100"| n2
+  n1 x--x|"lag 0<br/>strength 0.200"| n0
+  linkStyle 0 stroke-width:3.0px
+  linkStyle 1 stroke-width:2.0px
+  linkStyle 2 stroke-width:1.4px,stroke-dasharray:4
+  linkStyle 3 stroke-width:1.8px,stroke-dasharray:4,stroke:red
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRender_GraphML(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	graph, estimate := renderFixture()
+	var buf bytes.Buffer
+
+	type data struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	}
+	var doc struct {
+		Keys []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"key"`
+		Graph struct {
+			EdgeDefault string `xml:"edgedefault,attr"`
+			Data        []data `xml:"data"`
+			Nodes       []struct {
+				ID   string `xml:"id,attr"`
+				Data []data `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source   string `xml:"source,attr"`
+				Target   string `xml:"target,attr"`
+				Directed string `xml:"directed,attr"`
+				Data     []data `xml:"data"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	values := func(ds []data) map[string]string {
+		m := map[string]string{}
+		for _, d := range ds {
+			m[d.Key] = d.Value
+		}
+		return m
+	}
+
+	// Act
+	err := render.Write(&buf, graph, estimate, render.FormatGraphML)
+	require.NoError(t, err)
+
+	err = xml.Unmarshal(buf.Bytes(), &doc)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "directed", doc.Graph.EdgeDefault)
+	assert.Equal(t, "Window: 2024-03-12T14:00:00Z -> 2024-03-12T16:00:00Z (Step: 1m0s)", values(doc.Graph.Data)["title"])
+
+	require.Len(t, doc.Graph.Nodes, 3)
+	assert.Equal(t, map[string]string{"label": "requests", "transforms": "rate(requests)"}, values(doc.Graph.Nodes[0].Data))
+	assert.Equal(t, map[string]string{"label": "latency", "r_squared": "0.8"}, values(doc.Graph.Nodes[2].Data))
+
+	require.Len(t, doc.Graph.Edges, 4)
+
+	fitted := doc.Graph.Edges[1]
+	assert.Equal(t, "n1", fitted.Source)
+	assert.Equal(t, "n2", fitted.Target)
+	assert.Empty(t, fitted.Directed)
+	assert.Equal(t, map[string]string{
+		"edge_label":  "lag 2 = 2m0s\nstrength -0.250\ncoef 1.5000 [1.2500, 1.7500]",
+		"type":        "directed",
+		"lag":         "2",
+		"lag_time":    "2m0s",
+		"strength":    "-0.25",
+		"statistic":   "-3.2",
+		"p_value":     "0.002",
+		"coefficient": "1.5",
+		"ci_lower":    "1.25",
+		"ci_upper":    "1.75",
+	}, values(fitted.Data))
+
+	unoriented := doc.Graph.Edges[2]
+	assert.Equal(t, "false", unoriented.Directed)
+	assert.Equal(t, "undirected", values(unoriented.Data)["type"])
+	assert.NotContains(t, values(unoriented.Data), "coefficient")
+
+	declared := map[string]bool{}
+	for _, k := range doc.Keys {
+		declared[k.ID] = true
+	}
+	for _, n := range doc.Graph.Nodes {
+		for _, d := range n.Data {
+			assert.True(t, declared[d.Key], d.Key)
+		}
+	}
+	for _, e := range doc.Graph.Edges {
+		for _, d := range e.Data {
+			assert.True(t, declared[d.Key], d.Key)
+		}
+	}
+}
+
+func TestRender_WithoutWindow(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	graph := &causal.CausalGraph{
+		Edges: []*causal.Edge{{Source: "cpu", Target: "latency", Lag: 3, PartialCorr: 1}},
+	}
+	var buf bytes.Buffer
+
+	// Act
+	err := render.Write(&buf, graph, nil, render.FormatDOT)
+	require.NoError(t, err)
+
+	// Assert
+	expected := `digraph caus {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  edge [fontsize=10];
+  n0 [label="cpu"];
+  n1 [label="latency"];
+  n0 -> n1 [label="lag 3\nstrength 1.000", penwidth=5.0];
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRender_ParseFormat(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	valid := map[string]render.Format{
+		"dot":     render.FormatDOT,
+		"Mermaid": render.FormatMermaid,
+		"graphml": render.FormatGraphML,
+	}
+
+	invalid := map[string]string{
+		"unsupported graph format 'png' (supported: [dot mermaid graphml])": "png",
+		"unsupported graph format '' (supported: [dot mermaid graphml])":    "",
+	}
+
+	paths := map[string]render.Format{
+		"graph.gv":      render.FormatDOT,
+		"graph.mmd":     render.FormatMermaid,
+		"graph.MERMAID": render.FormatMermaid,
+		"graph.graphml": render.FormatGraphML,
+		"graph":         render.FormatDOT,
+		"-":             render.FormatDOT,
+	}
+
+	for input, expected := range valid {
+		// Act
+		got, err := render.ParseFormat(input)
+
+		// Assert
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, got)
+	}
+
+	for expected, input := range invalid {
+		// Act
+		_, err := render.ParseFormat(input)
+
+		// Assert
+		require.Error(t, err, input)
+		assert.Equal(t, expected, err.Error())
+	}
+
+	for path, expected := range paths {
+		// Act
+		got := render.FormatFromPath(path)
+
+		// Assert
+		assert.Equal(t, expected, got, path)
+	}
+}
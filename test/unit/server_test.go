@@ -0,0 +1,368 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	caus "github.com/w-h-a/caus/api/caus/v1alpha1"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	mockdiscoverer "github.com/w-h-a/caus/internal/client/discoverer/mock"
+	noopest "github.com/w-h-a/caus/internal/client/estimator/noop"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+	mockfetcher "github.com/w-h-a/caus/internal/client/fetcher/mock"
+	"github.com/w-h-a/caus/internal/server"
+	"github.com/w-h-a/caus/internal/service/jobs"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const serverVars = `
+variables:
+  - name: cpu
+    source: {type: metrics, impl: prometheus, loc: "http://prom:9090/"}
+    metrics_query: sum(rate(cpu[1m]))
+  - name: latency
+    source: {type: metrics, impl: prometheus, loc: "http://prom:9090/"}
+    metrics_query: histogram_quantile(0.99, rate(latency_bucket[1m]))
+`
+
+// serverFixture serves prometheus at http://prom:9090 from a mock fetcher
+// and records the config of every run.
+func serverFixture(opts ...server.Option) (*server.Server, *[]*variable.DiscoveryConfig) {
+	t0 := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	data := map[string]map[time.Time]float64{"cpu": {}, "latency": {}}
+	for i := 0; i < 10; i++ {
+		ts := t0.Add(time.Duration(i) * time.Minute)
+		data["cpu"][ts] = float64(i)
+		data["latency"][ts] = float64(i * i)
+	}
+
+	var runs []*variable.DiscoveryConfig
+
+	factory := func(cfg *variable.DiscoveryConfig, opts ...orchestrator.Option) (*orchestrator.Service, error) {
+		runs = append(runs, cfg)
+		fetchers := map[string]map[string]fetcher.Fetcher{
+			"metrics": {"prometheus": mockfetcher.NewFetcher(mockfetcher.WithData(data))},
+		}
+		return orchestrator.New(fetchers, mockdiscoverer.NewDiscoverer(), noopest.NewEstimator(), opts...), nil
+	}
+
+	opts = append([]server.Option{
+		server.WithSources(variable.Source{Type: "metrics", Impl: "prometheus", Loc: "http://prom:9090", ApiKey: "secret"}),
+	}, opts...)
+
+	return server.New(factory, opts...), &runs
+}
+
+func discoverBody(vars string, mutate func(run map[string]any)) string {
+	run := map[string]any{
+		"vars":  vars,
+		"start": "2024-03-12T14:00:00Z",
+		"end":   "2024-03-12T14:09:00Z",
+		"step":  "1m",
+	}
+	if mutate != nil {
+		mutate(run)
+	}
+	bs, _ := json.Marshal(map[string]any{"run": run, "maxLag": 2})
+	return string(bs)
+}
+
+func TestServer_Discover(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	srv, runs := serverFixture()
+	gateway := httptest.NewServer(srv.Handler())
+	defer gateway.Close()
+
+	// Act
+	rsp, err := http.Post(gateway.URL+"/v1alpha1/discover", "application/json", strings.NewReader(discoverBody(serverVars, nil)))
+	require.NoError(t, err)
+	defer rsp.Body.Close()
+
+	var graph struct {
+		Nodes []struct {
+			Label string `json:"label"`
+		} `json:"nodes"`
+	}
+	require.NoError(t, json.NewDecoder(rsp.Body).Decode(&graph))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	require.Len(t, graph.Nodes, 1)
+	assert.Equal(t, "test", graph.Nodes[0].Label)
+
+	require.Len(t, *runs, 1)
+	for _, v := range (*runs)[0].Variables {
+		assert.Equal(t, "secret", v.Source.ApiKey, v.Name)
+	}
+}
+
+func TestServer_DiscoverErrors(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	srv, runs := serverFixture(server.WithToken("t0ken"))
+	gateway := httptest.NewServer(srv.Handler())
+	defer gateway.Close()
+
+	type call struct {
+		token  string
+		body   string
+		status int
+	}
+
+	cases := map[string]call{
+		"missing or invalid bearer token": {
+			token: "wrong", body: discoverBody(serverVars, nil), status: http.StatusUnauthorized,
+		},
+		"source metrics/prometheus at 'http://other:9090' is not served": {
+			token: "t0ken", body: discoverBody(strings.ReplaceAll(serverVars, "prom:9090/", "other:9090"), nil), status: http.StatusForbidden,
+		},
+		"source metrics/prometheus at 'http://prom:9090/' carries credentials, which only the server sets": {
+			token: "t0ken", body: discoverBody(strings.Replace(serverVars, `loc: "http://prom:9090/"}`, `loc: "http://prom:9090/", api_key: mine}`, 1), nil), status: http.StatusBadRequest,
+		},
+		"invalid step '0s': must be a positive duration": {
+			token: "t0ken", body: discoverBody(serverVars, func(run map[string]any) { run["step"] = "0s" }), status: http.StatusBadRequest,
+		},
+		"window start 2024-03-12T14:09:00Z is not before its end 2024-03-12T14:00:00Z": {
+			token: "t0ken", body: discoverBody(serverVars, func(run map[string]any) { run["start"], run["end"] = run["end"], run["start"] }), status: http.StatusBadRequest,
+		},
+		"run.vars is required": {
+			token: "t0ken", body: discoverBody("", nil), status: http.StatusBadRequest,
+		},
+	}
+
+	for expected, c := range cases {
+		// Act
+		req, err := http.NewRequest(http.MethodPost, gateway.URL+"/v1alpha1/discover", strings.NewReader(c.body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+c.token)
+
+		rsp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		var body struct {
+			Message string `json:"message"`
+		}
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&body))
+		rsp.Body.Close()
+
+		// Assert
+		assert.Equal(t, c.status, rsp.StatusCode, expected)
+		assert.Equal(t, expected, body.Message)
+	}
+
+	assert.Empty(t, *runs)
+}
+
+func TestServer_GRPC(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	srv, _ := serverFixture(server.WithToken("t0ken"))
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(srv.UnaryInterceptor()))
+	caus.RegisterCausServer(s, srv)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := caus.NewCausClient(conn)
+
+	req := &caus.DiscoverRequest{
+		Run: &caus.Run{
+			Vars:  serverVars,
+			Start: "2024-03-12T14:00:00Z",
+			End:   "2024-03-12T14:09:00Z",
+		},
+		MaxLag: 2,
+	}
+
+	// Act
+	_, unauthenticated := client.Discover(context.Background(), req)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer t0ken")
+	graph, err := client.Discover(ctx, req)
+	require.NoError(t, err)
+
+	_, noGraph := client.Estimate(ctx, &caus.EstimateRequest{Run: req.Run})
+
+	// Assert
+	assert.Equal(t, codes.Unauthenticated, status.Code(unauthenticated))
+	require.Len(t, graph.Nodes, 1)
+	assert.Equal(t, "test", graph.Nodes[0].Label)
+	assert.Equal(t, codes.InvalidArgument, status.Code(noGraph))
+}
+
+func TestServer_Jobs(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	store := openJobStore(t, filepath.Join(t.TempDir(), "jobs.db"))
+	defer store.Close()
+
+	svc, err := jobs.New(store)
+	require.NoError(t, err)
+
+	srv, _ := serverFixture(server.WithJobs(svc))
+	gateway := httptest.NewServer(srv.Handler())
+	defer gateway.Close()
+
+	disabled, _ := serverFixture()
+	disabledGateway := httptest.NewServer(disabled.Handler())
+	defer disabledGateway.Close()
+
+	call := func(method string, url string, body string) (int, []byte) {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		require.NoError(t, err)
+		rsp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer rsp.Body.Close()
+		bs, err := io.ReadAll(rsp.Body)
+		require.NoError(t, err)
+		return rsp.StatusCode, bs
+	}
+
+	// Act
+	code, bs := call(http.MethodPost, gateway.URL+"/v1alpha1/jobs/discover", discoverBody(serverVars, nil))
+	require.Equal(t, http.StatusOK, code, string(bs))
+
+	submitted := &caus.Job{}
+	require.NoError(t, protojson.Unmarshal(bs, submitted))
+
+	logCode, bs := call(http.MethodGet, gateway.URL+"/v1alpha1/jobs/"+submitted.Id+"/logs?follow=true", "")
+
+	var messages []string
+	for _, line := range strings.Split(strings.TrimSpace(string(bs)), "\n") {
+		entry := &caus.LogEntry{}
+		require.NoError(t, protojson.Unmarshal([]byte(line), entry))
+		messages = append(messages, entry.Message)
+	}
+
+	code, bs = call(http.MethodGet, gateway.URL+"/v1alpha1/jobs/"+submitted.Id, "")
+	require.Equal(t, http.StatusOK, code, string(bs))
+
+	job := &caus.Job{}
+	require.NoError(t, protojson.Unmarshal(bs, job))
+
+	cancelCode, _ := call(http.MethodPost, gateway.URL+"/v1alpha1/jobs/"+submitted.Id+"/cancel", "")
+	missingCode, _ := call(http.MethodGet, gateway.URL+"/v1alpha1/jobs/nope", "")
+	invalidCode, _ := call(http.MethodPost, gateway.URL+"/v1alpha1/jobs/discover", discoverBody(strings.ReplaceAll(serverVars, "prom:9090/", "other:9090"), nil))
+	disabledCode, _ := call(http.MethodPost, disabledGateway.URL+"/v1alpha1/jobs/discover", discoverBody(serverVars, nil))
+
+	// Assert
+	assert.Equal(t, jobs.KindDiscover, submitted.Kind)
+	assert.Equal(t, jobs.StateQueued, submitted.State)
+
+	assert.Equal(t, http.StatusOK, logCode)
+	assert.Contains(t, messages, "ORCHESTRATOR: Fetching 'cpu' from http://prom:9090/...")
+	assert.Equal(t, "Finished as succeeded", messages[len(messages)-1])
+
+	assert.Equal(t, jobs.StateSucceeded, job.State)
+	require.Len(t, job.GetGraph().GetNodes(), 1)
+	assert.Equal(t, "test", job.GetGraph().Nodes[0].Label)
+	assert.True(t, proto.Equal(&caus.JobProgress{Stage: orchestrator.StageDiscover, Done: 1, Total: 1}, job.Progress))
+
+	assert.Equal(t, http.StatusConflict, cancelCode)
+	assert.Equal(t, http.StatusNotFound, missingCode)
+	assert.Equal(t, http.StatusForbidden, invalidCode)
+	assert.Equal(t, http.StatusNotImplemented, disabledCode)
+}
+
+func TestServer_Exposure(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	loopback := []string{"127.0.0.1:8080", "localhost:8080", "[::1]:8080", "127.0.0.2:8081"}
+	exposed := []string{":8080", "0.0.0.0:8080", "[::]:8080", "10.0.0.5:8080", "caus.internal:8080"}
+
+	for _, addr := range loopback {
+		// Act
+		err := server.CheckExposure(addr, "")
+
+		// Assert
+		assert.True(t, server.Loopback(addr), addr)
+		assert.NoError(t, err, addr)
+	}
+
+	for _, addr := range exposed {
+		// Act
+		withoutToken := server.CheckExposure(addr, "")
+		withToken := server.CheckExposure(addr, "s3cret")
+
+		// Assert
+		assert.False(t, server.Loopback(addr), addr)
+		require.Error(t, withoutToken, addr)
+		assert.Equal(t, "refusing to serve on '"+addr+"' without a token: anyone who can reach it could query the sources with their credentials", withoutToken.Error())
+		assert.NoError(t, withToken, addr)
+	}
+}
+
+func TestServer_TLSConfig(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	invalid := map[string][2]string{
+		"server cert and key must be set together":                                {"server.crt", ""},
+		"failed to load server cert: open missing.crt: no such file or directory": {"missing.crt", "missing.key"},
+	}
+
+	// Act
+	none, err := server.TLSConfig("", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	for expected, files := range invalid {
+		// Act
+		_, err := server.TLSConfig(files[0], files[1])
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, expected, err.Error())
+	}
+}
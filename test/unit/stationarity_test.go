@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/w-h-a/caus/internal/stats"
+)
+
+func TestStationarity_Tests(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	rng := rand.New(rand.NewSource(1))
+	n := 300
+
+	noise := make([]float64, n)
+	walk := make([]float64, n)
+	trend := make([]float64, n)
+	for i := range n {
+		noise[i] = rng.NormFloat64()
+		trend[i] = 0.1*float64(i) + rng.NormFloat64()
+		walk[i] = rng.NormFloat64()
+		if i > 0 {
+			walk[i] += walk[i-1]
+		}
+	}
+
+	// Act
+	noiseADF, err := stats.ADF(noise)
+	require.NoError(t, err)
+	noiseKPSS, err := stats.KPSS(noise, false)
+	require.NoError(t, err)
+
+	walkADF, err := stats.ADF(walk)
+	require.NoError(t, err)
+	walkKPSS, err := stats.KPSS(walk, false)
+	require.NoError(t, err)
+
+	trendKPSS, err := stats.KPSS(trend, false)
+	require.NoError(t, err)
+	detrendedKPSS, err := stats.KPSS(trend, true)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Less(t, noiseADF.Statistic, noiseADF.Critical)    // no unit root
+	assert.Less(t, noiseKPSS.Statistic, noiseKPSS.Critical)  // level stationary
+	assert.Greater(t, walkADF.Statistic, walkADF.Critical)   // unit root
+	assert.Greater(t, walkKPSS.Statistic, walkKPSS.Critical) // not level stationary
+	assert.Greater(t, trendKPSS.Statistic, trendKPSS.Critical)
+	assert.Less(t, detrendedKPSS.Statistic, detrendedKPSS.Critical) // trend stationary
+	assert.Equal(t, 6, walkADF.Lags)
+}
+
+func TestStationarity_Detrend(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	values := []float64{nan, 3, 5, nan, 9}
+
+	// Act
+	detrended, err := stats.Detrend(values, true)
+	require.NoError(t, err)
+
+	demeaned, err := stats.Detrend(values, false)
+	require.NoError(t, err)
+
+	// Assert
+	assertSeries(t, []float64{nan, 0, 0, nan, 0}, detrended)
+	assertSeries(t, []float64{nan, -2.666666666666667, -0.666666666666667, nan, 3.333333333333333}, demeaned)
+}
@@ -0,0 +1,93 @@
+package unit
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/w-h-a/caus/internal/dataset"
+)
+
+func TestTransform_Differences(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	counter := []float64{0, 60, 180, 30, 90} // reset after 180
+	seasonal := []float64{1, 2, 3, 4, 6, 8}
+
+	// Act
+	rate := dataset.Rate(counter, time.Minute)
+	derivative := dataset.Derivative(counter, time.Minute)
+	diff := dataset.Diff(seasonal, 1)
+	seasonalDiff := dataset.Diff(seasonal, 3)
+
+	// Assert
+	assertSeries(t, []float64{nan, 1, 2, 0.5, 1}, rate)
+	assertSeries(t, []float64{nan, 1, 2, -2.5, 1}, derivative)
+	assertSeries(t, []float64{nan, 1, 1, 1, 2, 2}, diff)
+	assertSeries(t, []float64{nan, nan, nan, 3, 4, 5}, seasonalDiff)
+}
+
+func TestTransform_Logs(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	values := []float64{math.E, 0, -1, nan}
+
+	// Act
+	logged := dataset.Log(values)
+	logged1p := dataset.Log1p(values)
+
+	// Assert
+	assertSeries(t, []float64{1, nan, nan, nan}, logged)
+	assertSeries(t, []float64{math.Log1p(math.E), 0, nan, nan}, logged1p)
+}
+
+func TestTransform_Scaling(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	values := []float64{1, 2, nan, 3, 100}
+
+	// Act
+	zscored := dataset.ZScore([]float64{1, 2, 3, nan})
+	flat := dataset.ZScore([]float64{4, 4, 4})
+	winsorized := dataset.Winsorize(values, 0, 0.5)
+
+	// Assert
+	assertSeries(t, []float64{-1, 0, 1, nan}, zscored)
+	assertSeries(t, []float64{0, 0, 0}, flat)
+	assertSeries(t, []float64{1, 2, nan, 2.5, 2.5}, winsorized)
+}
+
+func TestTransform_Rolling(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	nan := math.NaN()
+	values := []float64{1, 2, 9, nan, 4}
+
+	// Act
+	mean := dataset.RollingMean(values, 3)
+	median := dataset.RollingMedian(values, 3)
+
+	// Assert
+	assertSeries(t, []float64{nan, nan, 4, 5.5, 6.5}, mean)
+	assertSeries(t, []float64{nan, nan, 2, 5.5, 6.5}, median)
+}
@@ -0,0 +1,184 @@
+package unit
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+)
+
+func TestVariable_ValidateSplit(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	metrics := func(mutate func(v *variable.VariableDefinition)) variable.VariableDefinition {
+		v := variable.VariableDefinition{
+			Name:         "cpu",
+			Source:       &variable.Source{Type: "metrics", Impl: "prometheus", Loc: "http://prom:9090"},
+			MetricsQuery: "sum by (pod) (rate(cpu[1m]))",
+			SplitBy:      []string{"pod"},
+		}
+		mutate(&v)
+		return v
+	}
+
+	cases := map[string]variable.VariableDefinition{
+		"": metrics(func(v *variable.VariableDefinition) { v.SeriesName = "cpu_{{pod}}" }),
+		"series_name references 'node', which is not in split_by":         metrics(func(v *variable.VariableDefinition) { v.SeriesName = "cpu_{{node}}" }),
+		"series_name has an unclosed '{{'":                                metrics(func(v *variable.VariableDefinition) { v.SeriesName = "cpu_{{pod" }),
+		"unsupported other_series 'median'. Supported: [sum avg min max]": metrics(func(v *variable.VariableDefinition) { v.OtherSeries = "median" }),
+		"series_name, max_series and other_series require split_by":       metrics(func(v *variable.VariableDefinition) { v.SplitBy, v.MaxSeries = nil, 5 }),
+	}
+
+	for expected, v := range cases {
+		// Act
+		err := v.Validate()
+
+		// Assert
+		if len(expected) == 0 {
+			assert.NoError(t, err)
+			continue
+		}
+		require.Error(t, err, expected)
+		assert.Equal(t, expected, err.Error())
+	}
+}
+
+func TestVariable_ValidateExclusion(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	start := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	grafana := &variable.Source{Type: "annotations", Impl: "grafana", Loc: "http://grafana:3000"}
+
+	valid := []variable.Exclusion{
+		{Start: start, End: start.Add(time.Hour)},
+		{Source: grafana, Tags: []string{"deploy"}, Padding: time.Minute},
+	}
+
+	invalid := map[string]variable.Exclusion{
+		"start and end are required without a source":   {Start: start},
+		"end must be after start":                       {Start: start, End: start},
+		"tags require a source":                         {Start: start, End: start.Add(time.Hour), Tags: []string{"deploy"}},
+		"start and end can't be combined with a source": {Source: grafana, Start: start},
+		"source type must be 'annotations'":             {Source: &variable.Source{Type: "metrics", Impl: "prometheus", Loc: "http://prom:9090"}},
+		"padding must not be negative":                  {Start: start, End: start.Add(time.Hour), Padding: -time.Minute},
+	}
+
+	for _, e := range valid {
+		// Act
+		err := e.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	}
+
+	for expected, e := range invalid {
+		// Act
+		err := e.Validate()
+
+		// Assert
+		require.Error(t, err, expected)
+		assert.Equal(t, expected, err.Error())
+	}
+}
+
+func TestVariable_ValidateEvents(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	events := func(impl string, q *variable.EventQueryDetails) variable.VariableDefinition {
+		return variable.VariableDefinition{
+			Name:       "deploys",
+			Source:     &variable.Source{Type: "events", Impl: impl, Loc: "events.yml"},
+			EventQuery: q,
+		}
+	}
+
+	valid := []variable.VariableDefinition{
+		events("file", &variable.EventQueryDetails{Tags: []string{"deploy"}}),
+		events("http", &variable.EventQueryDetails{Indicator: variable.IndicatorCount}),
+		events("prometheus", &variable.EventQueryDetails{Query: "kube_deployment_status_observed_generation"}),
+	}
+
+	invalid := map[string]variable.VariableDefinition{
+		"event_query is required for source type 'events'":                            events("file", nil),
+		"event_query invalid: unsupported indicator 'sum'. Supported: [binary count]": events("file", &variable.EventQueryDetails{Indicator: "sum"}),
+		"event_query invalid: query is required for impl 'prometheus'":                events("prometheus", &variable.EventQueryDetails{}),
+		"event_query invalid: tags don't apply to impl 'prometheus'":                  events("prometheus", &variable.EventQueryDetails{Query: "up", Tags: []string{"deploy"}}),
+		"event_query invalid: query only applies to impl 'prometheus'":                events("http", &variable.EventQueryDetails{Query: "up"}),
+	}
+
+	for _, v := range valid {
+		// Act
+		err := v.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	}
+
+	for expected, v := range invalid {
+		// Act
+		err := v.Validate()
+
+		// Assert
+		require.Error(t, err, expected)
+		assert.Equal(t, expected, err.Error())
+	}
+}
+
+func TestVariable_ValidateResample(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	metrics := func(r *variable.ResamplePolicy) variable.VariableDefinition {
+		return variable.VariableDefinition{
+			Name:         "cpu",
+			Source:       &variable.Source{Type: "metrics", Impl: "prometheus", Loc: "http://prom:9090"},
+			MetricsQuery: "sum(rate(cpu[1m]))",
+			Resample:     r,
+		}
+	}
+
+	valid := []variable.VariableDefinition{
+		metrics(&variable.ResamplePolicy{}),
+		metrics(&variable.ResamplePolicy{Aggregation: variable.ResampleMax, Label: variable.LabelEnd, Upsample: variable.UpsampleLinear}),
+	}
+
+	invalid := map[string]variable.VariableDefinition{
+		"resample invalid: unsupported aggregation 'p95'. Supported: [mean sum min max first last]": metrics(&variable.ResamplePolicy{Aggregation: "p95"}),
+		"resample invalid: unsupported label 'middle'. Supported: [start end]":                      metrics(&variable.ResamplePolicy{Label: "middle"}),
+		"resample invalid: unsupported upsample 'spline'. Supported: [hold linear none]":            metrics(&variable.ResamplePolicy{Upsample: "spline"}),
+		"resample doesn't apply to derived variables": {
+			Name: "ratio", Source: &variable.Source{Type: "derived"}, Expression: "a / b", Resample: &variable.ResamplePolicy{},
+		},
+	}
+
+	for _, v := range valid {
+		// Act
+		err := v.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+	}
+
+	for expected, v := range invalid {
+		// Act
+		err := v.Validate()
+
+		// Assert
+		require.Error(t, err, expected)
+		assert.Equal(t, expected, err.Error())
+	}
+}
@@ -0,0 +1,167 @@
+package unit
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	variable "github.com/w-h-a/caus/api/variable/v1alpha1"
+	noopdisc "github.com/w-h-a/caus/internal/client/discoverer/noop"
+	"github.com/w-h-a/caus/internal/client/estimator/native"
+	"github.com/w-h-a/caus/internal/client/fetcher"
+	mockfetcher "github.com/w-h-a/caus/internal/client/fetcher/mock"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+)
+
+func TestOrchestrator_WhatIf(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	// load[t] is exogenous, latency[t] = 10 + 2*load[t-1] + 0.5*load[t]
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	step := time.Minute
+	load := []float64{3, 7, 1, 4, 8, 2, 6, 5, 9, 3}
+	end := start.Add(time.Duration(len(load)-1) * step)
+
+	mockData := map[string]map[time.Time]float64{"load": {}, "latency": {}}
+	for i, l := range load {
+		ts := start.Add(time.Duration(i) * step)
+		mockData["load"][ts] = l
+		if i > 0 {
+			mockData["latency"][ts] = 10 + 2*load[i-1] + 0.5*l
+		} else {
+			mockData["latency"][ts] = 10 + 0.5*l
+		}
+	}
+
+	mFetcher := mockfetcher.NewFetcher(
+		mockfetcher.WithData(mockData),
+	)
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mFetcher},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), native.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{Name: "load", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+		{Name: "latency", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "load"}, {Id: 1, Label: "latency"}},
+		Edges: []*causal.Edge{
+			{Source: "load", Target: "latency", Type: "directed", Lag: 1},
+			{Source: "load", Target: "latency", Type: "directed", Lag: 0},
+		},
+	}
+
+	// halve the load for steps 5 and 6 only
+	doStart := start.Add(5 * step)
+	doEnd := start.Add(6 * step)
+
+	// Act
+	result, err := svc.WhatIf(context.Background(), vars, start, end, step, orchestrator.WhatIfArgs{
+		Graph: graph,
+		Interventions: []orchestrator.Intervention{
+			{Variable: "load", Op: orchestrator.InterventionScale, Value: 0.5, Start: doStart, End: doEnd},
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, result.Outcomes, 1)
+	latency := result.Outcomes[0]
+	assert.Equal(t, "latency", latency.Variable)
+
+	for i := range load {
+		expected := latency.Observed[i]
+		switch i {
+		case 5: // contemporaneous effect only
+			expected -= 0.5 * 0.5 * load[5]
+		case 6: // both the lagged and contemporaneous effect
+			expected -= 2*0.5*load[5] + 0.5*0.5*load[6]
+		case 7: // the lagged effect outlives the intervention
+			expected -= 2 * 0.5 * load[6]
+		}
+		assert.InDelta(t, expected, latency.Counterfactual[i], 1e-3, "step %d", i)
+	}
+
+	assert.Less(t, latency.Delta, 0.0)
+	assert.InDelta(t, latency.CounterfactualMean-latency.ObservedMean, latency.Delta, 1e-9)
+}
+
+func TestOrchestrator_WhatIfTransformed(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	// log(load) drives latency one step later
+	start := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	step := time.Minute
+	load := []float64{3, 7, 1, 4, 8, 2, 6, 5, 9, 3}
+	end := start.Add(time.Duration(len(load)-1) * step)
+
+	mockData := map[string]map[time.Time]float64{"load": {}, "latency": {}}
+	for i, l := range load {
+		ts := start.Add(time.Duration(i) * step)
+		mockData["load"][ts] = l
+		mockData["latency"][ts] = 10
+		if i > 0 {
+			mockData["latency"][ts] += 2 * math.Log(load[i-1])
+		}
+	}
+
+	fetchers := map[string]map[string]fetcher.Fetcher{
+		"metrics": {"mock": mockfetcher.NewFetcher(mockfetcher.WithData(mockData))},
+	}
+
+	svc := orchestrator.New(fetchers, noopdisc.NewDiscoverer(), native.NewEstimator())
+
+	vars := []variable.VariableDefinition{
+		{Name: "load", Source: &variable.Source{Type: "metrics", Impl: "mock"}, Transforms: []variable.Transform{{Type: "log"}}},
+		{Name: "latency", Source: &variable.Source{Type: "metrics", Impl: "mock"}},
+	}
+
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "load"}, {Id: 1, Label: "latency"}},
+		Edges: []*causal.Edge{{Source: "load", Target: "latency", Type: "directed", Lag: 1}},
+	}
+
+	interventions := []orchestrator.Intervention{{Variable: "load", Op: orchestrator.InterventionShift, Value: 0.5}}
+
+	// Act
+	_, refused := svc.WhatIf(context.Background(), vars, start, end, step, orchestrator.WhatIfArgs{
+		Graph:         graph,
+		Interventions: interventions,
+	})
+
+	result, err := svc.WhatIf(context.Background(), vars, start, end, step, orchestrator.WhatIfArgs{
+		Graph:            graph,
+		Interventions:    interventions,
+		Outcomes:         []string{"load", "latency"},
+		AllowTransformed: true,
+	})
+	require.NoError(t, err)
+
+	// Assert
+	require.Error(t, refused)
+	assert.Contains(t, refused.Error(), "cannot intervene on 'load', which is transformed: the intervention would apply to log(load)")
+
+	require.Len(t, result.Outcomes, 2)
+	assert.Equal(t, []string{"log"}, result.Outcomes[0].Transforms)
+	assert.InDelta(t, 0.5, result.Outcomes[0].Delta, 1e-9) // in log units
+	assert.Empty(t, result.Outcomes[1].Transforms)
+	assert.InDelta(t, 2*0.5*float64(len(load)-1)/float64(len(load)), result.Outcomes[1].Delta, 1e-3) // no effect at the first step
+}
@@ -0,0 +1,552 @@
+package unit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	causal "github.com/w-h-a/caus/api/causal/v1alpha1"
+	causalv2 "github.com/w-h-a/caus/api/causal/v1alpha2"
+	"github.com/w-h-a/caus/internal/client/columnar"
+	"github.com/w-h-a/caus/internal/client/discoverer"
+	noopdisc "github.com/w-h-a/caus/internal/client/discoverer/noop"
+	discovererv1alpha2 "github.com/w-h-a/caus/internal/client/discoverer/v1alpha2"
+	"github.com/w-h-a/caus/internal/client/estimator"
+	noopest "github.com/w-h-a/caus/internal/client/estimator/noop"
+	estimatorv1alpha2 "github.com/w-h-a/caus/internal/client/estimator/v1alpha2"
+	"github.com/w-h-a/caus/internal/client/worker"
+	"github.com/w-h-a/caus/internal/dataset"
+	"github.com/w-h-a/caus/internal/service/orchestrator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeWorker is a v1alpha2 worker that reassembles what it is streamed and
+// answers with canned results.
+type fakeWorker struct {
+	causalv2.UnimplementedCausalDiscoveryServer
+	causalv2.UnimplementedCausalEstimationServer
+	discoverParams *causalv2.DiscoverParams
+	estimateParams *causalv2.EstimateParams
+	chunks         int
+	received       *dataset.Dataset
+	fail           error
+}
+
+func (w *fakeWorker) Discover(stream grpc.ClientStreamingServer[causalv2.DiscoverRequest, causalv2.CausalGraph]) error {
+	var chunks []*causalv2.DataChunk
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if p := req.GetParams(); p != nil {
+			w.discoverParams = p
+			continue
+		}
+		chunks = append(chunks, req.GetChunk())
+	}
+	if w.fail != nil {
+		return w.fail
+	}
+
+	ds, err := decodeChunks(w.discoverParams.Schema, chunks)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	w.chunks, w.received = len(chunks), ds
+
+	return stream.SendAndClose(&causalv2.CausalGraph{
+		Nodes: []*causalv2.Node{{Id: 0, Label: "var_a"}, {Id: 1, Label: "var_b"}},
+		Edges: []*causalv2.Edge{{Source: "var_a", Target: "var_b", Type: "directed", Lag: 1, PValue: 0.01, PartialCorr: 0.8}},
+	})
+}
+
+func (w *fakeWorker) Estimate(stream grpc.ClientStreamingServer[causalv2.EstimateRequest, causalv2.EstimateResponse]) error {
+	var chunks []*causalv2.DataChunk
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if p := req.GetParams(); p != nil {
+			w.estimateParams = p
+			continue
+		}
+		chunks = append(chunks, req.GetChunk())
+	}
+
+	ds, err := decodeChunks(w.estimateParams.Schema, chunks)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	w.chunks, w.received = len(chunks), ds
+
+	return stream.SendAndClose(&causalv2.EstimateResponse{
+		Models: map[string]*causalv2.ModelInfo{
+			"var_b": {Features: []string{"var_a_lag1"}, Coefficients: []float32{2}, Intercept: 1, NObs: 9, Inference: "analytic"},
+		},
+	})
+}
+
+func startFakeWorker(t *testing.T, w *fakeWorker, opts ...grpc.ServerOption) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(opts...)
+	causalv2.RegisterCausalDiscoveryServer(srv, w)
+	causalv2.RegisterCausalEstimationServer(srv, w)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// decodeChunks reassembles the chunks a fake worker received for a call
+// described by schema, with NaN where a value is missing.
+func decodeChunks(schema *causalv2.Schema, chunks []*causalv2.DataChunk) (*dataset.Dataset, error) {
+	ds := &dataset.Dataset{
+		Columns: make([]dataset.Column, len(schema.GetColumns())),
+	}
+
+	if len(schema.GetStep()) > 0 {
+		step, err := time.ParseDuration(schema.GetStep())
+		if err != nil {
+			return nil, fmt.Errorf("schema has an invalid step: %w", err)
+		}
+		ds.Step = step
+	}
+
+	for i, name := range schema.GetColumns() {
+		ds.Columns[i] = dataset.Column{Name: name, Values: make([]float64, 0, schema.GetRows())}
+	}
+
+	rows := 0
+	for _, chunk := range chunks {
+		if len(chunk.GetColumns()) != len(ds.Columns) {
+			return nil, fmt.Errorf("chunk has %d columns, schema has %d", len(chunk.GetColumns()), len(ds.Columns))
+		}
+
+		n := len(chunk.GetTimestamps())
+		if len(ds.Columns) > 0 {
+			n = len(chunk.GetColumns()[0].GetValues())
+		}
+
+		for _, ms := range chunk.GetTimestamps() {
+			ds.Timestamps = append(ds.Timestamps, time.UnixMilli(ms).UTC())
+		}
+
+		for i, col := range chunk.GetColumns() {
+			if len(col.GetValues()) != n {
+				return nil, fmt.Errorf("column '%s' has %d values in a chunk of %d rows", ds.Columns[i].Name, len(col.GetValues()), n)
+			}
+			if len(col.GetMissing()) > 0 && len(col.GetMissing()) != n {
+				return nil, fmt.Errorf("column '%s' has %d missing flags in a chunk of %d rows", ds.Columns[i].Name, len(col.GetMissing()), n)
+			}
+			for j, v := range col.GetValues() {
+				if len(col.GetMissing()) > 0 && col.GetMissing()[j] {
+					v = math.NaN()
+				}
+				ds.Columns[i].Values = append(ds.Columns[i].Values, v)
+			}
+		}
+
+		rows += n
+	}
+
+	if int64(rows) != schema.GetRows() {
+		return nil, fmt.Errorf("received %d rows, schema has %d", rows, schema.GetRows())
+	}
+	if len(ds.Timestamps) > 0 && len(ds.Timestamps) != rows {
+		return nil, fmt.Errorf("received %d timestamps for %d rows", len(ds.Timestamps), rows)
+	}
+
+	return ds, nil
+}
+
+func workerDataset() *dataset.Dataset {
+	t0 := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	ds := &dataset.Dataset{
+		Step: 15 * time.Second,
+		Columns: []dataset.Column{
+			{Name: "var_a"},
+			{Name: "var_b"},
+		},
+	}
+	for i := 0; i < 10; i++ {
+		ds.Timestamps = append(ds.Timestamps, t0.Add(time.Duration(i)*ds.Step))
+		ds.Columns[0].Values = append(ds.Columns[0].Values, 1+float64(i)/3)
+		ds.Columns[1].Values = append(ds.Columns[1].Values, 1e-9*float64(i))
+	}
+	ds.Columns[1].Values[4] = math.NaN()
+	return ds
+}
+
+func TestColumnar_Chunks(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	ds := workerDataset()
+	var chunks []*causalv2.DataChunk
+
+	// Act
+	err := columnar.Chunks(ds, 4, func(c *causalv2.DataChunk) error {
+		chunks = append(chunks, c)
+		return nil
+	})
+	require.NoError(t, err)
+
+	roundtrip, err := decodeChunks(columnar.Schema(ds), chunks)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, chunks, 3)
+	assert.Len(t, chunks[2].Timestamps, 2)
+	assert.Equal(t, ds.Timestamps[4].UnixMilli(), chunks[1].Timestamps[0])
+	assert.Empty(t, chunks[1].Columns[0].Missing)
+	assert.Equal(t, []bool{true, false, false, false}, chunks[1].Columns[1].Missing)
+	assert.Empty(t, chunks[2].Columns[1].Missing)
+
+	assert.Equal(t, ds.Timestamps, roundtrip.Timestamps)
+	assert.Equal(t, ds.Step, roundtrip.Step)
+	assert.Equal(t, ds.Names(), roundtrip.Names())
+	for i := range ds.Columns {
+		for j, v := range ds.Columns[i].Values {
+			if math.IsNaN(v) {
+				assert.True(t, math.IsNaN(roundtrip.Columns[i].Values[j]))
+				continue
+			}
+			// typed columns are exact, unlike the 6-decimal CSV
+			assert.Equal(t, v, roundtrip.Columns[i].Values[j])
+		}
+	}
+	assert.Equal(t, 1, columnar.ChunkRows(1<<20, 0))
+	assert.Equal(t, 1024, columnar.ChunkRows(1, 17*1024))
+}
+
+func TestV1alpha2Discoverer_StreamsDataset(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	worker := &fakeWorker{}
+	addr := startFakeWorker(t, worker)
+
+	d, err := discovererv1alpha2.NewDiscoverer(
+		discoverer.WithLocation(addr),
+		discovererv1alpha2.WithChunkBytes(26*3), // 3 rows of 2 columns per chunk
+	)
+	require.NoError(t, err)
+
+	svc := orchestrator.New(nil, d, noopest.NewEstimator())
+	ds := workerDataset()
+
+	// Act
+	graph, err := svc.DiscoverDataset(context.Background(), ds, orchestrator.DiscoveryArgs{MaxLag: 2, PcAlpha: 0.05})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int32(2), worker.discoverParams.MaxLag)
+	assert.Equal(t, float32(0.05), worker.discoverParams.PcAlpha)
+	assert.True(t, proto.Equal(&causalv2.Schema{Columns: []string{"var_a", "var_b"}, Rows: 10, Step: "15s"}, worker.discoverParams.Schema))
+	assert.Equal(t, 4, worker.chunks)
+	assert.Equal(t, ds.Columns[0].Values, worker.received.Columns[0].Values)
+	assert.Equal(t, ds.Columns[1].Values[3], worker.received.Columns[1].Values[3])
+	assert.True(t, math.IsNaN(worker.received.Columns[1].Values[4]))
+
+	require.Len(t, graph.Edges, 1)
+	assert.Equal(t, &causal.Edge{Source: "var_a", Target: "var_b", Type: "directed", Lag: 1, PValue: 0.01, PartialCorr: 0.8}, graph.Edges[0])
+	assert.Equal(t, &causal.Window{Start: "2023-10-01T10:00:00Z", End: "2023-10-01T10:02:15Z", Step: "15s"}, graph.Window)
+}
+
+func TestV1alpha2Discoverer_WorkerError(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	addr := startFakeWorker(t, &fakeWorker{fail: status.Error(codes.Internal, "Python error: singular matrix")})
+
+	d, err := discovererv1alpha2.NewDiscoverer(discoverer.WithLocation(addr))
+	require.NoError(t, err)
+
+	// Act
+	_, err = d.(discoverer.DatasetDiscoverer).DiscoverDataset(context.Background(), workerDataset(), &causal.DiscoverRequest{})
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.ErrorContains(t, err, "Python error: singular matrix")
+}
+
+func TestV1alpha2Estimator_StreamsDataset(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	worker := &fakeWorker{}
+	addr := startFakeWorker(t, worker)
+
+	e, err := estimatorv1alpha2.NewEstimator(estimator.WithLocation(addr))
+	require.NoError(t, err)
+
+	svc := orchestrator.New(nil, noopdisc.NewDiscoverer(), e)
+	ds := workerDataset()
+
+	graph := &causal.CausalGraph{
+		Nodes: []*causal.Node{{Id: 0, Label: "var_a"}, {Id: 1, Label: "var_b"}},
+		Edges: []*causal.Edge{{Source: "var_a", Target: "var_b", Type: "directed", Lag: 1}},
+	}
+
+	// Act
+	rsp, err := svc.EstimateDataset(context.Background(), ds, orchestrator.EstimateArgs{
+		Graph:           graph,
+		ConfidenceLevel: 0.9,
+		Bootstrap:       orchestrator.BootstrapArgs{Samples: 100, Seed: 7},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, worker.chunks)
+	assert.Equal(t, float32(0.9), worker.estimateParams.ConfidenceLevel)
+	assert.True(t, proto.Equal(&causalv2.Bootstrap{Samples: 100, Seed: 7}, worker.estimateParams.Bootstrap))
+	require.Len(t, worker.estimateParams.Graph.Edges, 1)
+	assert.Equal(t, "var_b", worker.estimateParams.Graph.Edges[0].Target)
+
+	require.Contains(t, rsp.Models, "var_b")
+	assert.Equal(t, []float32{2}, rsp.Models["var_b"].Coefficients)
+	assert.Equal(t, int32(9), rsp.Models["var_b"].NObs)
+	assert.Equal(t, "15s", rsp.Window.Step)
+	assert.Len(t, rsp.Nodes, 2)
+}
+
+// selfSignedCert writes a self-signed certificate for 127.0.0.1 to dir and
+// returns the paths of its PEM certificate and key.
+func selfSignedCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "caus-worker"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certPath, keyPath
+}
+
+func TestWorker_TLSAndToken(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	certPath, keyPath := selfSignedCert(t, t.TempDir())
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	require.NoError(t, err)
+
+	var auth []string
+	addr := startFakeWorker(t, &fakeWorker{},
+		grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			md, _ := metadata.FromIncomingContext(ss.Context())
+			auth = md.Get("authorization")
+			return handler(srv, ss)
+		}),
+	)
+
+	secure, err := discovererv1alpha2.NewDiscoverer(
+		discoverer.WithLocation(addr),
+		discoverer.WithTLS(worker.TLS{CAFile: certPath}),
+		discoverer.WithToken("s3cr3t"),
+	)
+	require.NoError(t, err)
+
+	plaintext, err := discovererv1alpha2.NewDiscoverer(discoverer.WithLocation(addr))
+	require.NoError(t, err)
+
+	// Act
+	graph, err := secure.(discoverer.DatasetDiscoverer).DiscoverDataset(context.Background(), workerDataset(), &causal.DiscoverRequest{})
+	require.NoError(t, err)
+
+	_, plaintextErr := plaintext.(discoverer.DatasetDiscoverer).DiscoverDataset(context.Background(), workerDataset(), &causal.DiscoverRequest{})
+
+	// Assert
+	assert.Len(t, graph.Edges, 1)
+	assert.Equal(t, []string{"Bearer s3cr3t"}, auth)
+	assert.Equal(t, codes.Unavailable, status.Code(plaintextErr))
+}
+
+func TestWorker_TokenOverLoopback(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	var auth []string
+	addr := startFakeWorker(t, &fakeWorker{},
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			md, _ := metadata.FromIncomingContext(ss.Context())
+			auth = md.Get("authorization")
+			return handler(srv, ss)
+		}),
+	)
+
+	// Act
+	d, err := discovererv1alpha2.NewDiscoverer(
+		discoverer.WithLocation("dns:///"+addr),
+		discoverer.WithToken("s3cr3t"),
+	)
+	require.NoError(t, err)
+
+	_, err = d.(discoverer.DatasetDiscoverer).DiscoverDataset(context.Background(), workerDataset(), &causal.DiscoverRequest{})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"Bearer s3cr3t"}, auth)
+}
+
+func TestWorker_DialErrors(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	invalid := map[string][]discoverer.Option{
+		"worker address is required":                         {discoverer.WithLocation("")},
+		"worker client cert and key must be set together":    {discoverer.WithLocation("localhost:50051"), discoverer.WithTLS(worker.TLS{CertFile: "cert.pem"})},
+		"worker CA '" + notPEM + "' has no PEM certificates": {discoverer.WithLocation("localhost:50051"), discoverer.WithTLS(worker.TLS{CAFile: notPEM})},
+		"retries must not be negative":                       {discoverer.WithLocation("localhost:50051"), discoverer.WithRetry(worker.Retry{Retries: -1})},
+		"refusing to send the worker token to 'worker:50051' in cleartext: enable worker TLS or reach the worker over loopback": {
+			discoverer.WithLocation("worker:50051"), discoverer.WithToken("s3cr3t"),
+		},
+		"refusing to send the worker token to 'dns:///10.0.0.7:50051' in cleartext: enable worker TLS or reach the worker over loopback": {
+			discoverer.WithLocation("dns:///10.0.0.7:50051"), discoverer.WithToken("s3cr3t"),
+		},
+		"max backoff must not be below the initial backoff": {
+			discoverer.WithLocation("localhost:50051"), discoverer.WithRetry(worker.Retry{InitialBackoff: time.Second, MaxBackoff: time.Millisecond}),
+		},
+	}
+
+	for expected, opts := range invalid {
+		// Act
+		_, err := discovererv1alpha2.NewDiscoverer(opts...)
+
+		// Assert
+		require.Error(t, err, expected)
+		assert.Equal(t, expected, err.Error())
+	}
+}
+
+func TestWorker_CallRetries(t *testing.T) {
+	if len(os.Getenv("INTEGRATION")) > 0 {
+		t.Log("SKIPPING UNIT TEST")
+		return
+	}
+
+	// Arrange
+	retry := worker.Retry{Retries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	failing := func(codeSeq ...codes.Code) (func(ctx context.Context) error, *int) {
+		attempts := 0
+		return func(ctx context.Context) error {
+			attempts++
+			if attempts > len(codeSeq) {
+				return nil
+			}
+			return status.Error(codeSeq[attempts-1], "worker says no")
+		}, &attempts
+	}
+
+	recovers, recoversAttempts := failing(codes.Unavailable, codes.Unavailable)
+	down, downAttempts := failing(codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable)
+	broken, brokenAttempts := failing(codes.Internal)
+
+	var deadline time.Time
+	var hasDeadline bool
+
+	// Act
+	recoversErr := worker.Call(context.Background(), time.Minute, retry, recovers)
+	downErr := worker.Call(context.Background(), time.Minute, retry, down)
+	brokenErr := worker.Call(context.Background(), time.Minute, retry, broken)
+	_ = worker.Call(context.Background(), time.Minute, retry, func(ctx context.Context) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, recoversErr)
+	assert.Equal(t, 3, *recoversAttempts)
+
+	assert.Equal(t, codes.Unavailable, status.Code(downErr))
+	assert.Equal(t, 4, *downAttempts)
+
+	assert.Equal(t, codes.Internal, status.Code(brokenErr))
+	assert.Equal(t, 1, *brokenAttempts)
+
+	require.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0c\x63\x61usal.proto\x12\x0f\x63\x61usal.v1alpha1\"F\n\x0f\x44iscoverRequest\x12\x10\n\x08\x63sv_data\x18\x01 \x01(\t\x12\x0f\n\x07max_lag\x18\x02 \x01(\x05\x12\x10\n\x08pc_alpha\x18\x03 \x01(\x02\"Y\n\x0b\x43\x61usalGraph\x12$\n\x05nodes\x18\x01 \x03(\x0b\x32\x15.causal.v1alpha1.Node\x12$\n\x05\x65\x64ges\x18\x02 \x03(\x0b\x32\x15.causal.v1alpha1.Edge\"!\n\x04Node\x12\n\n\x02id\x18\x01 \x01(\x05\x12\r\n\x05label\x18\x02 \x01(\t\"A\n\x04\x45\x64ge\x12\x0e\n\x06source\x18\x01 \x01(\t\x12\x0e\n\x06target\x18\x02 \x01(\t\x12\x0c\n\x04type\x18\x03 \x01(\t\x12\x0b\n\x03lag\x18\x04 \x01(\x05\"P\n\x0f\x45stimateRequest\x12\x10\n\x08\x63sv_data\x18\x01 \x01(\t\x12+\n\x05graph\x18\x02 \x01(\x0b\x32\x1c.causal.v1alpha1.CausalGraph\"\x9c\x01\n\x10\x45stimateResponse\x12=\n\x06models\x18\x02 \x03(\x0b\x32-.causal.v1alpha1.EstimateResponse.ModelsEntry\x1aI\n\x0bModelsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.causal.v1alpha1.ModelInfo:\x02\x38\x01\"F\n\tModelInfo\x12\x10\n\x08\x66\x65\x61tures\x18\x01 \x03(\t\x12\x14\n\x0c\x63oefficients\x18\x02 \x03(\x02\x12\x11\n\tintercept\x18\x03 \x01(\x02\x32_\n\x0f\x43\x61usalDiscovery\x12L\n\x08\x44iscover\x12 .causal.v1alpha1.DiscoverRequest\x1a\x1c.causal.v1alpha1.CausalGraph\"\x00\x32\x65\n\x10\x43\x61usalEstimation\x12Q\n\x08\x45stimate\x12 .causal.v1alpha1.EstimateRequest\x1a!.causal.v1alpha1.EstimateResponse\"\x00\x42+Z)github.com/w-h-a/caus/api/causal/v1alpha1b\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0c\x63\x61usal.proto\x12\x0f\x63\x61usal.v1alpha1\"V\n\x0f\x44iscoverRequest\x12\x10\n\x08\x63sv_data\x18\x01 \x01(\t\x12\x0f\n\x07max_lag\x18\x02 \x01(\x05\x12\x10\n\x08pc_alpha\x18\x03 \x01(\x02\x12\x0e\n\x06\x62reaks\x18\x04 \x03(\x05\"\x82\x01\n\x0b\x43\x61usalGraph\x12$\n\x05nodes\x18\x01 \x03(\x0b\x32\x15.causal.v1alpha1.Node\x12$\n\x05\x65\x64ges\x18\x02 \x03(\x0b\x32\x15.causal.v1alpha1.Edge\x12\'\n\x06window\x18\x03 \x01(\x0b\x32\x17.causal.v1alpha1.Window\"2\n\x06Window\x12\r\n\x05start\x18\x01 \x01(\t\x12\x0b\n\x03\x65nd\x18\x02 \x01(\t\x12\x0c\n\x04step\x18\x03 \x01(\t\"5\n\x04Node\x12\n\n\x02id\x18\x01 \x01(\x05\x12\r\n\x05label\x18\x02 \x01(\t\x12\x12\n\ntransforms\x18\x03 \x03(\t\"{\n\x04\x45\x64ge\x12\x0e\n\x06source\x18\x01 \x01(\t\x12\x0e\n\x06target\x18\x02 \x01(\t\x12\x0c\n\x04type\x18\x03 \x01(\t\x12\x0b\n\x03lag\x18\x04 \x01(\x05\x12\x11\n\tstatistic\x18\x05 \x01(\x02\x12\x0f\n\x07p_value\x18\x06 \x01(\x02\x12\x14\n\x0cpartial_corr\x18\x07 \x01(\x02\"\xa9\x01\n\x0f\x45stimateRequest\x12\x10\n\x08\x63sv_data\x18\x01 \x01(\t\x12+\n\x05graph\x18\x02 \x01(\x0b\x32\x1c.causal.v1alpha1.CausalGraph\x12\x18\n\x10\x63onfidence_level\x18\x03 \x01(\x02\x12-\n\tbootstrap\x18\x04 \x01(\x0b\x32\x1a.causal.v1alpha1.Bootstrap\x12\x0e\n\x06\x62reaks\x18\x05 \x03(\x05\">\n\tBootstrap\x12\x0f\n\x07samples\x18\x01 \x01(\x05\x12\x12\n\nblock_size\x18\x02 \x01(\x05\x12\x0c\n\x04seed\x18\x03 \x01(\x04\"\xeb\x01\n\x10\x45stimateResponse\x12=\n\x06models\x18\x02 \x03(\x0b\x32-.causal.v1alpha1.EstimateResponse.ModelsEntry\x12\'\n\x06window\x18\x03 \x01(\x0b\x32\x17.causal.v1alpha1.Window\x12$\n\x05nodes\x18\x04 \x03(\x0b\x32\x15.causal.v1alpha1.Node\x1aI\n\x0bModelsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.causal.v1alpha1.ModelInfo:\x02\x38\x01\"\x95\x02\n\tModelInfo\x12\x10\n\x08\x66\x65\x61tures\x18\x01 \x03(\t\x12\x14\n\x0c\x63oefficients\x18\x02 \x03(\x02\x12\x11\n\tintercept\x18\x03 \x01(\x02\x12\x17\n\x0fstandard_errors\x18\x04 \x03(\x02\x12\x14\n\x0ct_statistics\x18\x05 \x03(\x02\x12\x10\n\x08p_values\x18\x06 \x03(\x02\x12\x10\n\x08\x63i_lower\x18\x07 \x03(\x02\x12\x10\n\x08\x63i_upper\x18\x08 \x03(\x02\x12\x11\n\tr_squared\x18\t \x01(\x02\x12\x19\n\x11residual_variance\x18\n \x01(\x02\x12\r\n\x05n_obs\x18\x0b \x01(\x05\x12\x11\n\tinference\x18\x0c \x01(\t\x12\x18\n\x10\x63onfidence_level\x18\r \x01(\x02\x32_\n\x0f\x43\x61usalDiscovery\x12L\n\x08\x44iscover\x12 .causal.v1alpha1.DiscoverRequest\x1a\x1c.causal.v1alpha1.CausalGraph\"\x00\x32\x65\n\x10\x43\x61usalEstimation\x12Q\n\x08\x45stimate\x12 .causal.v1alpha1.EstimateRequest\x1a!.causal.v1alpha1.EstimateResponse\"\x00\x42+Z)github.com/w-h-a/caus/api/causal/v1alpha1b\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -35,23 +35,27 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_ESTIMATERESPONSE_MODELSENTRY']._loaded_options = None
   _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_options = b'8\001'
   _globals['_DISCOVERREQUEST']._serialized_start=33
-  _globals['_DISCOVERREQUEST']._serialized_end=103
-  _globals['_CAUSALGRAPH']._serialized_start=105
-  _globals['_CAUSALGRAPH']._serialized_end=194
-  _globals['_NODE']._serialized_start=196
-  _globals['_NODE']._serialized_end=229
-  _globals['_EDGE']._serialized_start=231
-  _globals['_EDGE']._serialized_end=296
-  _globals['_ESTIMATEREQUEST']._serialized_start=298
-  _globals['_ESTIMATEREQUEST']._serialized_end=378
-  _globals['_ESTIMATERESPONSE']._serialized_start=381
-  _globals['_ESTIMATERESPONSE']._serialized_end=537
-  _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_start=464
-  _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_end=537
-  _globals['_MODELINFO']._serialized_start=539
-  _globals['_MODELINFO']._serialized_end=609
-  _globals['_CAUSALDISCOVERY']._serialized_start=611
-  _globals['_CAUSALDISCOVERY']._serialized_end=706
-  _globals['_CAUSALESTIMATION']._serialized_start=708
-  _globals['_CAUSALESTIMATION']._serialized_end=809
+  _globals['_DISCOVERREQUEST']._serialized_end=119
+  _globals['_CAUSALGRAPH']._serialized_start=122
+  _globals['_CAUSALGRAPH']._serialized_end=252
+  _globals['_WINDOW']._serialized_start=254
+  _globals['_WINDOW']._serialized_end=304
+  _globals['_NODE']._serialized_start=306
+  _globals['_NODE']._serialized_end=359
+  _globals['_EDGE']._serialized_start=361
+  _globals['_EDGE']._serialized_end=484
+  _globals['_ESTIMATEREQUEST']._serialized_start=487
+  _globals['_ESTIMATEREQUEST']._serialized_end=656
+  _globals['_BOOTSTRAP']._serialized_start=658
+  _globals['_BOOTSTRAP']._serialized_end=720
+  _globals['_ESTIMATERESPONSE']._serialized_start=723
+  _globals['_ESTIMATERESPONSE']._serialized_end=958
+  _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_start=885
+  _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_end=958
+  _globals['_MODELINFO']._serialized_start=961
+  _globals['_MODELINFO']._serialized_end=1238
+  _globals['_CAUSALDISCOVERY']._serialized_start=1240
+  _globals['_CAUSALDISCOVERY']._serialized_end=1335
+  _globals['_CAUSALESTIMATION']._serialized_start=1337
+  _globals['_CAUSALESTIMATION']._serialized_end=1438
 # @@protoc_insertion_point(module_scope)
@@ -5,26 +5,126 @@ import json
 import io
 import logging
 from concurrent import futures
-from sklearn.linear_model import LinearRegression
+import statsmodels.api as sm
+from scipy import stats
 
 import grpc
 import causal_pb2 as pb
 import causal_pb2_grpc
+from v1alpha2 import causal_pb2 as pb2
+from v1alpha2 import causal_pb2_grpc as causal_pb2_grpc_v2
 
 from tigramite import data_processing as pp
 from tigramite.pcmci import PCMCI
 from tigramite.independence_tests.parcorr import ParCorr
 
-def perform_causal_discovery(csv_data_string: str, max_lag: int, pc_alpha: float) -> pb.CausalGraph:
+# tigramite link notation -> Edge.type. '<--' is the same link as '-->' read
+# from the other end, so it is skipped.
+EDGE_TYPES = {
+    '-->': "directed",
+    'o-o': "undirected",
+    '<->': "bidirected",
+    'x-x': "conflicting",
+}
+
+# Value standing in for the empty (missing) cells of the request CSV, which
+# tigramite excludes from every test that would touch them.
+MISSING_FLAG = 999999999.0
+
+# MCI significance level a link must reach to be kept in the graph. pc_alpha
+# only selects the parents PC1 conditions on, and None (pc_alpha 0 in the
+# request) lets tigramite pick it per variable.
+ALPHA_LEVEL = 0.05
+
+def mci_dof(n_rows: int, tau_max: int, parents: dict, i: int, j: int, tau: int) -> int:
+    """
+    Degrees of freedom of the MCI test of X_i(t-tau) -> X_j(t): the samples
+    left after cutting 2*tau_max rows, minus x, y and the conditioning set
+    (the parents of j plus the parents of i shifted by tau).
+    """
+    conds = {p for p in parents.get(j, []) if p != (i, -tau)}
+    for (k, lag) in parents.get(i, []):
+        shifted = (k, lag - tau)
+        if -shifted[1] <= 2 * tau_max:
+            conds.add(shifted)
+    return n_rows - 2 * tau_max - 2 - len(conds)
+
+def parcorr_statistic(val: float, dof: int) -> float:
+    """
+    t statistic of a partial correlation, as used for its analytic p-value.
+    """
+    if dof < 1:
+        return 0.0
+    val = max(-1 + 1e-12, min(1 - 1e-12, val))
+    return float(val * np.sqrt(dof / (1 - val * val)))
+
+def read_chunks(schema, chunks) -> pd.DataFrame:
+    """
+    Reassembles the columnar chunks of a v1alpha2 call into one DataFrame,
+    with NaN where a value is missing.
+    """
+    columns = [[] for _ in schema.columns]
+    rows = 0
+    for chunk in chunks:
+        if len(chunk.columns) != len(schema.columns):
+            raise ValueError(f"chunk has {len(chunk.columns)} columns, schema has {len(schema.columns)}")
+        n = len(chunk.columns[0].values) if chunk.columns else len(chunk.timestamps)
+        for i, column in enumerate(chunk.columns):
+            values = np.array(column.values, dtype=np.float64)
+            if len(values) != n:
+                raise ValueError(f"column '{schema.columns[i]}' has {len(values)} values in a chunk of {n} rows")
+            if len(column.missing) > 0:
+                values[np.array(column.missing, dtype=bool)] = np.nan
+            columns[i].append(values)
+        rows += n
+    if rows != schema.rows:
+        raise ValueError(f"received {rows} rows, schema has {schema.rows}")
+    data = {name: np.concatenate(parts) if parts else np.array([], dtype=np.float64) for name, parts in zip(schema.columns, columns)}
+    return pd.DataFrame(data, columns=list(schema.columns))
+
+def split_stream(request_iterator):
+    """
+    Splits a v1alpha2 request stream into its leading params message and the
+    chunks that follow it.
+    """
+    first = next(request_iterator, None)
+    if first is None or first.WhichOneof('payload') != 'params':
+        raise ValueError("the first message of the stream must carry the params")
+
+    def chunks():
+        for request in request_iterator:
+            if request.WhichOneof('payload') != 'chunk':
+                raise ValueError("params must only be sent once, before the chunks")
+            yield request.chunk
+
+    return first.params, chunks()
+
+def split_runs(values: np.ndarray, breaks) -> list:
+    """
+    Splits the rows at the breaks, where an excluded window starts or ends,
+    into the runs that no lagged sample may reach across.
+    """
+    bounds = [0] + sorted(b for b in breaks if 0 < b < len(values)) + [len(values)]
+    return [values[lo:hi] for lo, hi in zip(bounds, bounds[1:]) if hi > lo]
+
+def perform_causal_discovery(raw_data: pd.DataFrame, max_lag: int, pc_alpha: float, breaks=(), msgs=pb):
     """
-    Takes CSV, runs PCMCI, and returns a pb.CausalGraph *struct*.
+    Runs PCMCI on the data and returns a CausalGraph of the msgs module (the
+    v1alpha1 or v1alpha2 messages). Each run between the breaks is its own
+    dataset, so no lagged sample reaches across an excluded window.
     """
     try:
-        # 1. Read data
-        raw_data = pd.read_csv(io.StringIO(csv_data_string))
+        # 1. Prepare data
+        if max_lag <= 0:
+            max_lag = 3 # default
+
         labels = raw_data.columns.tolist()
-        data_values_float = raw_data.values.astype(np.float64)
-        dataframe = pp.DataFrame(data_values_float, var_names=labels)
+        data_values_float = raw_data.fillna(MISSING_FLAG).values.astype(np.float64)
+        runs = [r for r in split_runs(data_values_float, breaks) if len(r) > 2 * max_lag] or [data_values_float]
+        if len(runs) == 1:
+            dataframe = pp.DataFrame(runs[0], var_names=labels, missing_flag=MISSING_FLAG)
+        else:
+            dataframe = pp.DataFrame(dict(enumerate(runs)), var_names=labels, missing_flag=MISSING_FLAG, analysis_mode='multiple')
 
         # 2. Initialize PCMCI
         parcorr = ParCorr(significance='analytic')
@@ -34,43 +134,97 @@ def perform_causal_discovery(csv_data_string: str, max_lag: int, pc_alpha: float
         run_alpha = None
         if pc_alpha > 0:
             run_alpha = pc_alpha
-        if max_lag <= 0:
-            max_lag = 3 # default
 
-        logging.info(f"Running PCMCI with max_lag={max_lag} and pc_alpha={run_alpha}")
-        results = pcmci.run_pcmci(tau_max=max_lag, pc_alpha=run_alpha)
+        logging.info(f"Running PCMCI with max_lag={max_lag}, pc_alpha={run_alpha} and alpha_level={ALPHA_LEVEL} on {len(runs)} run(s)")
+        results = pcmci.run_pcmci(tau_max=max_lag, pc_alpha=run_alpha, alpha_level=ALPHA_LEVEL)
         
         # 4. Build the response
         graph_matrix = results['graph']
-        pb_nodes = [pb.Node(id=i, label=label) for i, label in enumerate(labels)]
+        val_matrix = results['val_matrix']
+        p_matrix = results['p_matrix']
+        parents = getattr(pcmci, 'all_parents', None) or {j: [] for j in range(len(labels))}
+        # every run loses its first 2*max_lag rows, mci_dof cuts them once
+        n_rows = sum(len(r) for r in runs) - 2 * max_lag * (len(runs) - 1)
+        pb_nodes = [msgs.Node(id=i, label=label) for i, label in enumerate(labels)]
         pb_edges = []
         for i in range(len(labels)):      # Source
             for j in range(len(labels)):  # Target
                 for tau in range(max_lag + 1): # Lag
-                    if graph_matrix[i, j, tau] == '-->':
-                        pb_edges.append(pb.Edge(
-                            source=labels[i],
-                            target=labels[j],
-                            type="directed",
-                            lag=tau
-                        ))
+                    link = graph_matrix[i, j, tau]
+                    edge_type = EDGE_TYPES.get(link)
+                    if edge_type is None:
+                        continue
+                    # symmetric contemporaneous links appear at [i, j, 0] and [j, i, 0]
+                    if tau == 0 and edge_type != "directed" and j < i:
+                        continue
+                    val = float(val_matrix[i, j, tau])
+                    dof = mci_dof(n_rows, max_lag, parents, i, j, tau)
+                    pb_edges.append(msgs.Edge(
+                        source=labels[i],
+                        target=labels[j],
+                        type=edge_type,
+                        lag=tau,
+                        statistic=parcorr_statistic(val, dof),
+                        p_value=float(p_matrix[i, j, tau]),
+                        partial_corr=val,
+                    ))
         
-        # 5. Return the full pb.CausalGraph struct
-        return pb.CausalGraph(nodes=pb_nodes, edges=pb_edges)
+        # 5. Return the full CausalGraph struct
+        return msgs.CausalGraph(nodes=pb_nodes, edges=pb_edges)
 
     except Exception as e:
         logging.error(f"Causal discovery failed: {e}")
         raise
 
-def perform_estimation(csv_data: str, graph_proto: pb.CausalGraph) -> dict[str, pb.ModelInfo]:
+def block_bootstrap(X: pd.DataFrame, y: pd.Series, fit, samples: int, block_size: int, level: float, seed: int):
+    """
+    Moving block bootstrap of the OLS residuals, added back onto the fitted
+    values. A seed of 0 picks a fresh one. Returns standard errors, p-values
+    (normal approximation) and percentile intervals per feature.
+    """
+    n = len(y)
+    if block_size <= 0:
+        block_size = max(1, int(round(n ** (1.0 / 3.0))))
+    block_size = min(block_size, n)
+
+    rng = np.random.default_rng(seed if seed > 0 else None)
+    Xc = sm.add_constant(X, has_constant='add').values
+    fitted = fit.fittedvalues.values
+    resid = fit.resid.values
+
+    draws = []
+    for _ in range(samples):
+        idx = []
+        while len(idx) < n:
+            start = rng.integers(0, n - block_size + 1)
+            idx.extend(range(start, start + block_size))
+        idx = np.array(idx[:n])
+        y_star = fitted + resid[idx]
+        beta, *_ = np.linalg.lstsq(Xc, y_star, rcond=None)
+        draws.append(beta[1:])
+
+    draws = np.array(draws)
+    se = draws.std(axis=0, ddof=1)
+    params = fit.params.values[1:]
+    with np.errstate(divide='ignore', invalid='ignore'):
+        z = np.abs(params / se)
+    pvalues = 2 * stats.norm.sf(z)
+    tail = (1 - level) / 2
+    lower = np.quantile(draws, tail, axis=0)
+    upper = np.quantile(draws, 1 - tail, axis=0)
+
+    return se, pvalues, lower, upper
+
+def perform_estimation(df: pd.DataFrame, graph_proto, confidence_level: float, bootstrap, breaks=(), msgs=pb) -> dict:
     """
-    Fits SCM.
+    Fits SCM and reports the uncertainty of each coefficient as ModelInfo
+    messages of the msgs module. Parents are lagged within the runs between
+    the breaks, so no row pairs values from either side of an excluded window.
     """
     try:
-        # 1. Load Data
-        df = pd.read_csv(io.StringIO(csv_data))
-        df = df.fillna(0)
-        
+        if confidence_level <= 0 or confidence_level >= 1:
+            confidence_level = 0.95 # default
+
         # 2. Parse Graph into Parents Lookup
         parents = {col: [] for col in df.columns}
         for edge in graph_proto.edges:
@@ -79,7 +233,8 @@ def perform_estimation(csv_data: str, graph_proto: pb.CausalGraph) -> dict[str,
                 parents[edge.target].append((edge.source, edge.lag))
 
         # 3. Fit SCM
-        models = {}
+        pb_models = {}
+        run = np.searchsorted(sorted(breaks), np.arange(len(df)), side='right')
         
         for node in df.columns:
             node_parents = parents[node]
@@ -91,7 +246,7 @@ def perform_estimation(csv_data: str, graph_proto: pb.CausalGraph) -> dict[str,
             
             for p_name, p_lag in node_parents:
                 if p_lag >= 0:
-                    X_features.append(df[p_name].shift(p_lag))
+                    X_features.append(df[p_name].groupby(run).shift(p_lag))
                     feature_names.append(f"{p_name}_lag{p_lag}")
             
             if not X_features:
@@ -101,23 +256,42 @@ def perform_estimation(csv_data: str, graph_proto: pb.CausalGraph) -> dict[str,
             X.columns = feature_names
             y = df[node]
             
-            valid_idx = X.dropna().index
+            # drop the lag warm-up rows and any row with a missing value
+            valid_idx = pd.concat([X, y], axis=1).dropna().index
             X = X.loc[valid_idx]
             y = y.loc[valid_idx]
             
-            model = LinearRegression()
-            model.fit(X, y)
+            fit = sm.OLS(y, sm.add_constant(X, has_constant='add')).fit()
 
-            logging.info(f"Model for {node}: Coeffs={model.coef_} Intercept={model.intercept_} Features={feature_names}")
-            models[node] = { "model": model, "features": feature_names }
+            logging.info(f"Model for {node}: Coeffs={fit.params.values[1:]} Intercept={fit.params.values[0]} Features={feature_names}")
 
-        # 4. Format Results
-        pb_models = {}
-        for node, info in models.items():
-            pb_models[node] = pb.ModelInfo(
-                features=info["features"],
-                coefficients=info["model"].coef_.tolist(),
-                intercept=float(info["model"].intercept_)
+            # 4. Format Results
+            ci = fit.conf_int(alpha=1 - confidence_level).values[1:]
+            se = fit.bse.values[1:]
+            tvalues = fit.tvalues.values[1:]
+            pvalues = fit.pvalues.values[1:]
+            lower, upper = ci[:, 0], ci[:, 1]
+            inference = "analytic"
+
+            if bootstrap.samples > 0:
+                se, pvalues, lower, upper = block_bootstrap(X, y, fit, bootstrap.samples, bootstrap.block_size, confidence_level, bootstrap.seed)
+                tvalues = fit.params.values[1:] / se
+                inference = "bootstrap"
+
+            pb_models[node] = msgs.ModelInfo(
+                features=feature_names,
+                coefficients=fit.params.values[1:].tolist(),
+                intercept=float(fit.params.values[0]),
+                standard_errors=np.asarray(se).tolist(),
+                t_statistics=np.asarray(tvalues).tolist(),
+                p_values=np.asarray(pvalues).tolist(),
+                ci_lower=np.asarray(lower).tolist(),
+                ci_upper=np.asarray(upper).tolist(),
+                r_squared=float(fit.rsquared),
+                residual_variance=float(fit.scale),
+                n_obs=int(fit.nobs),
+                inference=inference,
+                confidence_level=confidence_level,
             )
 
         return pb_models
@@ -131,9 +305,10 @@ class CausalDiscoveryServicer(causal_pb2_grpc.CausalDiscoveryServicer):
         try:
             logging.info("Received causal discovery request.")
             pb_graph = perform_causal_discovery(
-                request.csv_data,
+                pd.read_csv(io.StringIO(request.csv_data)),
                 request.max_lag,
-                request.pc_alpha
+                request.pc_alpha,
+                request.breaks,
             )
             logging.info("Causal discovery complete.")
             return pb_graph
@@ -149,8 +324,11 @@ class CausalEstimationServicer(causal_pb2_grpc.CausalEstimationServicer):
         try:
             logging.info(f"Received Estimation request.")
             models_map = perform_estimation(
-                request.csv_data, 
+                pd.read_csv(io.StringIO(request.csv_data)),
                 request.graph, 
+                request.confidence_level,
+                request.bootstrap,
+                request.breaks,
             )
             logging.info("Estimation complete.")
             return pb.EstimateResponse(models=models_map)
@@ -160,6 +338,48 @@ class CausalEstimationServicer(causal_pb2_grpc.CausalEstimationServicer):
             context.set_details(f"Python error: {e}")
             return pb.EstimateResponse()
 
+class CausalDiscoveryV2Servicer(causal_pb2_grpc_v2.CausalDiscoveryServicer):
+    def Discover(self, request_iterator, context):
+        try:
+            params, chunks = split_stream(request_iterator)
+            logging.info(f"Received v1alpha2 causal discovery request ({params.schema.rows} rows, {len(params.schema.columns)} columns).")
+            pb_graph = perform_causal_discovery(
+                read_chunks(params.schema, chunks),
+                params.max_lag,
+                params.pc_alpha,
+                params.schema.breaks,
+                msgs=pb2,
+            )
+            logging.info("Causal discovery complete.")
+            return pb_graph
+
+        except Exception as e:
+            logging.error(f"Error processing request: {e}")
+            context.set_code(grpc.StatusCode.INTERNAL)
+            context.set_details(f"Python error: {e}")
+            return pb2.CausalGraph()
+
+class CausalEstimationV2Servicer(causal_pb2_grpc_v2.CausalEstimationServicer):
+    def Estimate(self, request_iterator, context):
+        try:
+            params, chunks = split_stream(request_iterator)
+            logging.info(f"Received v1alpha2 estimation request ({params.schema.rows} rows, {len(params.schema.columns)} columns).")
+            models_map = perform_estimation(
+                read_chunks(params.schema, chunks),
+                params.graph,
+                params.confidence_level,
+                params.bootstrap,
+                params.schema.breaks,
+                msgs=pb2,
+            )
+            logging.info("Estimation complete.")
+            return pb2.EstimateResponse(models=models_map)
+        except Exception as e:
+            logging.error(f"Error in Estimate: {e}")
+            context.set_code(grpc.StatusCode.INTERNAL)
+            context.set_details(f"Python error: {e}")
+            return pb2.EstimateResponse()
+
 def serve():
     """Starts the gRPC server and waits for connections."""
     server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
@@ -170,6 +390,12 @@ def serve():
     causal_pb2_grpc.add_CausalEstimationServicer_to_server(
         CausalEstimationServicer(), server
     )
+    causal_pb2_grpc_v2.add_CausalDiscoveryServicer_to_server(
+        CausalDiscoveryV2Servicer(), server
+    )
+    causal_pb2_grpc_v2.add_CausalEstimationServicer_to_server(
+        CausalEstimationV2Servicer(), server
+    )
 
     port = "50051"
     server.add_insecure_port(f"[::]:{port}")
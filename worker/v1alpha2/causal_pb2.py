@@ -0,0 +1,71 @@
+# -*- coding: utf-8 -*-
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# NO CHECKED-IN PROTOBUF GENCODE
+# source: v1alpha2/causal.proto
+# Protobuf Python Version: 6.31.1
+"""Generated protocol buffer code."""
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import runtime_version as _runtime_version
+from google.protobuf import symbol_database as _symbol_database
+from google.protobuf.internal import builder as _builder
+_runtime_version.ValidateProtobufRuntimeVersion(
+    _runtime_version.Domain.PUBLIC,
+    6,
+    31,
+    1,
+    '',
+    'v1alpha2/causal.proto'
+)
+# @@protoc_insertion_point(imports)
+
+_sym_db = _symbol_database.Default()
+
+
+
+
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x15v1alpha2/causal.proto\x12\x0f\x63\x61usal.v1alpha2\"|\n\x0f\x44iscoverRequest\x12\x31\n\x06params\x18\x01 \x01(\x0b\x32\x1f.causal.v1alpha2.DiscoverParamsH\x00\x12+\n\x05\x63hunk\x18\x02 \x01(\x0b\x32\x1a.causal.v1alpha2.DataChunkH\x00\x42\t\n\x07payload\"\\\n\x0e\x44iscoverParams\x12\'\n\x06schema\x18\x01 \x01(\x0b\x32\x17.causal.v1alpha2.Schema\x12\x0f\n\x07max_lag\x18\x02 \x01(\x05\x12\x10\n\x08pc_alpha\x18\x03 \x01(\x02\"|\n\x0f\x45stimateRequest\x12\x31\n\x06params\x18\x01 \x01(\x0b\x32\x1f.causal.v1alpha2.EstimateParamsH\x00\x12+\n\x05\x63hunk\x18\x02 \x01(\x0b\x32\x1a.causal.v1alpha2.DataChunkH\x00\x42\t\n\x07payload\"\xaf\x01\n\x0e\x45stimateParams\x12\'\n\x06schema\x18\x01 \x01(\x0b\x32\x17.causal.v1alpha2.Schema\x12+\n\x05graph\x18\x02 \x01(\x0b\x32\x1c.causal.v1alpha2.CausalGraph\x12\x18\n\x10\x63onfidence_level\x18\x03 \x01(\x02\x12-\n\tbootstrap\x18\x04 \x01(\x0b\x32\x1a.causal.v1alpha2.Bootstrap\"E\n\x06Schema\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\t\x12\x0c\n\x04rows\x18\x02 \x01(\x03\x12\x0c\n\x04step\x18\x03 \x01(\t\x12\x0e\n\x06\x62reaks\x18\x04 \x03(\x03\"I\n\tDataChunk\x12\x12\n\ntimestamps\x18\x01 \x03(\x03\x12(\n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x17.causal.v1alpha2.Column\")\n\x06\x43olumn\x12\x0e\n\x06values\x18\x01 \x03(\x01\x12\x0f\n\x07missing\x18\x02 \x03(\x08\"\x82\x01\n\x0b\x43\x61usalGraph\x12$\n\x05nodes\x18\x01 \x03(\x0b\x32\x15.causal.v1alpha2.Node\x12$\n\x05\x65\x64ges\x18\x02 \x03(\x0b\x32\x15.causal.v1alpha2.Edge\x12\'\n\x06window\x18\x03 \x01(\x0b\x32\x17.causal.v1alpha2.Window\"2\n\x06Window\x12\r\n\x05start\x18\x01 \x01(\t\x12\x0b\n\x03\x65nd\x18\x02 \x01(\t\x12\x0c\n\x04step\x18\x03 \x01(\t\"5\n\x04Node\x12\n\n\x02id\x18\x01 \x01(\x05\x12\r\n\x05label\x18\x02 \x01(\t\x12\x12\n\ntransforms\x18\x03 \x03(\t\"{\n\x04\x45\x64ge\x12\x0e\n\x06source\x18\x01 \x01(\t\x12\x0e\n\x06target\x18\x02 \x01(\t\x12\x0c\n\x04type\x18\x03 \x01(\t\x12\x0b\n\x03lag\x18\x04 \x01(\x05\x12\x11\n\tstatistic\x18\x05 \x01(\x02\x12\x0f\n\x07p_value\x18\x06 \x01(\x02\x12\x14\n\x0cpartial_corr\x18\x07 \x01(\x02\">\n\tBootstrap\x12\x0f\n\x07samples\x18\x01 \x01(\x05\x12\x12\n\nblock_size\x18\x02 \x01(\x05\x12\x0c\n\x04seed\x18\x03 \x01(\x04\"\xeb\x01\n\x10\x45stimateResponse\x12=\n\x06models\x18\x01 \x03(\x0b\x32-.causal.v1alpha2.EstimateResponse.ModelsEntry\x12\'\n\x06window\x18\x02 \x01(\x0b\x32\x17.causal.v1alpha2.Window\x12$\n\x05nodes\x18\x03 \x03(\x0b\x32\x15.causal.v1alpha2.Node\x1aI\n\x0bModelsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.causal.v1alpha2.ModelInfo:\x02\x38\x01\"\x95\x02\n\tModelInfo\x12\x10\n\x08\x66\x65\x61tures\x18\x01 \x03(\t\x12\x14\n\x0c\x63oefficients\x18\x02 \x03(\x02\x12\x11\n\tintercept\x18\x03 \x01(\x02\x12\x17\n\x0fstandard_errors\x18\x04 \x03(\x02\x12\x14\n\x0ct_statistics\x18\x05 \x03(\x02\x12\x10\n\x08p_values\x18\x06 \x03(\x02\x12\x10\n\x08\x63i_lower\x18\x07 \x03(\x02\x12\x10\n\x08\x63i_upper\x18\x08 \x03(\x02\x12\x11\n\tr_squared\x18\t \x01(\x02\x12\x19\n\x11residual_variance\x18\n \x01(\x02\x12\r\n\x05n_obs\x18\x0b \x01(\x05\x12\x11\n\tinference\x18\x0c \x01(\t\x12\x18\n\x10\x63onfidence_level\x18\r \x01(\x02\x32\x61\n\x0f\x43\x61usalDiscovery\x12N\n\x08\x44iscover\x12 .causal.v1alpha2.DiscoverRequest\x1a\x1c.causal.v1alpha2.CausalGraph\"\x00(\x01\x32g\n\x10\x43\x61usalEstimation\x12S\n\x08\x45stimate\x12 .causal.v1alpha2.EstimateRequest\x1a!.causal.v1alpha2.EstimateResponse\"\x00(\x01\x42+Z)github.com/w-h-a/caus/api/causal/v1alpha2b\x06proto3')
+
+_globals = globals()
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'causal_pb2', _globals)
+if not _descriptor._USE_C_DESCRIPTORS:
+  _globals['DESCRIPTOR']._loaded_options = None
+  _globals['DESCRIPTOR']._serialized_options = b'Z)github.com/w-h-a/caus/api/causal/v1alpha2'
+  _globals['_ESTIMATERESPONSE_MODELSENTRY']._loaded_options = None
+  _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_options = b'8\001'
+  _globals['_DISCOVERREQUEST']._serialized_start=42
+  _globals['_DISCOVERREQUEST']._serialized_end=166
+  _globals['_DISCOVERPARAMS']._serialized_start=168
+  _globals['_DISCOVERPARAMS']._serialized_end=260
+  _globals['_ESTIMATEREQUEST']._serialized_start=262
+  _globals['_ESTIMATEREQUEST']._serialized_end=386
+  _globals['_ESTIMATEPARAMS']._serialized_start=389
+  _globals['_ESTIMATEPARAMS']._serialized_end=564
+  _globals['_SCHEMA']._serialized_start=566
+  _globals['_SCHEMA']._serialized_end=635
+  _globals['_DATACHUNK']._serialized_start=637
+  _globals['_DATACHUNK']._serialized_end=710
+  _globals['_COLUMN']._serialized_start=712
+  _globals['_COLUMN']._serialized_end=753
+  _globals['_CAUSALGRAPH']._serialized_start=756
+  _globals['_CAUSALGRAPH']._serialized_end=886
+  _globals['_WINDOW']._serialized_start=888
+  _globals['_WINDOW']._serialized_end=938
+  _globals['_NODE']._serialized_start=940
+  _globals['_NODE']._serialized_end=993
+  _globals['_EDGE']._serialized_start=995
+  _globals['_EDGE']._serialized_end=1118
+  _globals['_BOOTSTRAP']._serialized_start=1120
+  _globals['_BOOTSTRAP']._serialized_end=1182
+  _globals['_ESTIMATERESPONSE']._serialized_start=1185
+  _globals['_ESTIMATERESPONSE']._serialized_end=1420
+  _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_start=1347
+  _globals['_ESTIMATERESPONSE_MODELSENTRY']._serialized_end=1420
+  _globals['_MODELINFO']._serialized_start=1423
+  _globals['_MODELINFO']._serialized_end=1700
+  _globals['_CAUSALDISCOVERY']._serialized_start=1702
+  _globals['_CAUSALDISCOVERY']._serialized_end=1799
+  _globals['_CAUSALESTIMATION']._serialized_start=1801
+  _globals['_CAUSALESTIMATION']._serialized_end=1904
+# @@protoc_insertion_point(module_scope)